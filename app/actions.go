@@ -1,23 +1,35 @@
 package app
 
 import (
+	"errors"
+	"path"
+
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nnnkkk7/lazyactions/github"
 )
 
 // User action functions - triggered by keyboard shortcuts
 
+// confirm runs fn once the user confirms msg, or right away unless required
+func (a *App) confirm(required bool, msg string, fn func() tea.Cmd) tea.Cmd {
+	if !required {
+		return fn()
+	}
+	a.showConfirm = true
+	a.confirmMsg = msg
+	a.confirmFn = fn
+	return nil
+}
+
 // confirmCancelRun shows confirmation dialog for cancelling a run
 func (a *App) confirmCancelRun() tea.Cmd {
 	run, ok := a.runs.Selected()
 	if !ok || !run.IsRunning() {
 		return nil
 	}
-	a.showConfirm = true
-	a.confirmMsg = "Cancel this run?"
-	a.confirmFn = func() tea.Cmd {
-		return cancelRun(a.client, a.repo, run.ID)
-	}
-	return nil
+	return a.confirm(a.settings.confirmCancel, "Cancel this run?", func() tea.Cmd {
+		return cancelRun(a.client, a.runRepo(run.ID), run.ID)
+	})
 }
 
 // rerunWorkflow triggers a workflow rerun
@@ -26,7 +38,9 @@ func (a *App) rerunWorkflow() tea.Cmd {
 	if !ok {
 		return nil
 	}
-	return rerunWorkflow(a.client, a.repo, run.ID)
+	return a.confirm(a.settings.confirmRerun, "Rerun this workflow?", func() tea.Cmd {
+		return rerunWorkflow(a.client, a.runRepo(run.ID), run.ID)
+	})
 }
 
 // rerunFailedJobs reruns only failed jobs
@@ -35,22 +49,90 @@ func (a *App) rerunFailedJobs() tea.Cmd {
 	if !ok || !run.IsFailed() {
 		return nil
 	}
-	return rerunFailedJobs(a.client, a.repo, run.ID)
+	return a.confirm(a.settings.confirmRerunFailed, "Rerun the failed jobs?", func() tea.Cmd {
+		return rerunFailedJobs(a.client, a.runRepo(run.ID), run.ID)
+	})
 }
 
-// triggerWorkflow triggers a workflow dispatch
+// triggerWorkflow opens the ref picker for the selected workflow and loads the refs it offers
 func (a *App) triggerWorkflow() tea.Cmd {
 	wf, ok := a.workflows.Selected()
 	if !ok {
 		return nil
 	}
-	// Get workflow file name from path (e.g., ".github/workflows/ci.yml" -> "ci.yml")
-	workflowFile := wf.Path
-	if idx := len(".github/workflows/"); len(wf.Path) > idx {
-		workflowFile = wf.Path[idx:]
+	a.refPicker = newRefPicker(wf, a.state.LastRef(a.refStateKey(wf)), a.localBranch)
+	return fetchRefs(a.client, a.repo)
+}
+
+// onRefsLoaded fills the ref picker with the loaded refs.
+// On error the picker stays usable with the local, last used and typed refs.
+func (a *App) onRefsLoaded(msg RefsLoadedMsg) {
+	if a.refPicker == nil {
+		return
+	}
+	a.refPicker.loading = false
+	if msg.Err != nil {
+		a.refPicker.err = "Failed to load refs: " + msg.Err.Error()
+		return
+	}
+	if msg.TagsErr != nil {
+		a.refPicker.err = "Failed to load tags: " + msg.TagsErr.Error()
+	}
+	a.refPicker.setRefs(msg.DefaultBranch, msg.Branches, msg.Tags)
+}
+
+// selectRef remembers the chosen ref for the workflow and reads its
+// workflow_dispatch inputs at that ref. The workflow is dispatched once
+// the inputs are known (see onDispatchInputsLoaded).
+func (a *App) selectRef() tea.Cmd {
+	picker := a.refPicker
+	ref, ok := picker.selected()
+	if !ok {
+		return nil
+	}
+	a.refPicker = nil
+	a.state.SetLastRef(a.refStateKey(picker.workflow), ref)
+	return fetchDispatchInputs(a.client, a.repo, picker.workflow, ref)
+}
+
+// refStateKey returns the key under which the last used ref of wf is stored
+func (a *App) refStateKey(wf github.Workflow) string {
+	return a.repo.FullName() + ":" + wf.Path
+}
+
+// onDispatchInputsLoaded dispatches a workflow without inputs right away
+// and shows the input form for workflows that declare inputs
+func (a *App) onDispatchInputsLoaded(msg DispatchInputsLoadedMsg) tea.Cmd {
+	if msg.Err != nil {
+		if errors.Is(msg.Err, github.ErrNoWorkflowDispatch) {
+			return flashMessage(msg.Workflow.Name+" has no workflow_dispatch trigger", FlashDurationInfo)
+		}
+		a.err = msg.Err
+		return nil
 	}
-	// Trigger on default branch (main)
-	return triggerWorkflow(a.client, a.repo, workflowFile, "main", nil)
+	if len(msg.Inputs) == 0 {
+		return triggerWorkflow(a.client, a.repo, workflowFileName(msg.Workflow.Path), msg.Ref, nil)
+	}
+	a.dispatchForm = newDispatchForm(msg.Workflow, msg.Ref, msg.Inputs, msg.Environments)
+	return nil
+}
+
+// submitDispatchForm validates the dispatch form and triggers the workflow with its inputs
+func (a *App) submitDispatchForm() tea.Cmd {
+	form := a.dispatchForm
+	inputs, err := form.values()
+	if err != nil {
+		form.err = err.Error()
+		return nil
+	}
+	a.dispatchForm = nil
+	return triggerWorkflow(a.client, a.repo, workflowFileName(form.workflow.Path), form.ref, inputs)
+}
+
+// workflowFileName returns the file name of a workflow path
+// (e.g., ".github/workflows/ci.yml" -> "ci.yml")
+func workflowFileName(workflowPath string) string {
+	return path.Base(workflowPath)
 }
 
 // yankURL copies the selected run URL to clipboard
@@ -70,12 +152,18 @@ func (a *App) yankURL() tea.Cmd {
 
 // refreshAll refreshes all data
 func (a *App) refreshAll() tea.Cmd {
+	if a.dashboard != nil {
+		return a.fetchDashboardCmd()
+	}
 	a.loading = true
 	return a.fetchWorkflowsCmd()
 }
 
-// refreshCurrentWorkflow refreshes runs for the current workflow
+// refreshCurrentWorkflow refreshes runs for the current workflow, or the dashboard
 func (a *App) refreshCurrentWorkflow() tea.Cmd {
+	if a.dashboard != nil {
+		return a.fetchDashboardCmd()
+	}
 	if wf, ok := a.workflows.Selected(); ok {
 		return a.fetchRunsCmd(wf.ID)
 	}
@@ -0,0 +1,97 @@
+package app
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Helpers for strings styled with ANSI escape sequences, such as the
+// formatted log lines
+
+// highlightRange is a byte range of the text of a styled string to render
+// with style
+type highlightRange struct {
+	start, end int
+	style      lipgloss.Style
+}
+
+// highlightANSI renders the ranges of the visible text of s, a string styled
+// with ANSI escape sequences, in their style. The styles of s resume after
+// each range.
+func highlightANSI(s string, ranges []highlightRange) string {
+	var b, segment, active strings.Builder
+	pos, r := 0, 0
+	for i := 0; i < len(s); {
+		if n := ansiSeqLen(s, i); n > 0 {
+			seq := s[i : i+n]
+			trackSGR(&active, seq)
+			if segment.Len() == 0 {
+				b.WriteString(seq)
+			}
+			i += n
+			continue
+		}
+
+		inRange := r < len(ranges) && pos >= ranges[r].start
+		if inRange {
+			segment.WriteByte(s[i])
+		} else {
+			b.WriteByte(s[i])
+		}
+		pos++
+		i++
+		if inRange && pos == ranges[r].end {
+			b.WriteString(ranges[r].style.Render(segment.String()) + active.String())
+			segment.Reset()
+			r++
+		}
+	}
+	if segment.Len() > 0 {
+		b.WriteString(ranges[r].style.Render(segment.String()))
+	}
+	return b.String()
+}
+
+// stripANSI returns the visible text of s, without its escape sequences
+func stripANSI(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		if n := ansiSeqLen(s, i); n > 0 {
+			i += n
+			continue
+		}
+		b.WriteByte(s[i])
+		i++
+	}
+	return b.String()
+}
+
+// ansiSeqLen returns the length of the escape sequence at s[i], or 0 if
+// there is none: a CSI sequence such as a color, or an escape and a byte
+func ansiSeqLen(s string, i int) int {
+	if s[i] != '\x1b' || i+1 >= len(s) {
+		return 0
+	}
+	if s[i+1] != '[' {
+		return 2
+	}
+	for j := i + 2; j < len(s); j++ {
+		if s[j] >= 0x40 && s[j] <= 0x7e {
+			return j - i + 1
+		}
+	}
+	return len(s) - i
+}
+
+// trackSGR keeps in active the SGR sequences, the styles, in effect after
+// seq, so that they can be resumed after a reset
+func trackSGR(active *strings.Builder, seq string) {
+	switch {
+	case !strings.HasSuffix(seq, "m"):
+	case seq == "\x1b[m" || seq == "\x1b[0m":
+		active.Reset()
+	default:
+		active.WriteString(seq)
+	}
+}
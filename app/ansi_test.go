@@ -0,0 +1,28 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestHighlightANSI(t *testing.T) {
+	s := "\x1b[31mred error\x1b[0m done"
+	if got := stripANSI(s); got != "red error done" {
+		t.Errorf("stripANSI() = %q", got)
+	}
+
+	// The color of the line resumes after the match; tests render without colors
+	got := highlightANSI(s, []highlightRange{{4, 6, lipgloss.NewStyle()}, {10, 14, lipgloss.NewStyle()}})
+	if want := "\x1b[31mred er\x1b[31mror\x1b[0m done"; got != want {
+		t.Errorf("highlightANSI() = %q, want %q", got, want)
+	}
+}
+
+func TestWrapLines_ANSI(t *testing.T) {
+	// Escape sequences take no width, and the style carries over the break
+	got := wrapLines("\x1b[31mabcdef\x1b[0m gh", 4)
+	if want := "\x1b[31mabcd\x1b[0m\n\x1b[31mef\x1b[0m g\nh"; got != want {
+		t.Errorf("wrapLines() = %q, want %q", got, want)
+	}
+}
@@ -2,6 +2,7 @@
 package app
 
 import (
+	"fmt"
 	"strconv"
 	"strings"
 	"time"
@@ -11,6 +12,8 @@ import (
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/nnnkkk7/lazyactions/github"
+	"github.com/nnnkkk7/lazyactions/repo"
+	"github.com/nnnkkk7/lazyactions/state"
 )
 
 // Pane represents a UI pane
@@ -20,6 +23,7 @@ const (
 	WorkflowsPane Pane = iota
 	RunsPane
 	JobsPane
+	ReposPane // shown above WorkflowsPane in a workspace
 )
 
 // DetailTab represents the tab in the detail view
@@ -38,11 +42,25 @@ const (
 	LogPaneWidthRatio = 0.50
 	// WorkflowsPaneWidthRatio is the percentage of screen width for workflows pane
 	WorkflowsPaneWidthRatio = 0.20
+	// StackedLayoutWidth is the terminal width below which the auto layout stacks the panes
+	StackedLayoutWidth = 100
+	// StackedPanesRatio is the share of the height taken by the stacked panes
+	StackedPanesRatio = 0.5
+	// CompactLayoutWidth is the terminal width below which the panes collapse into one tabbed list
+	CompactLayoutWidth = 60
+	// CompactLayoutHeight is the terminal height below which the panes collapse into one tabbed list
+	CompactLayoutHeight = 24
+	// CompactDetailHeight is the minimum height of the compact layout that shows the detail panel below the list
+	CompactDetailHeight = 16
+	// NarrowPanelWidth is the panel width below which list columns are abbreviated
+	NarrowPanelWidth = 32
+	// LayoutResizeStep is the share of the screen the sidebar grows or shrinks by per key press
+	LayoutResizeStep = 0.05
 	// MinLeftPanelWidth is the minimum width for the left panel
 	MinLeftPanelWidth = 20
 	// MinTotalHeight is the minimum terminal height
 	MinTotalHeight = 10
-	// NumLeftPanels is the number of panels in the left sidebar
+	// NumLeftPanels is the number of panels in the left sidebar outside a workspace
 	NumLeftPanels = 3
 	// MinPanelHeight is the minimum height for each panel
 	MinPanelHeight = 5
@@ -66,12 +84,18 @@ const (
 	BorderWidth = 2
 	// StatusBarHeight is the height of the status bar
 	StatusBarHeight = 1
+	// StatusBarPadding is the horizontal padding of the status bar
+	StatusBarPadding = 2
 	// ItemPaddingSmall is the padding for truncated item names
 	ItemPaddingSmall = 6
 	// ItemPaddingMedium is the padding for truncated job names
 	ItemPaddingMedium = 10
 	// ContentPadding is the padding for content areas
 	ContentPadding = 4
+	// HelpKeyWidth is the minimum width of the key column of the help popup
+	HelpKeyWidth = 12
+	// HelpRuleWidth is the width of the rule under the section titles of the help popup
+	HelpRuleWidth = 34
 	// StatusAreaHeight accounts for status bar and bottom border
 	StatusAreaHeight = 2
 	// FlashDurationSuccess is the flash message duration for success
@@ -96,12 +120,30 @@ func (c *realClipboard) WriteAll(text string) error {
 type App struct {
 	// Data (using FilteredList pattern)
 	repo      github.Repository
+	remote    string        // name of the git remote repo was detected from
+	remotes   []repo.Remote // GitHub remotes offered by the remote switcher
 	workflows *FilteredList[github.Workflow]
 	runs      *FilteredList[github.Run]
 	jobs      *FilteredList[github.Job]
 
+	// Pages of runs loaded and server-side filter of the Runs pane
+	runHistory runHistory
+	viewer     string // login of the authenticated user, once actor:@me was used
+
+	// Workspace (Repositories pane)
+	workspace         []github.Repository
+	repos             *FilteredList[github.Repository]
+	repoRuns          map[github.Repository]github.Run // latest run of each repository
+	repoStatusUpdated time.Time
+	views             map[github.Repository]*repoView // state of the repositories not shown
+
+	// Dashboard of all repositories of the owner (nil when closed)
+	dashboard *dashboard
+
 	// UI state
 	focusedPane Pane
+	layout      paneLayout
+	drag        *layoutDrag // border being dragged with the mouse
 	detailTab   DetailTab
 	width       int
 	height      int
@@ -113,10 +155,18 @@ type App struct {
 
 	// Popups
 	showHelp    bool
+	help        helpOverlay
 	showConfirm bool
 	confirmMsg  string
 	confirmFn   func() tea.Cmd
 
+	// Ref picker, workflow_dispatch input form, remote switcher and command
+	// palette (nil when hidden)
+	refPicker      *refPicker
+	dispatchForm   *dispatchForm
+	remoteSwitcher *remoteSwitcher
+	palette        *commandPalette
+
 	// Filter (/key)
 	filtering   bool
 	filterInput textinput.Model
@@ -127,14 +177,38 @@ type App struct {
 	// Flash message
 	flashMsg string
 
+	// Background polling
+	poll poller
+
 	// Dependencies
-	client    github.Client
-	clipboard Clipboard
-	keys      KeyMap
+	client      github.Client
+	clipboard   Clipboard
+	keys        KeyMap
+	settings    settings
+	state       *state.State
+	localBranch string
 
 	// Fullscreen log mode
 	fullscreenLog bool
 
+	// Search of the log view (/ key in the log content)
+	logSearch logSearch
+	// Whether long log lines wrap, otherwise they scroll horizontally
+	logWrap bool
+	// Whether log lines show their number
+	logLineNumbers bool
+	// Prompt of the log line to go to (: key), nil if hidden
+	logGoto *textinput.Model
+	// Log lines selected to copy, nil outside of the visual mode (v key)
+	logVisual *logVisual
+	// Log line last gone to, where the visual mode starts if in view
+	logCursor int
+	// Groups nested in steps that are unfolded, by their first line
+	logUnfolded map[int]bool
+	// First key of a chord typed, such as the z of za, waiting for the next
+	// key; empty if none
+	keyPrefix string
+
 	// Mouse tracking
 	mouseX int
 	mouseY int
@@ -162,6 +236,23 @@ func WithRepository(repo github.Repository) Option {
 	}
 }
 
+// WithRemotes sets the GitHub remotes of the local checkout and the name of the
+// remote the repository was detected from
+func WithRemotes(remotes []repo.Remote, current string) Option {
+	return func(a *App) {
+		a.remotes = remotes
+		a.remote = current
+	}
+}
+
+// WithWorkspace sets the repositories listed in the Repositories pane.
+// The pane is shown when there are at least two.
+func WithWorkspace(repos []github.Repository) Option {
+	return func(a *App) {
+		a.workspace = repos
+	}
+}
+
 // WithClipboard sets the clipboard implementation
 func WithClipboard(cb Clipboard) Option {
 	return func(a *App) {
@@ -169,6 +260,20 @@ func WithClipboard(cb Clipboard) Option {
 	}
 }
 
+// WithState sets the persisted UI state
+func WithState(st *state.State) Option {
+	return func(a *App) {
+		a.state = st
+	}
+}
+
+// WithLocalBranch sets the branch checked out locally, offered by the ref picker
+func WithLocalBranch(branch string) Option {
+	return func(a *App) {
+		a.localBranch = branch
+	}
+}
+
 // New creates a new App instance
 func New(opts ...Option) *App {
 	ti := textinput.New()
@@ -177,26 +282,17 @@ func New(opts ...Option) *App {
 
 	s := spinner.New()
 	s.Spinner = spinner.Dot
-	s.Style = RunningStyle
 
 	a := &App{
-		workflows: NewFilteredList(func(w github.Workflow, filter string) bool {
-			return strings.Contains(strings.ToLower(w.Name), strings.ToLower(filter))
-		}),
-		runs: NewFilteredList(func(r github.Run, filter string) bool {
-			return strings.Contains(strings.ToLower(r.Branch), strings.ToLower(filter)) ||
-				strings.Contains(strings.ToLower(r.Actor), strings.ToLower(filter))
-		}),
-		jobs: NewFilteredList(func(j github.Job, filter string) bool {
-			return strings.Contains(strings.ToLower(j.Name), strings.ToLower(filter))
-		}),
-		focusedPane:     WorkflowsPane,
-		logView:         NewLogViewport(DefaultLogViewportWidth, DefaultLogViewportHeight),
-		filterInput:     ti,
-		spinner:         s,
-		keys:            DefaultKeyMap(),
-		selectedStepIdx: -1, // -1 means "All logs"
-		stepListFocused: true,
+		repos:       NewRankedList(queryScorer(repoFields)),
+		repoRuns:    map[github.Repository]github.Run{},
+		views:       map[github.Repository]*repoView{},
+		focusedPane: WorkflowsPane,
+		logView:     NewLogViewport(DefaultLogViewportWidth, DefaultLogViewportHeight),
+		filterInput: ti,
+		spinner:     s,
+		keys:        DefaultKeyMap(),
+		settings:    defaultSettings(),
 	}
 
 	for _, opt := range opts {
@@ -208,19 +304,64 @@ func New(opts ...Option) *App {
 		a.clipboard = &realClipboard{}
 	}
 
+	// Keep state in memory only if not provided
+	if a.state == nil {
+		a.state = state.New()
+	}
+
+	a.restoreLayout()
+	a.logWrap = a.state.LogWrap()
+	a.logLineNumbers = a.state.ShowLogLineNumbers()
+
+	// Styles are package-wide, the theme of the last App created applies
+	theme = a.settings.theme
+	a.spinner.Style = theme.Running
+
+	a.restoreView(a.newRepoView())
+	a.repos.SetFilter(a.settings.filters.Repositories)
+	a.repos.SetItems(a.workspace)
+	a.repos.SelectMatching(func(r github.Repository) bool { return r == a.repo })
+
 	return a
 }
 
+// newWorkflowList creates the list of the Workflows pane
+func newWorkflowList() *FilteredList[github.Workflow] {
+	return NewRankedList(queryScorer(workflowFields))
+}
+
+// newRunList creates the list of the Runs pane. The terms of its filter that
+// the API supports are also applied by the API; see runListOpts.
+func newRunList() *FilteredList[github.Run] {
+	return NewRankedList(queryScorer(runFields))
+}
+
+// newJobList creates the list of the Jobs pane
+func newJobList() *FilteredList[github.Job] {
+	return NewRankedList(queryScorer(jobFields))
+}
+
 // Init implements tea.Model
 func (a *App) Init() tea.Cmd {
 	return tea.Batch(
 		a.spinner.Tick,
 		a.fetchWorkflowsCmd(),
+		a.loadViewer(),
+		a.refreshRepoStatuses(),
+		a.schedulePoll(),
 	)
 }
 
-// Update implements tea.Model
+// Update implements tea.Model. The log view is then sized to the area View
+// renders it in, as any message may move or resize it.
 func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	model, cmd := a.update(msg)
+	a.layoutLogView()
+	return model, cmd
+}
+
+// update handles a message
+func (a *App) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
 
 	switch msg := msg.(type) {
@@ -239,14 +380,40 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		a.width = msg.Width
 		a.height = msg.Height
-		a.logView.SetSize(a.logPaneWidth(), a.logPaneHeight())
+		a.autoCompact()
+
+	case tea.FocusMsg:
+		if cmd := a.handleFocus(); cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+
+	case tea.BlurMsg:
+		a.handleBlur()
+
+	case TickMsg:
+		if cmd := a.handleTick(); cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+
+	case repoMsg:
+		// Drop results loaded for the view in use before a switch
+		if msg.repo != a.repo || msg.dashboard != (a.dashboard != nil) {
+			return a, nil
+		}
+		return a.update(msg.msg)
 
 	case WorkflowsLoadedMsg:
 		a.loading = false
 		if msg.Err != nil {
 			a.err = msg.Err
 		} else {
+			a.markUpdated()
+			prev, hadPrev := a.workflows.Selected()
 			a.workflows.SetItems(msg.Workflows)
+			a.workflows.SetTotal(msg.Total)
+			if hadPrev {
+				a.workflows.SelectMatching(func(w github.Workflow) bool { return w.ID == prev.ID })
+			}
 			if a.workflows.Len() > 0 {
 				if wf, ok := a.workflows.Selected(); ok {
 					cmds = append(cmds, a.fetchRunsCmd(wf.ID))
@@ -255,24 +422,20 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 	case RunsLoadedMsg:
-		a.loading = false
-		if msg.Err != nil {
-			a.err = msg.Err
-		} else {
-			a.runs.SetItems(msg.Runs)
-			if a.runs.Len() > 0 {
-				if run, ok := a.runs.Selected(); ok {
-					cmds = append(cmds, a.fetchJobsCmd(run.ID))
-				}
-			}
-		}
+		cmds = append(cmds, a.onRunsLoaded(msg))
 
 	case JobsLoadedMsg:
 		a.loading = false
 		if msg.Err != nil {
 			a.err = msg.Err
 		} else {
+			a.markUpdated()
+			prev, hadPrev := a.jobs.Selected()
 			a.jobs.SetItems(msg.Jobs)
+			a.jobs.SetTotal(msg.Total)
+			if hadPrev {
+				a.jobs.SelectMatching(func(j github.Job) bool { return j.ID == prev.ID })
+			}
 			if job, ok := a.jobs.Selected(); ok {
 				// GitHub API only provides logs for completed jobs
 				if job.IsCompleted() && a.parsedLogs == nil {
@@ -301,7 +464,7 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			// Don't set a.err - avoid showing error in status bar
 		} else {
-			a.parsedLogs = ParseLogs(msg.Logs)
+			a.parsedLogs = ParseJobLogs(msg.Logs, job.Steps)
 			a.updateLogViewContent()
 		}
 
@@ -329,6 +492,28 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			cmds = append(cmds, a.refreshCurrentWorkflow())
 		}
 
+	case RepoStatusLoadedMsg:
+		a.onRepoStatusLoaded(msg)
+
+	case ViewerLoadedMsg:
+		cmds = append(cmds, a.onViewerLoaded(msg))
+
+	case RunsFilterDebounceMsg:
+		cmds = append(cmds, a.onRunsFilterDebounce(msg))
+
+	case DashboardLoadedMsg:
+		if cmd := a.onDashboardLoaded(msg); cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+
+	case RefsLoadedMsg:
+		a.onRefsLoaded(msg)
+
+	case DispatchInputsLoadedMsg:
+		if cmd := a.onDispatchInputsLoaded(msg); cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+
 	case WorkflowTriggeredMsg:
 		if msg.Err != nil {
 			a.err = msg.Err
@@ -337,6 +522,9 @@ func (a *App) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			cmds = append(cmds, a.refreshCurrentWorkflow())
 		}
 
+	case FlashMsg:
+		a.flashMsg = msg.Message
+
 	case FlashClearMsg:
 		a.flashMsg = ""
 
@@ -355,69 +543,65 @@ func (a *App) View() string {
 		return "Loading..."
 	}
 
-	if a.fullscreenLog {
-		return a.renderFullscreenLog()
+	// The palette opens over any view
+	if a.palette != nil {
+		return a.renderPalette()
 	}
 
 	if a.showHelp {
 		return a.renderHelp()
 	}
 
-	if a.showConfirm {
-		return a.renderConfirmDialog()
+	if a.fullscreenLog {
+		return a.renderFullscreenLog()
 	}
 
-	// Calculate dimensions using helper
-	totalHeight, panelHeight := a.panelLayout()
-
-	// Left sidebar, Right detail
-	leftWidth := a.leftPanelWidth()
-	rightWidth := a.width - leftWidth
-
-	// Build left sidebar panels
-	wfLines := a.buildWorkflowsPanel(leftWidth, panelHeight)
-	runLines := a.buildRunsPanel(leftWidth, panelHeight)
-	jobLines := a.buildJobsPanel(leftWidth, totalHeight-2*panelHeight) // remaining height
+	if a.refPicker != nil {
+		return a.renderRefPicker()
+	}
 
-	// Build right detail view
-	detailLines := a.buildDetailPanel(rightWidth, totalHeight)
+	if a.dispatchForm != nil {
+		return a.renderDispatchForm()
+	}
 
-	// Combine: left sidebar + right detail, line by line
-	var output strings.Builder
-	leftIdx := 0
+	if a.remoteSwitcher != nil {
+		return a.renderRemoteSwitcher()
+	}
 
-	// Workflows panel
-	for i := 0; i < panelHeight && leftIdx < totalHeight; i++ {
-		line := wfLines[i]
-		if leftIdx < len(detailLines) {
-			line += detailLines[leftIdx]
-		}
-		output.WriteString(line)
-		output.WriteString("\n")
-		leftIdx++
+	if a.showConfirm {
+		return a.renderConfirmDialog()
 	}
 
-	// Runs panel
-	for i := 0; i < panelHeight && leftIdx < totalHeight; i++ {
-		line := runLines[i]
-		if leftIdx < len(detailLines) {
-			line += detailLines[leftIdx]
+	// Build the panels and lay them out line by line: panels side by side
+	// share lines, stacked panels follow each other
+	totalHeight, _ := a.panelLayout()
+	panes, detail := a.paneRects()
+	type panel struct {
+		area  rect
+		lines []string
+	}
+	var panels []panel
+	for _, pane := range a.leftPanes() {
+		if r, ok := panes[pane]; ok {
+			lines := a.buildLeftPanel(pane, r.width, r.height)
+			if a.layout.compact && len(lines) > 0 {
+				lines[0] = a.compactTabs(r.width)
+			}
+			panels = append(panels, panel{r, lines})
 		}
-		output.WriteString(line)
-		output.WriteString("\n")
-		leftIdx++
+	}
+	if detail.width > 0 {
+		panels = append(panels, panel{detail, a.buildDetailPanel(detail.width, detail.height)})
 	}
 
-	// Jobs panel (remaining height)
-	jobHeight := totalHeight - 2*panelHeight
-	for i := 0; i < jobHeight && leftIdx < totalHeight; i++ {
-		line := jobLines[i]
-		if leftIdx < len(detailLines) {
-			line += detailLines[leftIdx]
+	var output strings.Builder
+	for y := range totalHeight {
+		for _, p := range panels {
+			if i := y - p.area.y; i >= 0 && i < len(p.lines) {
+				output.WriteString(p.lines[i])
+			}
 		}
-		output.WriteString(line)
 		output.WriteString("\n")
-		leftIdx++
 	}
 
 	// Add status bar
@@ -432,28 +616,28 @@ func (a *App) fetchWorkflowsCmd() tea.Cmd {
 		return nil
 	}
 	a.loading = true
-	return fetchWorkflows(a.client, a.repo)
+	return a.forView(fetchWorkflows(a.client, a.repo))
 }
 
 func (a *App) fetchRunsCmd(workflowID int64) tea.Cmd {
 	if a.client == nil {
 		return nil
 	}
-	return fetchRuns(a.client, a.repo, workflowID)
+	return a.forView(fetchRuns(a.client, a.repo, a.runsOpts(workflowID, 1)))
 }
 
 func (a *App) fetchJobsCmd(runID int64) tea.Cmd {
 	if a.client == nil {
 		return nil
 	}
-	return fetchJobs(a.client, a.repo, runID)
+	return a.forView(fetchJobs(a.client, a.runRepo(runID), runID))
 }
 
 func (a *App) fetchLogsCmd(jobID int64) tea.Cmd {
 	if a.client == nil {
 		return nil
 	}
-	return fetchLogs(a.client, a.repo, jobID)
+	return a.forView(fetchLogs(a.client, a.selectedRunRepo(), jobID))
 }
 
 // formatRunNumber formats a run ID for display
@@ -462,19 +646,25 @@ func formatRunNumber(id int64) string {
 }
 
 // Run starts the TUI application
-func Run(client github.Client, repo github.Repository) error {
-	// Display startup banner
-	PrintBanner()
-
-	app := New(
+func Run(client github.Client, repo github.Repository, opts ...Option) error {
+	app := New(append([]Option{
 		WithClient(client),
 		WithRepository(repo),
-	)
+	}, opts...)...)
+
+	// Display startup banner, in the colors of the theme
+	PrintBanner()
 
 	p := tea.NewProgram(app,
 		tea.WithAltScreen(),
 		tea.WithMouseCellMotion(),
+		tea.WithReportFocus(),
 	)
-	_, err := p.Run()
-	return err
+	if _, err := p.Run(); err != nil {
+		return err
+	}
+	if err := app.state.Save(); err != nil {
+		return fmt.Errorf("failed to save state: %w", err)
+	}
+	return nil
 }
@@ -2,6 +2,7 @@ package app
 
 import (
 	"errors"
+	"strings"
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -198,6 +199,26 @@ func TestApp_Update_JobsLoadedMsg(t *testing.T) {
 	}
 }
 
+func TestApp_Update_JobsLoadedMsg_Truncated(t *testing.T) {
+	app := New()
+	app.width, app.height = 120, 40
+
+	jobs := []github.Job{
+		{ID: 1, Name: "build", Status: "queued"},
+		{ID: 2, Name: "test", Status: "queued"},
+	}
+	app.Update(JobsLoadedMsg{Jobs: jobs, Total: 240})
+
+	if !strings.Contains(app.View(), "Jobs (showing 2 of 240)") {
+		t.Error("Jobs pane title should show that the list is incomplete")
+	}
+
+	app.Update(JobsLoadedMsg{Jobs: jobs, Total: 2})
+	if strings.Contains(app.View(), "showing") {
+		t.Error("a complete list should not be marked as truncated")
+	}
+}
+
 func TestApp_Update_JobsLoadedMsg_QueuedJob(t *testing.T) {
 	app := New()
 
@@ -3,8 +3,6 @@ package app
 import (
 	"fmt"
 	"time"
-
-	"github.com/charmbracelet/lipgloss"
 )
 
 // Banner timing constant
@@ -23,11 +21,8 @@ const bannerArt = `
                |___/
 `
 
-// BannerStyle defines the cyan color style for the banner
-var BannerStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#00FFFF"))
-
-// PrintBanner prints the ASCII art banner in cyan with a brief delay
+// PrintBanner prints the ASCII art banner in the color of the theme with a brief delay
 func PrintBanner() {
-	fmt.Println(BannerStyle.Render(bannerArt))
+	fmt.Println(theme.Banner.Render(bannerArt))
 	time.Sleep(BannerDisplayDelay)
 }
@@ -15,9 +15,9 @@ func TestPrintBanner_NoPanic(t *testing.T) {
 }
 
 func TestBannerStyle_NotNil(t *testing.T) {
-	// Verify that BannerStyle is properly initialized
-	if BannerStyle.GetForeground() == nil {
-		t.Error("BannerStyle foreground color should not be nil")
+	// Verify that theme.Banner is properly initialized
+	if theme.Banner.GetForeground() == nil {
+		t.Error("theme.Banner foreground color should not be nil")
 	}
 }
 
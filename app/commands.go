@@ -2,6 +2,8 @@ package app
 
 import (
 	"context"
+	"sort"
+	"sync"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -13,36 +15,36 @@ import (
 // Retries on transient errors (rate limits, server errors).
 func fetchWorkflows(client github.Client, repo github.Repository) tea.Cmd {
 	return func() tea.Msg {
-		var workflows []github.Workflow
+		var workflows github.List[github.Workflow]
 		err := github.RetryWithBackoff(context.Background(), 3, func() error {
 			var e error
 			workflows, e = client.ListWorkflows(context.Background(), repo)
 			return e
 		})
 		return WorkflowsLoadedMsg{
-			Workflows: workflows,
+			Workflows: workflows.Items,
+			Total:     workflows.Total,
 			Err:       err,
 		}
 	}
 }
 
-// fetchRuns creates a command to fetch runs for a workflow.
-// It captures the client, repo, and workflowID to avoid race conditions.
+// fetchRuns creates a command to fetch a page of runs.
+// It captures the client, repo, and opts to avoid race conditions.
 // Retries on transient errors (rate limits, server errors).
-func fetchRuns(client github.Client, repo github.Repository, workflowID int64) tea.Cmd {
+func fetchRuns(client github.Client, repo github.Repository, opts github.ListRunsOpts) tea.Cmd {
 	return func() tea.Msg {
-		opts := &github.ListRunsOpts{
-			WorkflowID: workflowID,
-		}
-		var runs []github.Run
+		var runs github.List[github.Run]
 		err := github.RetryWithBackoff(context.Background(), 3, func() error {
 			var e error
-			runs, e = client.ListRuns(context.Background(), repo, opts)
+			runs, e = client.ListRuns(context.Background(), repo, &opts)
 			return e
 		})
 		return RunsLoadedMsg{
-			Runs: runs,
-			Err:  err,
+			Runs:  runs.Items,
+			Opts:  opts,
+			Total: runs.Total,
+			Err:   err,
 		}
 	}
 }
@@ -52,15 +54,16 @@ func fetchRuns(client github.Client, repo github.Repository, workflowID int64) t
 // Retries on transient errors (rate limits, server errors).
 func fetchJobs(client github.Client, repo github.Repository, runID int64) tea.Cmd {
 	return func() tea.Msg {
-		var jobs []github.Job
+		var jobs github.List[github.Job]
 		err := github.RetryWithBackoff(context.Background(), 3, func() error {
 			var e error
 			jobs, e = client.ListJobs(context.Background(), repo, runID)
 			return e
 		})
 		return JobsLoadedMsg{
-			Jobs: jobs,
-			Err:  err,
+			Jobs:  jobs.Items,
+			Total: jobs.Total,
+			Err:   err,
 		}
 	}
 }
@@ -124,6 +127,75 @@ func rerunFailedJobs(client github.Client, repo github.Repository, runID int64)
 	}
 }
 
+// fetchRefs creates a command to load the default branch, branches and tags of a repository.
+// The branches are kept if the tags fail to load.
+// Retries on transient errors (rate limits, server errors).
+func fetchRefs(client github.Client, repo github.Repository) tea.Cmd {
+	return func() tea.Msg {
+		var msg RefsLoadedMsg
+		msg.Err = github.RetryWithBackoff(context.Background(), 3, func() error {
+			var e error
+			msg.DefaultBranch, e = client.GetDefaultBranch(context.Background(), repo)
+			return e
+		})
+		if msg.Err != nil {
+			return msg
+		}
+
+		msg.Err = github.RetryWithBackoff(context.Background(), 3, func() error {
+			var e error
+			msg.Branches, e = client.ListBranches(context.Background(), repo)
+			return e
+		})
+		if msg.Err != nil {
+			return msg
+		}
+
+		msg.TagsErr = github.RetryWithBackoff(context.Background(), 3, func() error {
+			var e error
+			msg.Tags, e = client.ListTags(context.Background(), repo)
+			return e
+		})
+		return msg
+	}
+}
+
+// fetchDispatchInputs creates a command to read the workflow_dispatch inputs of a workflow.
+// It fetches the workflow file at ref and parses its inputs block. Environments are
+// only listed when an input needs the environment picker.
+// Retries on transient errors (rate limits, server errors).
+func fetchDispatchInputs(client github.Client, repo github.Repository, wf github.Workflow, ref string) tea.Cmd {
+	return func() tea.Msg {
+		msg := DispatchInputsLoadedMsg{Workflow: wf, Ref: ref}
+
+		var content string
+		msg.Err = github.RetryWithBackoff(context.Background(), 3, func() error {
+			var e error
+			content, e = client.GetFileContent(context.Background(), repo, wf.Path, ref)
+			return e
+		})
+		if msg.Err != nil {
+			return msg
+		}
+
+		msg.Inputs, msg.Err = github.ParseDispatchInputs([]byte(content))
+		if msg.Err != nil {
+			return msg
+		}
+
+		for _, in := range msg.Inputs {
+			if in.Type == github.InputTypeEnvironment {
+				// Without the list the form falls back to a free-text field
+				if envs, err := client.ListEnvironments(context.Background(), repo); err == nil {
+					msg.Environments = envs
+				}
+				break
+			}
+		}
+		return msg
+	}
+}
+
 // triggerWorkflow creates a command to trigger a workflow dispatch.
 // It captures the client, repo, workflowFile, ref, and inputs to avoid race conditions.
 func triggerWorkflow(client github.Client, repo github.Repository, workflowFile string, ref string, inputs map[string]string) tea.Cmd {
@@ -142,6 +214,100 @@ func triggerWorkflow(client github.Client, repo github.Repository, workflowFile
 	}
 }
 
+// fetchRepoStatus creates a command to load the latest run of a repository.
+func fetchRepoStatus(client github.Client, repo github.Repository) tea.Cmd {
+	return func() tea.Msg {
+		var runs github.List[github.Run]
+		err := github.RetryWithBackoff(context.Background(), 3, func() error {
+			var e error
+			runs, e = client.ListRuns(context.Background(), repo, &github.ListRunsOpts{PerPage: 1})
+			return e
+		})
+		msg := RepoStatusLoadedMsg{Repo: repo, Err: err}
+		if len(runs.Items) > 0 {
+			msg.Run = &runs.Items[0]
+		}
+		return msg
+	}
+}
+
+// fetchViewer creates a command to load the login of the authenticated user.
+// Retries on transient errors (rate limits, server errors).
+func fetchViewer(client github.Client) tea.Cmd {
+	return func() tea.Msg {
+		var login string
+		err := github.RetryWithBackoff(context.Background(), 3, func() error {
+			var e error
+			login, e = client.CurrentUser(context.Background())
+			return e
+		})
+		return ViewerLoadedMsg{Login: login, Err: err}
+	}
+}
+
+// fetchDashboard creates a command to load the runs in flight and the recent
+// failures of every repository of owner. Runs are listed per repository and
+// status with at most DashboardConcurrency requests in flight.
+// Retries on transient errors (rate limits, server errors) until ctx is
+// cancelled.
+func fetchDashboard(ctx context.Context, client github.Client, owner string) tea.Cmd {
+	return func() tea.Msg {
+		var repos []github.Repository
+		err := github.RetryWithBackoff(ctx, 3, func() error {
+			var e error
+			repos, e = client.ListOwnerRepositories(ctx, owner)
+			return e
+		})
+		if err != nil {
+			return DashboardLoadedMsg{Err: err}
+		}
+
+		msg := DashboardLoadedMsg{RunRepos: make(map[int64]github.Repository), Repos: len(repos)}
+		var mu sync.Mutex
+		var wg sync.WaitGroup
+		sem := make(chan struct{}, DashboardConcurrency)
+		for _, repo := range repos {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for _, status := range DashboardStatuses {
+					sem <- struct{}{}
+					var runs github.List[github.Run]
+					err := github.RetryWithBackoff(ctx, 3, func() error {
+						var e error
+						runs, e = client.ListRuns(ctx, repo, &github.ListRunsOpts{Status: status, PerPage: DashboardRunsPerStatus})
+						return e
+					})
+					<-sem
+
+					mu.Lock()
+					if err != nil {
+						msg.Failed++
+						mu.Unlock()
+						return
+					}
+					for _, run := range runs.Items {
+						if _, seen := msg.RunRepos[run.ID]; !seen {
+							msg.RunRepos[run.ID] = repo
+							msg.Runs = append(msg.Runs, run)
+						}
+					}
+					mu.Unlock()
+				}
+			}()
+		}
+		wg.Wait()
+		if err := ctx.Err(); err != nil {
+			return DashboardLoadedMsg{Err: err}
+		}
+
+		sort.SliceStable(msg.Runs, func(i, j int) bool {
+			return msg.Runs[i].CreatedAt.After(msg.Runs[j].CreatedAt)
+		})
+		return msg
+	}
+}
+
 // flashMessage creates a flash message that clears after duration.
 // It returns a batch of commands: the flash message and a delayed clear.
 func flashMessage(msg string, duration time.Duration) tea.Cmd {
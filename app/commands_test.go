@@ -70,7 +70,7 @@ func TestFetchRuns(t *testing.T) {
 		repo := github.Repository{Owner: "owner", Name: "repo"}
 		workflowID := int64(1)
 
-		cmd := fetchRuns(mock, repo, workflowID)
+		cmd := fetchRuns(mock, repo, github.ListRunsOpts{WorkflowID: workflowID})
 		msg := cmd()
 
 		result, ok := msg.(RunsLoadedMsg)
@@ -104,7 +104,7 @@ func TestFetchRuns(t *testing.T) {
 		})
 		repo := github.Repository{Owner: "owner", Name: "repo"}
 
-		cmd := fetchRuns(mock, repo, 1)
+		cmd := fetchRuns(mock, repo, github.ListRunsOpts{WorkflowID: 1})
 		msg := cmd()
 
 		result, ok := msg.(RunsLoadedMsg)
@@ -484,7 +484,7 @@ func TestCommandsAreFunctions(t *testing.T) {
 		t.Error("fetchWorkflows returned nil")
 	}
 
-	cmd = fetchRuns(mock, repo, 1)
+	cmd = fetchRuns(mock, repo, github.ListRunsOpts{WorkflowID: 1})
 	if cmd == nil {
 		t.Error("fetchRuns returned nil")
 	}
@@ -543,7 +543,7 @@ func TestConcurrentCommandExecution(t *testing.T) {
 	// Create multiple commands
 	cmds := []tea.Cmd{
 		fetchWorkflows(mock, repo),
-		fetchRuns(mock, repo, 1),
+		fetchRuns(mock, repo, github.ListRunsOpts{WorkflowID: 1}),
 		fetchJobs(mock, repo, 100),
 		fetchLogs(mock, repo, 200),
 	}
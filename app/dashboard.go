@@ -0,0 +1,221 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"strconv"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nnnkkk7/lazyactions/github"
+)
+
+// Dashboard constants
+const (
+	// DashboardConcurrency bounds the ListRuns requests in flight while loading the dashboard
+	DashboardConcurrency = 8
+	// DashboardRunsPerStatus is the number of runs loaded per repository and status
+	DashboardRunsPerStatus = 5
+	// DashboardRepoColumnMaxWidth caps the width of the repository column
+	DashboardRepoColumnMaxWidth = 20
+	// DashboardRefreshInterval is the minimum delay between polls of the
+	// dashboard, as each one lists the runs of every repository
+	DashboardRefreshInterval = time.Minute
+	// DashboardRequestsPerHour is the share of the hourly API quota (5000
+	// requests) the dashboard reloads may use; the interval between them
+	// grows with the number of repositories to stay within it
+	DashboardRequestsPerHour = 1000
+)
+
+// DashboardStatuses are the run statuses listed by the dashboard:
+// everything in flight and recent failures
+var DashboardStatuses = []string{"in_progress", "queued", "failure"}
+
+// dashboard is the state of the runs view across all repositories of an owner.
+// While it is open the Runs pane lists those runs and the Jobs pane and log
+// view follow the repository of the selected run.
+type dashboard struct {
+	owner    string
+	repos    int                         // repositories scanned
+	failed   int                         // repositories whose runs could not be loaded
+	runRepos map[int64]github.Repository // repository of each listed run
+	saved    *repoView                   // view of the current repository, restored on exit
+	focus    Pane                        // focused pane before the dashboard opened
+
+	requested time.Time          // when the runs were last requested
+	cancel    context.CancelFunc // cancels the last request, nil before the first
+}
+
+// newDashboardRunList creates the runs list of the dashboard,
+// which also filters by repository name
+func newDashboardRunList(d *dashboard) *FilteredList[github.Run] {
+	return NewRankedList(queryScorer(dashboardFields(d)))
+}
+
+// dashboardFields returns the filter fields of the runs of the dashboard:
+// those of the Runs pane and the repository
+func dashboardFields(d *dashboard) *queryFields[github.Run] {
+	repoName := func(r github.Run) []string { return []string{d.runRepos[r.ID].Name} }
+	fields := maps.Clone(runFields.fields)
+	fields["repo"] = queryField[github.Run]{text: repoName}
+	return &queryFields[github.Run]{
+		fields: fields,
+		text: func(r github.Run) []string {
+			return append(repoName(r), r.Name, r.Branch, r.Actor)
+		},
+	}
+}
+
+// toggleDashboard opens the dashboard of the current repository's owner, or closes it
+func (a *App) toggleDashboard() tea.Cmd {
+	if a.dashboard != nil {
+		a.exitDashboard()
+		return a.refreshAll()
+	}
+
+	d := &dashboard{
+		owner:    a.repo.Owner,
+		runRepos: make(map[int64]github.Repository),
+		saved:    a.saveView(),
+		focus:    a.focusedPane,
+	}
+	view := a.newRepoView()
+	view.runs = newDashboardRunList(d)
+	view.runs.SetFilter(a.resolveViewer(view.runHistory.filter))
+	a.restoreView(view)
+	a.dashboard = d
+	a.focusedPane = RunsPane
+	a.err = nil
+	return a.fetchDashboardCmd()
+}
+
+// exitDashboard closes the dashboard and shows the current repository again
+func (a *App) exitDashboard() {
+	if a.dashboard == nil {
+		return
+	}
+	d := a.dashboard
+	a.dashboard = nil
+	if d.cancel != nil {
+		d.cancel()
+	}
+	a.restoreView(d.saved)
+	a.focusedPane = d.focus
+	a.err = nil
+}
+
+// fetchDashboardCmd loads the runs of the dashboard, cancelling the previous
+// load if still in flight
+func (a *App) fetchDashboardCmd() tea.Cmd {
+	if a.client == nil || a.dashboard == nil {
+		return nil
+	}
+	d := a.dashboard
+	if d.cancel != nil {
+		d.cancel()
+	}
+	var ctx context.Context
+	ctx, d.cancel = context.WithCancel(context.Background())
+	d.requested = time.Now()
+	a.loading = true
+	return a.forView(fetchDashboard(ctx, a.client, d.owner))
+}
+
+// reloadCost returns the number of requests of a reload: one listing per
+// repository and status, and the listing of the repositories
+func (d *dashboard) reloadCost() int {
+	return d.repos*len(DashboardStatuses) + 1
+}
+
+// refreshInterval returns the delay between reloads, at least
+// DashboardRefreshInterval and long enough for the reloads to stay within
+// DashboardRequestsPerHour
+func (d *dashboard) refreshInterval() time.Duration {
+	return max(DashboardRefreshInterval, time.Hour*time.Duration(d.reloadCost())/DashboardRequestsPerHour)
+}
+
+// pollDashboard reloads the dashboard once its refresh interval has passed
+// since the last load, unless the remaining rate limit would drop below
+// PollRateLimitThreshold. In between, it refreshes the jobs of the selected
+// run while it runs.
+func (a *App) pollDashboard() tea.Cmd {
+	d := a.dashboard
+	if time.Since(d.requested) >= d.refreshInterval() &&
+		a.client.RateLimitRemaining() >= d.reloadCost()+PollRateLimitThreshold {
+		return a.fetchDashboardCmd()
+	}
+	if run, ok := a.runs.Selected(); ok && run.IsRunning() {
+		return a.fetchJobsCmd(run.ID)
+	}
+	return nil
+}
+
+// onDashboardLoaded shows the runs of the dashboard, keeping the selected run
+func (a *App) onDashboardLoaded(msg DashboardLoadedMsg) tea.Cmd {
+	// A cancelled load was replaced by another one
+	if errors.Is(msg.Err, context.Canceled) {
+		return nil
+	}
+	a.loading = false
+	if a.dashboard == nil {
+		return nil
+	}
+	if msg.Err != nil {
+		a.err = msg.Err
+		return nil
+	}
+	a.markUpdated()
+
+	d := a.dashboard
+	d.repos = msg.Repos
+	d.failed = msg.Failed
+	d.runRepos = msg.RunRepos
+
+	prev, hadPrev := a.runs.Selected()
+	a.runs.SetItems(msg.Runs)
+	if hadPrev {
+		a.runs.SelectMatching(func(r github.Run) bool { return r.ID == prev.ID })
+	}
+	if run, ok := a.runs.Selected(); ok {
+		return a.fetchJobsCmd(run.ID)
+	}
+	return nil
+}
+
+// runRepo returns the repository of a listed run:
+// that of the dashboard row, or the current repository
+func (a *App) runRepo(runID int64) github.Repository {
+	if a.dashboard != nil {
+		if r, ok := a.dashboard.runRepos[runID]; ok {
+			return r
+		}
+	}
+	return a.repo
+}
+
+// selectedRunRepo returns the repository of the selected run
+func (a *App) selectedRunRepo() github.Repository {
+	run, _ := a.runs.Selected()
+	return a.runRepo(run.ID)
+}
+
+// dashboardTitle returns the title of the Runs pane in the dashboard
+func (a *App) dashboardTitle() string {
+	d := a.dashboard
+	title := "Runs · " + d.owner + " (" + strconv.Itoa(d.repos) + " repos"
+	if d.failed > 0 {
+		title += ", " + strconv.Itoa(d.failed) + " failed"
+	}
+	return title + ")"
+}
+
+// dashboardRepoColumnWidth returns the width of the repository column,
+// fitting the longest repository name listed
+func (a *App) dashboardRepoColumnWidth() int {
+	width := 0
+	for _, r := range a.dashboard.runRepos {
+		width = max(width, len(r.Name))
+	}
+	return min(width, DashboardRepoColumnMaxWidth)
+}
@@ -0,0 +1,257 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nnnkkk7/lazyactions/github"
+)
+
+var (
+	dashRepo  = github.Repository{Host: "github.com", Owner: "acme", Name: "api"}
+	otherRepo = github.Repository{Host: "github.com", Owner: "acme", Name: "web"}
+)
+
+func TestFetchDashboard(t *testing.T) {
+	now := time.Now()
+	brokenRepo := github.Repository{Host: "github.com", Owner: "acme", Name: "broken"}
+
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+	mock := newMockClient(nil)
+	mock.ListOwnerRepositoriesFunc = func(ctx context.Context, owner string) ([]github.Repository, error) {
+		return []github.Repository{dashRepo, otherRepo, brokenRepo}, nil
+	}
+	mock.ListRunsFunc = func(ctx context.Context, repo github.Repository, opts *github.ListRunsOpts) (github.List[github.Run], error) {
+		mu.Lock()
+		inFlight++
+		maxInFlight = max(maxInFlight, inFlight)
+		mu.Unlock()
+		defer func() {
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+		}()
+
+		switch {
+		case repo == brokenRepo:
+			return github.List[github.Run]{}, errAPI
+		case repo == dashRepo && opts.Status == "in_progress":
+			return github.List[github.Run]{Items: []github.Run{{ID: 1, Status: "in_progress", CreatedAt: now.Add(-time.Hour)}}}, nil
+		case repo == otherRepo && opts.Status == "failure":
+			return github.List[github.Run]{Items: []github.Run{{ID: 2, Status: "completed", Conclusion: "failure", CreatedAt: now}}}, nil
+		}
+		return github.List[github.Run]{}, nil
+	}
+
+	msg, ok := fetchDashboard(context.Background(), mock, "acme")().(DashboardLoadedMsg)
+	if !ok {
+		t.Fatal("expected DashboardLoadedMsg")
+	}
+	if msg.Err != nil {
+		t.Fatalf("Err = %v", msg.Err)
+	}
+	if msg.Repos != 3 || msg.Failed != 1 {
+		t.Errorf("Repos = %d, Failed = %d, want 3 and 1", msg.Repos, msg.Failed)
+	}
+	if len(msg.Runs) != 2 || msg.Runs[0].ID != 2 || msg.Runs[1].ID != 1 {
+		t.Errorf("Runs = %+v, want runs 2 and 1, newest first", msg.Runs)
+	}
+	if msg.RunRepos[1] != dashRepo || msg.RunRepos[2] != otherRepo {
+		t.Errorf("RunRepos = %+v", msg.RunRepos)
+	}
+	if maxInFlight > DashboardConcurrency {
+		t.Errorf("%d requests in flight, want at most %d", maxInFlight, DashboardConcurrency)
+	}
+	for _, call := range mock.ListRunsCalls() {
+		if call.Opts.PerPage != DashboardRunsPerStatus {
+			t.Errorf("PerPage = %d, want %d", call.Opts.PerPage, DashboardRunsPerStatus)
+		}
+	}
+}
+
+func TestFetchDashboard_RepositoriesError(t *testing.T) {
+	mock := newMockClient(nil)
+	mock.ListOwnerRepositoriesFunc = func(ctx context.Context, owner string) ([]github.Repository, error) {
+		return nil, errAPI
+	}
+
+	msg := fetchDashboard(context.Background(), mock, "acme")().(DashboardLoadedMsg)
+	if msg.Err == nil {
+		t.Error("expected an error")
+	}
+}
+
+// newDashboardTestApp returns an app with the dashboard open and loaded
+func newDashboardTestApp(t *testing.T) (*App, *github.MockClient) {
+	t.Helper()
+	mock := newMockClient(&mockClientState{
+		jobs: []github.Job{{ID: 100, Name: "build", Status: "in_progress"}},
+	})
+	app := New(WithClient(mock), WithRepository(dashRepo))
+	app.width, app.height = 120, 40
+	app.workflows.SetItems([]github.Workflow{{ID: 1, Name: "CI"}})
+	app.focusedPane = WorkflowsPane
+
+	if cmd := app.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'D'}}); cmd == nil {
+		t.Fatal("D should load the dashboard")
+	}
+	app.Update(repoMsg{repo: dashRepo, dashboard: true, msg: DashboardLoadedMsg{
+		Runs: []github.Run{
+			{ID: 20, Name: "Deploy", RunNumber: 7, Status: "completed", Conclusion: "failure"},
+			{ID: 10, Name: "CI", RunNumber: 3, Status: "in_progress"},
+		},
+		RunRepos: map[int64]github.Repository{20: otherRepo, 10: dashRepo},
+		Repos:    2,
+	}})
+	return app, mock
+}
+
+func TestApp_Dashboard_Open(t *testing.T) {
+	app, _ := newDashboardTestApp(t)
+
+	if app.dashboard == nil {
+		t.Fatal("dashboard should be open")
+	}
+	if app.focusedPane != RunsPane {
+		t.Errorf("focusedPane = %v, want RunsPane", app.focusedPane)
+	}
+	if got := app.leftPanes(); len(got) != 2 {
+		t.Errorf("leftPanes() = %v, want Runs and Jobs", got)
+	}
+	if app.runs.Len() != 2 {
+		t.Fatalf("runs = %d, want 2", app.runs.Len())
+	}
+
+	view := app.View()
+	for _, want := range []string{"Runs · acme (2 repos)", "web Deploy #7", "api CI #3"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("view should contain %q:\n%s", want, view)
+		}
+	}
+}
+
+func TestApp_Dashboard_DrillDown(t *testing.T) {
+	app, mock := newDashboardTestApp(t)
+
+	// The first run belongs to another repository than the one opened
+	app.Update(app.fetchJobsCmd(20)())
+	calls := mock.ListJobsCalls()
+	if len(calls) == 0 || calls[len(calls)-1].Repo != otherRepo {
+		t.Fatalf("jobs should be loaded from %s, calls = %+v", otherRepo.FullName(), calls)
+	}
+	if app.jobs.Len() != 1 {
+		t.Errorf("jobs = %d, want 1", app.jobs.Len())
+	}
+
+	app.fetchLogsCmd(100)()
+	if logs := mock.GetJobLogsCalls(); logs[len(logs)-1].Repo != otherRepo {
+		t.Errorf("logs should be loaded from %s", otherRepo.FullName())
+	}
+}
+
+func TestApp_Dashboard_Actions(t *testing.T) {
+	app, mock := newDashboardTestApp(t)
+
+	app.rerunFailedJobs()()
+	if calls := mock.RerunFailedJobsCalls(); len(calls) != 1 || calls[0].Repo != otherRepo || calls[0].RunID != 20 {
+		t.Errorf("rerun should target run 20 of %s, calls = %+v", otherRepo.FullName(), calls)
+	}
+
+	app.runs.SelectNext()
+	app.confirmCancelRun()
+	app.confirmFn()()
+	if calls := mock.CancelRunCalls(); len(calls) != 1 || calls[0].Repo != dashRepo || calls[0].RunID != 10 {
+		t.Errorf("cancel should target run 10 of %s, calls = %+v", dashRepo.FullName(), calls)
+	}
+}
+
+func TestApp_Dashboard_Close(t *testing.T) {
+	app, _ := newDashboardTestApp(t)
+	stale := app.fetchJobsCmd(20)
+
+	app.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'D'}})
+	if app.dashboard != nil {
+		t.Fatal("D should close the dashboard")
+	}
+	if app.focusedPane != WorkflowsPane {
+		t.Errorf("focusedPane = %v, want it restored", app.focusedPane)
+	}
+	if app.workflows.Len() != 1 || app.runs.Len() != 0 {
+		t.Error("the view of the repository should be restored")
+	}
+
+	app.Update(stale())
+	if app.jobs.Len() != 0 {
+		t.Error("jobs loaded for the dashboard should be dropped")
+	}
+}
+
+func TestApp_Dashboard_Polling(t *testing.T) {
+	app, mock := newDashboardTestApp(t)
+	repoCalls := len(mock.ListOwnerRepositoriesCalls())
+
+	// Ticks within DashboardRefreshInterval refresh only the running run selected
+	if cmd := app.pollCmd(); cmd != nil {
+		t.Error("the dashboard should not reload before DashboardRefreshInterval")
+	}
+	app.runs.SelectNext()
+	if cmd := app.pollCmd(); cmd == nil {
+		t.Fatal("the jobs of the running run selected should refresh")
+	}
+	if len(mock.ListOwnerRepositoriesCalls()) != repoCalls {
+		t.Error("the dashboard should not reload")
+	}
+
+	// Later ticks reload it, unless the reload would leave the rate limit low
+	app.dashboard.requested = time.Now().Add(-DashboardRefreshInterval)
+	mock.RateLimitRemainingFunc = func() int { return app.dashboard.reloadCost() + PollRateLimitThreshold - 1 }
+	app.pollCmd()
+	if !app.dashboard.requested.Before(time.Now().Add(-time.Second)) {
+		t.Error("the dashboard should not reload with a low rate limit")
+	}
+	mock.RateLimitRemainingFunc = func() int { return 5000 }
+	if cmd := app.pollCmd(); cmd == nil || time.Since(app.dashboard.requested) > time.Second {
+		t.Error("the dashboard should reload after DashboardRefreshInterval")
+	}
+}
+
+func TestDashboard_RefreshInterval(t *testing.T) {
+	tests := []struct {
+		repos int
+		want  time.Duration
+	}{
+		{0, DashboardRefreshInterval},
+		{5, DashboardRefreshInterval},
+		{100, 301 * time.Hour / DashboardRequestsPerHour},
+		{1000, 3001 * time.Hour / DashboardRequestsPerHour},
+	}
+	for _, tt := range tests {
+		d := &dashboard{repos: tt.repos}
+		if got := d.refreshInterval(); got != tt.want {
+			t.Errorf("refreshInterval() with %d repos = %v, want %v", tt.repos, got, tt.want)
+		}
+		if perHour := int(time.Hour/d.refreshInterval()) * d.reloadCost(); perHour > DashboardRequestsPerHour {
+			t.Errorf("%d repos use %d requests an hour, want at most %d", tt.repos, perHour, DashboardRequestsPerHour)
+		}
+	}
+}
+
+func TestApp_Dashboard_CloseCancelsLoad(t *testing.T) {
+	app, mock := newDashboardTestApp(t)
+	mock.ListOwnerRepositoriesFunc = func(ctx context.Context, owner string) ([]github.Repository, error) {
+		return nil, ctx.Err()
+	}
+	cmd := app.fetchDashboardCmd()
+
+	app.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'D'}})
+	msg := cmd().(repoMsg).msg.(DashboardLoadedMsg)
+	if !errors.Is(msg.Err, context.Canceled) {
+		t.Errorf("closing the dashboard should cancel its load, Err = %v", msg.Err)
+	}
+}
@@ -0,0 +1,242 @@
+package app
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/nnnkkk7/lazyactions/github"
+)
+
+// Dispatch form constants
+const (
+	// DispatchFormMaxWidth is the maximum width of the dispatch form dialog
+	DispatchFormMaxWidth = 70
+	// DispatchInputCharLimit is the maximum characters for a text input
+	DispatchInputCharLimit = 256
+)
+
+// dispatchField is a single input of the dispatch form.
+// Text-like inputs use text, booleans use checked, and choice inputs
+// (including environments when the list is known) use options and choice.
+type dispatchField struct {
+	input   github.WorkflowInput
+	text    textinput.Model
+	checked bool
+	options []string
+	choice  int
+}
+
+// isText returns true if the field is edited through the text input
+func (f *dispatchField) isText() bool {
+	return f.input.Type != github.InputTypeBoolean && len(f.options) == 0
+}
+
+// value returns the current value of the field as sent to the API
+func (f *dispatchField) value() string {
+	switch {
+	case f.input.Type == github.InputTypeBoolean:
+		return strconv.FormatBool(f.checked)
+	case len(f.options) > 0:
+		return f.options[f.choice]
+	default:
+		return strings.TrimSpace(f.text.Value())
+	}
+}
+
+// dispatchForm is the modal form for workflow_dispatch inputs
+type dispatchForm struct {
+	workflow github.Workflow
+	ref      string
+	fields   []dispatchField
+	focused  int
+	err      string
+}
+
+// newDispatchForm creates a form with one field per input, prefilled with defaults.
+// Environment inputs become a picker when environments are known, free text otherwise.
+func newDispatchForm(wf github.Workflow, ref string, inputs []github.WorkflowInput, environments []string) *dispatchForm {
+	f := &dispatchForm{
+		workflow: wf,
+		ref:      ref,
+		fields:   make([]dispatchField, 0, len(inputs)),
+	}
+
+	for _, in := range inputs {
+		field := dispatchField{input: in}
+		switch in.Type {
+		case github.InputTypeBoolean:
+			field.checked = in.Default == "true"
+		case github.InputTypeChoice:
+			field.options = in.Options
+		case github.InputTypeEnvironment:
+			if len(environments) > 0 {
+				field.options = environments
+				if !in.Required {
+					field.options = append([]string{""}, environments...)
+				}
+			}
+		}
+
+		if len(field.options) > 0 {
+			for i, opt := range field.options {
+				if opt == in.Default {
+					field.choice = i
+					break
+				}
+			}
+		} else if field.isText() {
+			ti := textinput.New()
+			ti.CharLimit = DispatchInputCharLimit
+			ti.Prompt = ""
+			ti.SetValue(in.Default)
+			if in.Type == github.InputTypeNumber {
+				ti.Placeholder = "number"
+			}
+			field.text = ti
+		}
+		f.fields = append(f.fields, field)
+	}
+
+	f.focus(0)
+	return f
+}
+
+// focus moves focus to the field at index i
+func (f *dispatchForm) focus(i int) {
+	if len(f.fields) == 0 {
+		return
+	}
+	if i < 0 {
+		i = len(f.fields) - 1
+	} else if i >= len(f.fields) {
+		i = 0
+	}
+	for idx := range f.fields {
+		if f.fields[idx].isText() {
+			f.fields[idx].text.Blur()
+		}
+	}
+	f.focused = i
+	if f.fields[i].isText() {
+		f.fields[i].text.Focus()
+	}
+}
+
+// change toggles a boolean or cycles a choice on the focused field
+func (f *dispatchForm) change(delta int) {
+	if len(f.fields) == 0 {
+		return
+	}
+	field := &f.fields[f.focused]
+	switch {
+	case field.input.Type == github.InputTypeBoolean:
+		field.checked = !field.checked
+	case len(field.options) > 0:
+		n := len(field.options)
+		field.choice = ((field.choice+delta)%n + n) % n
+	}
+}
+
+// update handles a key press that is not a form-level command
+func (f *dispatchForm) update(msg tea.KeyMsg) tea.Cmd {
+	if len(f.fields) == 0 {
+		return nil
+	}
+	field := &f.fields[f.focused]
+	if field.isText() {
+		var cmd tea.Cmd
+		field.text, cmd = field.text.Update(msg)
+		return cmd
+	}
+	switch msg.String() {
+	case "left", "h":
+		f.change(-1)
+	case "right", "l", " ":
+		f.change(1)
+	}
+	return nil
+}
+
+// values validates the form and returns the inputs map for the dispatch API
+func (f *dispatchForm) values() (map[string]string, error) {
+	values := make(map[string]string, len(f.fields))
+	for i := range f.fields {
+		field := &f.fields[i]
+		v := field.value()
+		if v == "" {
+			if field.input.Required {
+				return nil, fmt.Errorf("%s is required", field.input.Name)
+			}
+			continue
+		}
+		if field.input.Type == github.InputTypeNumber {
+			if _, err := strconv.ParseFloat(v, 64); err != nil {
+				return nil, errors.New(field.input.Name + " must be a number")
+			}
+		}
+		values[field.input.Name] = v
+	}
+	return values, nil
+}
+
+// view renders the form body
+func (f *dispatchForm) view(width int) string {
+	var b strings.Builder
+	b.WriteString(lipgloss.NewStyle().Bold(true).Render("Run workflow: " + f.workflow.Name))
+	b.WriteString("\n")
+	b.WriteString(theme.Queued.Render("ref: " + f.ref))
+	b.WriteString("\n")
+
+	for i := range f.fields {
+		field := &f.fields[i]
+		focused := i == f.focused
+
+		label := field.input.Name
+		if field.input.Required {
+			label += " *"
+		}
+		cursor := "  "
+		if focused {
+			cursor = theme.Cursor.Render(">") + " "
+			label = lipgloss.NewStyle().Bold(true).Render(label)
+		}
+		b.WriteString("\n" + cursor + label + "\n")
+		if field.input.Description != "" {
+			b.WriteString("  " + theme.Queued.Render(truncateString(field.input.Description, width-2)) + "\n")
+		}
+		b.WriteString("  " + field.controlView(focused) + "\n")
+	}
+
+	if f.err != "" {
+		b.WriteString("\n" + theme.Failure.Render(f.err) + "\n")
+	}
+	b.WriteString("\n[Tab] next  [←/→] change  [Enter] run  [Esc] cancel")
+	return b.String()
+}
+
+// controlView renders the input control of a field
+func (f *dispatchField) controlView(focused bool) string {
+	switch {
+	case f.input.Type == github.InputTypeBoolean:
+		if f.checked {
+			return "[x] true"
+		}
+		return "[ ] false"
+	case len(f.options) > 0:
+		opt := f.options[f.choice]
+		if opt == "" {
+			opt = "(none)"
+		}
+		if focused {
+			return "◀ " + theme.SelectedItemFocused.Render(" "+opt+" ") + " ▶"
+		}
+		return "  " + opt
+	default:
+		return "[" + f.text.View() + "]"
+	}
+}
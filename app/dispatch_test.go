@@ -0,0 +1,245 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nnnkkk7/lazyactions/github"
+)
+
+const testDispatchWorkflow = `
+on:
+  workflow_dispatch:
+    inputs:
+      version:
+        required: true
+      dry_run:
+        type: boolean
+        default: false
+      target:
+        type: choice
+        options: [staging, production]
+`
+
+func testDispatchInputs() []github.WorkflowInput {
+	return []github.WorkflowInput{
+		{Name: "version", Type: github.InputTypeString, Required: true},
+		{Name: "dry_run", Type: github.InputTypeBoolean, Default: "true"},
+		{Name: "target", Type: github.InputTypeChoice, Options: []string{"staging", "production"}, Default: "production"},
+		{Name: "env", Type: github.InputTypeEnvironment},
+		{Name: "replicas", Type: github.InputTypeNumber, Default: "2"},
+	}
+}
+
+func TestNewDispatchForm_Defaults(t *testing.T) {
+	form := newDispatchForm(github.Workflow{Name: "Deploy"}, "main", testDispatchInputs(), []string{"staging", "prod"})
+
+	if len(form.fields) != 5 {
+		t.Fatalf("fields = %d, want 5", len(form.fields))
+	}
+	if !form.fields[1].checked {
+		t.Error("boolean default true should be checked")
+	}
+	if got := form.fields[2].value(); got != "production" {
+		t.Errorf("choice value = %q, want production", got)
+	}
+	// Optional environment picker starts with an empty choice
+	if got := form.fields[3].options; len(got) != 3 || got[0] != "" {
+		t.Errorf("environment options = %v, want leading empty option", got)
+	}
+	if got := form.fields[4].value(); got != "2" {
+		t.Errorf("number value = %q, want 2", got)
+	}
+	if !form.fields[0].text.Focused() {
+		t.Error("first field should be focused")
+	}
+}
+
+func TestNewDispatchForm_EnvironmentWithoutList(t *testing.T) {
+	inputs := []github.WorkflowInput{{Name: "env", Type: github.InputTypeEnvironment, Default: "qa"}}
+	form := newDispatchForm(github.Workflow{}, "main", inputs, nil)
+
+	if !form.fields[0].isText() {
+		t.Fatal("environment without known environments should be a text field")
+	}
+	if got := form.fields[0].value(); got != "qa" {
+		t.Errorf("value = %q, want qa", got)
+	}
+}
+
+func TestDispatchForm_Values(t *testing.T) {
+	t.Run("required field missing", func(t *testing.T) {
+		form := newDispatchForm(github.Workflow{}, "main", testDispatchInputs(), nil)
+
+		_, err := form.values()
+		if err == nil || !strings.Contains(err.Error(), "version is required") {
+			t.Errorf("values() error = %v, want required error", err)
+		}
+	})
+
+	t.Run("invalid number", func(t *testing.T) {
+		form := newDispatchForm(github.Workflow{}, "main", testDispatchInputs(), nil)
+		form.fields[0].text.SetValue("1.2.3")
+		form.fields[4].text.SetValue("abc")
+
+		_, err := form.values()
+		if err == nil || !strings.Contains(err.Error(), "replicas must be a number") {
+			t.Errorf("values() error = %v, want number error", err)
+		}
+	})
+
+	t.Run("valid form", func(t *testing.T) {
+		form := newDispatchForm(github.Workflow{}, "main", testDispatchInputs(), nil)
+		form.fields[0].text.SetValue("1.2.3")
+
+		values, err := form.values()
+		if err != nil {
+			t.Fatalf("values() error = %v", err)
+		}
+		want := map[string]string{"version": "1.2.3", "dry_run": "true", "target": "production", "replicas": "2"}
+		for k, v := range want {
+			if values[k] != v {
+				t.Errorf("values()[%q] = %q, want %q", k, values[k], v)
+			}
+		}
+		if _, ok := values["env"]; ok {
+			t.Error("empty optional input should be omitted")
+		}
+	})
+}
+
+func TestDispatchForm_Change(t *testing.T) {
+	form := newDispatchForm(github.Workflow{}, "main", testDispatchInputs(), nil)
+
+	form.focus(1)
+	form.change(1)
+	if form.fields[1].checked {
+		t.Error("change() should toggle boolean off")
+	}
+
+	form.focus(2)
+	form.change(1)
+	if got := form.fields[2].value(); got != "staging" {
+		t.Errorf("choice after cycling = %q, want staging (wraps around)", got)
+	}
+	form.change(-1)
+	if got := form.fields[2].value(); got != "production" {
+		t.Errorf("choice after cycling back = %q, want production", got)
+	}
+}
+
+func TestDispatchForm_FocusWraps(t *testing.T) {
+	form := newDispatchForm(github.Workflow{}, "main", testDispatchInputs(), nil)
+
+	form.focus(-1)
+	if form.focused != 4 {
+		t.Errorf("focused = %d, want 4", form.focused)
+	}
+	form.focus(5)
+	if form.focused != 0 {
+		t.Errorf("focused = %d, want 0", form.focused)
+	}
+}
+
+func TestApp_TriggerWorkflow_WithInputs(t *testing.T) {
+	mock := newMockClient(&mockClientState{fileContent: testDispatchWorkflow})
+	app := New(WithClient(mock))
+	app.width = 120
+	app.height = 40
+	app.workflows.SetItems([]github.Workflow{{ID: 1, Name: "Deploy", Path: ".github/workflows/deploy.yml"}})
+
+	app.Update(app.triggerWorkflow()())
+	app.Update(app.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})())
+
+	if app.dispatchForm == nil {
+		t.Fatal("workflow with inputs should open the dispatch form")
+	}
+	if calls := mock.GetFileContentCalls(); len(calls) != 1 || calls[0].Path != ".github/workflows/deploy.yml" || calls[0].Ref != "main" {
+		t.Errorf("GetFileContent calls = %+v", calls)
+	}
+	if view := app.View(); !strings.Contains(view, "Run workflow: Deploy") {
+		t.Error("View() should render the dispatch form")
+	}
+
+	// Submitting without the required input keeps the form open
+	app.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	if app.dispatchForm == nil || app.dispatchForm.err == "" {
+		t.Fatal("submitting an invalid form should show an error")
+	}
+
+	for _, r := range "v1" {
+		app.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	app.handleKeyPress(tea.KeyMsg{Type: tea.KeyTab})
+	app.handleKeyPress(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}})
+
+	cmd := app.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("submitting a valid form should trigger the workflow")
+	}
+	if app.dispatchForm != nil {
+		t.Error("form should close after submit")
+	}
+	cmd()
+
+	calls := mock.TriggerWorkflowCalls()
+	if len(calls) != 1 {
+		t.Fatalf("TriggerWorkflow calls = %d, want 1", len(calls))
+	}
+	if calls[0].WorkflowFile != "deploy.yml" {
+		t.Errorf("WorkflowFile = %q, want deploy.yml", calls[0].WorkflowFile)
+	}
+	if calls[0].Inputs["version"] != "v1" || calls[0].Inputs["dry_run"] != "true" || calls[0].Inputs["target"] != "staging" {
+		t.Errorf("Inputs = %v", calls[0].Inputs)
+	}
+}
+
+func TestApp_TriggerWorkflow_WithoutInputs(t *testing.T) {
+	mock := newMockClient(&mockClientState{fileContent: "on: workflow_dispatch\n"})
+	app := New(WithClient(mock))
+	app.workflows.SetItems([]github.Workflow{{ID: 1, Name: "CI", Path: ".github/workflows/ci.yml"}})
+
+	app.Update(app.triggerWorkflow()())
+	_, cmd := app.Update(app.selectRef()())
+	if app.dispatchForm != nil {
+		t.Error("workflow without inputs should not open the form")
+	}
+	if cmd == nil {
+		t.Fatal("workflow without inputs should be triggered directly")
+	}
+	if _, ok := cmd().(WorkflowTriggeredMsg); !ok {
+		t.Error("expected WorkflowTriggeredMsg")
+	}
+	if calls := mock.TriggerWorkflowCalls(); len(calls) != 1 || calls[0].Ref != "main" {
+		t.Errorf("TriggerWorkflow calls = %+v", calls)
+	}
+}
+
+func TestApp_TriggerWorkflow_NotDispatchable(t *testing.T) {
+	app := New()
+
+	_, cmd := app.Update(DispatchInputsLoadedMsg{
+		Workflow: github.Workflow{Name: "CI"},
+		Err:      github.ErrNoWorkflowDispatch,
+	})
+	if cmd == nil {
+		t.Fatal("expected flash message command")
+	}
+	if app.err != nil {
+		t.Errorf("err = %v, want nil", app.err)
+	}
+	if app.dispatchForm != nil {
+		t.Error("form should not open")
+	}
+}
+
+func TestApp_DispatchForm_Escape(t *testing.T) {
+	app := New()
+	app.dispatchForm = newDispatchForm(github.Workflow{}, "main", testDispatchInputs(), nil)
+
+	app.handleKeyPress(tea.KeyMsg{Type: tea.KeyEsc})
+	if app.dispatchForm != nil {
+		t.Error("Esc should close the dispatch form")
+	}
+}
@@ -0,0 +1,73 @@
+package app
+
+import (
+	"path"
+	"strings"
+	"time"
+
+	"github.com/nnnkkk7/lazyactions/github"
+)
+
+// Filter fields of the items of each pane
+
+// shortSHALength is the length of the abbreviated commit SHAs that sha:
+// terms also match
+const shortSHALength = 7
+
+var repoFields = &queryFields[github.Repository]{
+	fields: map[string]queryField[github.Repository]{
+		"owner": {text: func(r github.Repository) []string { return []string{r.Owner} }},
+		"name":  {text: func(r github.Repository) []string { return []string{r.Name} }},
+	},
+	text: func(r github.Repository) []string { return []string{r.FullName()} },
+}
+
+var workflowFields = &queryFields[github.Workflow]{
+	fields: map[string]queryField[github.Workflow]{
+		"name":  {text: func(w github.Workflow) []string { return []string{w.Name} }},
+		"file":  {text: func(w github.Workflow) []string { return []string{path.Base(w.Path)} }},
+		"state": {text: func(w github.Workflow) []string { return []string{w.State} }},
+	},
+	text: func(w github.Workflow) []string { return []string{w.Name} },
+}
+
+var runFields = &queryFields[github.Run]{
+	fields: map[string]queryField[github.Run]{
+		// Like the API, status: also matches conclusions
+		"status":     {text: func(r github.Run) []string { return []string{r.Status, r.Conclusion} }},
+		"conclusion": {text: func(r github.Run) []string { return []string{r.Conclusion} }},
+		"branch":     {text: func(r github.Run) []string { return []string{r.Branch} }},
+		"actor":      {text: func(r github.Run) []string { return actorNames(r.Actor) }},
+		"event":      {text: func(r github.Run) []string { return []string{r.Event} }},
+		"name":       {text: func(r github.Run) []string { return []string{r.Name} }},
+		"sha":        {text: func(r github.Run) []string { return []string{r.HeadSHA, shortSHA(r.HeadSHA)} }},
+		"created":    {date: func(r github.Run) time.Time { return r.CreatedAt }},
+	},
+	text: func(r github.Run) []string { return []string{r.Branch, r.Actor} },
+}
+
+var jobFields = &queryFields[github.Job]{
+	fields: map[string]queryField[github.Job]{
+		"name":       {text: func(j github.Job) []string { return []string{j.Name} }},
+		"status":     {text: func(j github.Job) []string { return []string{j.Status, j.Conclusion} }},
+		"conclusion": {text: func(j github.Job) []string { return []string{j.Conclusion} }},
+	},
+	text: func(j github.Job) []string { return []string{j.Name} },
+}
+
+// shortSHA abbreviates a commit SHA
+func shortSHA(sha string) string {
+	if len(sha) > shortSHALength {
+		return sha[:shortSHALength]
+	}
+	return sha
+}
+
+// actorNames returns the names an actor matches: its login, and that of a
+// bot without its [bot] suffix, as in -actor:dependabot
+func actorNames(login string) []string {
+	if name, ok := strings.CutSuffix(login, "[bot]"); ok {
+		return []string{login, name}
+	}
+	return []string{login}
+}
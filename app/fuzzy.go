@@ -0,0 +1,113 @@
+package app
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Fuzzy match scoring
+const (
+	// fuzzyScoreMatch is scored for every matched character
+	fuzzyScoreMatch = 16
+	// fuzzyBonusConsecutive is scored for a character right after the previous match
+	fuzzyBonusConsecutive = 12
+	// fuzzyBonusBoundary is scored for a character starting a word
+	fuzzyBonusBoundary = 10
+	// fuzzyBonusFirst is scored if the match starts the text
+	fuzzyBonusFirst = 8
+	// fuzzyPenaltyGapStart is deducted for every gap between matches
+	fuzzyPenaltyGapStart = 3
+	// fuzzyPenaltyGapExtension is deducted for every character skipped after the first of a gap
+	fuzzyPenaltyGapExtension = 1
+)
+
+// fuzzyMatch reports whether the characters of pattern appear in text in
+// order, ignoring case, and scores the best such match: consecutive
+// characters and characters starting words score higher, gaps lower.
+// positions are the rune indexes of the matched characters of text.
+func fuzzyMatch(pattern, text string) (score int, positions []int, ok bool) {
+	p := []rune(strings.ToLower(pattern))
+	t := []rune(text)
+	if len(p) == 0 {
+		return 0, nil, true
+	}
+
+	lower := make([]rune, len(t))
+	for i, r := range t {
+		lower[i] = unicode.ToLower(r)
+	}
+
+	// Try every start of the match and keep the best
+	best := -1
+	for start := range lower {
+		if lower[start] != p[0] {
+			continue
+		}
+		pos := matchFrom(p, lower, start)
+		if pos == nil {
+			// No later start can match either
+			break
+		}
+		if s := scoreFuzzyMatch(t, pos); s > best {
+			best, positions = s, pos
+		}
+	}
+	if positions == nil {
+		return 0, nil, false
+	}
+	return best, positions, true
+}
+
+// matchFrom matches the pattern greedily from start, or returns nil
+func matchFrom(pattern, text []rune, start int) []int {
+	positions := make([]int, 0, len(pattern))
+	pi := 0
+	for i := start; i < len(text) && pi < len(pattern); i++ {
+		if text[i] == pattern[pi] {
+			positions = append(positions, i)
+			pi++
+		}
+	}
+	if pi < len(pattern) {
+		return nil
+	}
+	return positions
+}
+
+// scoreFuzzyMatch scores the matched positions of text
+func scoreFuzzyMatch(text []rune, positions []int) int {
+	score := 0
+	for i, pos := range positions {
+		score += fuzzyScoreMatch
+		if isWordStart(text, pos) {
+			score += fuzzyBonusBoundary
+		}
+		if i > 0 {
+			if gap := pos - positions[i-1] - 1; gap == 0 {
+				score += fuzzyBonusConsecutive
+			} else {
+				score -= fuzzyPenaltyGapStart + (gap-1)*fuzzyPenaltyGapExtension
+			}
+		}
+	}
+	if positions[0] == 0 {
+		score += fuzzyBonusFirst
+	}
+	return score
+}
+
+// isWordStart reports whether the character at i starts a word: it follows
+// a separator or is an upper-case letter after a lower-case one
+func isWordStart(text []rune, i int) bool {
+	if i == 0 {
+		return true
+	}
+	prev, cur := text[i-1], text[i]
+	switch {
+	case !unicode.IsLetter(prev) && !unicode.IsDigit(prev):
+		return true
+	case unicode.IsLower(prev) && unicode.IsUpper(cur):
+		return true
+	}
+	return false
+}
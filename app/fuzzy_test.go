@@ -0,0 +1,51 @@
+package app
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestFuzzyMatch(t *testing.T) {
+	tests := []struct {
+		pattern, text string
+		want          bool
+		positions     []int
+	}{
+		{"dply", "Deploy to production", true, []int{0, 2, 3, 5}},
+		{"DEP", "deploy", true, []int{0, 1, 2}},
+		{"ci", "CI", true, []int{0, 1}},
+		{"", "anything", true, nil},
+		{"xyz", "Deploy", false, nil},
+		{"yd", "Deploy", false, nil},
+		// The later, consecutive occurrence scores higher
+		{"test", "the e2e tests", true, []int{8, 9, 10, 11}},
+	}
+	for _, tt := range tests {
+		_, positions, ok := fuzzyMatch(tt.pattern, tt.text)
+		if ok != tt.want || !slices.Equal(positions, tt.positions) {
+			t.Errorf("fuzzyMatch(%q, %q) = %v, %v, want %v, %v", tt.pattern, tt.text, positions, ok, tt.positions, tt.want)
+		}
+	}
+}
+
+func TestFuzzyMatch_Ranking(t *testing.T) {
+	// Each text should score higher than the next one
+	ranked := []string{
+		"Deploy to production",  // prefix
+		"pre-deploy checks",     // word start
+		"redeploy",              // substring
+		"d-e-p-l-o-y",           // scattered
+		"dev pipeline only yes", // far apart
+	}
+	prev := 0
+	for i, text := range ranked {
+		score, _, ok := fuzzyMatch("deploy", text)
+		if !ok {
+			t.Fatalf("fuzzyMatch(deploy, %q) should match", text)
+		}
+		if i > 0 && score >= prev {
+			t.Errorf("score(%q) = %d, want below %d of %q", text, score, prev, ranked[i-1])
+		}
+		prev = score
+	}
+}
@@ -0,0 +1,259 @@
+package app
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// helpOverlay is the state of the help popup
+type helpOverlay struct {
+	search    textinput.Model
+	searching bool // whether the search input has focus
+	offset    int  // index of the first line shown
+}
+
+// helpLine is a line of the help popup: what the keys of some bindings do
+type helpLine struct {
+	desc     string
+	bindings []key.Binding
+}
+
+// helpSection is a titled group of lines of the help popup. The lines of an
+// inactive section do not apply in the current context.
+type helpSection struct {
+	title  string
+	active bool
+	lines  []helpLine
+}
+
+// helpSections lists the keybindings in use by context
+func (a *App) helpSections() []helpSection {
+	k := a.keys
+	// line describes a binding by its help, lineAs by desc
+	line := func(b key.Binding) helpLine {
+		return helpLine{desc: capitalize(b.Help().Desc), bindings: []key.Binding{b}}
+	}
+	lineAs := func(desc string, bindings ...key.Binding) helpLine {
+		return helpLine{desc: desc, bindings: bindings}
+	}
+	jobs := a.focusedPane == JobsPane
+	return []helpSection{
+		{"Global", true, []helpLine{
+			lineAs("Move in list", k.Down, k.Up),
+			lineAs("Next/previous panel", k.PanelDown, k.PanelUp),
+			line(k.Left),
+			line(k.Right),
+			line(k.Tab),
+			line(k.ShiftTab),
+			line(k.InfoTab),
+			line(k.LogsTab),
+			line(k.Filter),
+			line(k.Refresh),
+			line(k.Yank),
+			line(k.SwitchRemote),
+			line(k.Dashboard),
+			line(k.Palette),
+			line(k.Help),
+			line(k.Escape),
+			line(k.Quit),
+		}},
+		{"Layout", true, []helpLine{
+			line(k.ShrinkSidebar),
+			line(k.GrowSidebar),
+			line(k.ShrinkPane),
+			line(k.GrowPane),
+			line(k.Zoom),
+			line(k.Layout),
+			line(k.Compact),
+		}},
+		{"Workflows", a.focusedPane == WorkflowsPane, []helpLine{
+			line(k.Trigger),
+		}},
+		{"Runs", a.focusedPane == RunsPane, []helpLine{
+			line(k.Cancel),
+			line(k.Rerun),
+			line(k.RerunFailed),
+		}},
+		{"Jobs", jobs, []helpLine{
+			lineAs("Previous/next job", k.JobUp, k.JobDown),
+			line(k.FullLog),
+		}},
+		{"Log view", jobs && a.detailTab == LogsTab, []helpLine{
+			lineAs("Select step or scroll log", k.Down, k.Up),
+			lineAs("Focus log content", k.Enter),
+			lineAs("Back to step list", k.Escape),
+			lineAs("Search the log content", k.Filter),
+			line(k.NextMatch),
+			line(k.PrevMatch),
+			line(k.Wrap),
+			lineAs("Scroll unwrapped lines", k.Left, k.Right),
+			line(k.LineNumbers),
+			line(k.GotoLine),
+			line(k.Visual),
+			lineAs("Fold or unfold a group", k.Enter, k.Fold),
+			line(k.UnfoldAll),
+			line(k.FoldAll),
+		}},
+		{"Fullscreen log", a.fullscreenLog, []helpLine{
+			lineAs("Scroll log", k.Down, k.Up),
+			lineAs("Search the log", k.Filter),
+			line(k.NextMatch),
+			line(k.PrevMatch),
+			line(k.Wrap),
+			lineAs("Scroll unwrapped lines", k.Left, k.Right),
+			line(k.LineNumbers),
+			line(k.GotoLine),
+			line(k.Visual),
+			lineAs("Fold or unfold a group", k.Enter, k.Fold),
+			line(k.UnfoldAll),
+			line(k.FoldAll),
+			lineAs("Exit fullscreen", k.Escape),
+		}},
+	}
+}
+
+// capitalize returns s with its first letter in upper case
+func capitalize(s string) string {
+	r, size := utf8.DecodeRuneInString(s)
+	return string(unicode.ToUpper(r)) + s[size:]
+}
+
+// openHelp shows the help popup, scrolled to the top and without search
+func (a *App) openHelp() {
+	ti := textinput.New()
+	ti.Placeholder = "search"
+	ti.Prompt = "/"
+	a.help = helpOverlay{search: ti}
+	a.showHelp = true
+}
+
+// handleHelpInput handles input when the help popup is shown
+func (a *App) handleHelpInput(msg tea.KeyMsg) tea.Cmd {
+	h := &a.help
+	if h.searching {
+		switch msg.String() {
+		case "esc":
+			h.searching = false
+			h.search.Blur()
+			h.search.SetValue("")
+		case "enter":
+			h.searching = false
+			h.search.Blur()
+		default:
+			var cmd tea.Cmd
+			h.search, cmd = h.search.Update(msg)
+			h.offset = 0
+			return cmd
+		}
+		return nil
+	}
+
+	switch {
+	case key.Matches(msg, a.keys.Quit):
+		return tea.Quit
+	case key.Matches(msg, a.keys.Help), key.Matches(msg, a.keys.Escape):
+		a.showHelp = false
+	case key.Matches(msg, a.keys.Filter):
+		h.searching = true
+		h.search.Focus()
+	case key.Matches(msg, a.keys.Up):
+		a.scrollHelp(-1)
+	case key.Matches(msg, a.keys.Down):
+		a.scrollHelp(1)
+	case msg.String() == "pgup":
+		a.scrollHelp(-a.helpHeight())
+	case msg.String() == "pgdown":
+		a.scrollHelp(a.helpHeight())
+	}
+	return nil
+}
+
+// scrollHelp moves the help popup by delta lines, within its content
+func (a *App) scrollHelp(delta int) {
+	maxOffset := max(len(a.helpLines())-a.helpHeight(), 0)
+	a.help.offset = min(max(a.help.offset+delta, 0), maxOffset)
+}
+
+// helpHeight returns the number of lines of sections the help popup shows
+// at once: the terminal height less the frame, header and footer
+func (a *App) helpHeight() int {
+	return max(a.height-theme.HelpPopup.GetVerticalFrameSize()-4, 1)
+}
+
+// helpLines renders the sections of the help popup that match the search,
+// one string per line. Inactive sections are dimmed.
+func (a *App) helpLines() []string {
+	query := strings.TrimSpace(a.help.search.Value())
+	sections := a.helpSections()
+	keyWidth := HelpKeyWidth
+	for _, s := range sections {
+		for _, l := range s.lines {
+			keyWidth = max(keyWidth, lipgloss.Width(bindingKeys(l.bindings...))+1)
+		}
+	}
+
+	var lines []string
+	for _, s := range sections {
+		style, titleStyle := theme.NormalItem, lipgloss.NewStyle().Bold(true)
+		if !s.active {
+			style, titleStyle = theme.Dimmed, theme.Dimmed
+		}
+
+		var matched []string
+		for _, l := range s.lines {
+			keys := bindingKeys(l.bindings...)
+			_, positions, ok := fuzzyMatch(query, l.desc)
+			if !ok && !strings.Contains(strings.ToLower(keys), strings.ToLower(query)) {
+				continue
+			}
+			matched = append(matched, style.Render(padRight(keys, keyWidth))+renderHighlighted("", l.desc, positions, style))
+		}
+		if len(matched) == 0 {
+			continue
+		}
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, titleStyle.Render(s.title), style.Render(strings.Repeat("─", HelpRuleWidth)))
+		lines = append(lines, matched...)
+	}
+	return lines
+}
+
+// renderHelp renders the help popup, scrolled to the offset of the help
+func (a *App) renderHelp() string {
+	lines := a.helpLines()
+	height := a.helpHeight()
+	offset := min(a.help.offset, max(len(lines)-height, 0))
+	shown := lines[offset:min(offset+height, len(lines))]
+
+	var b strings.Builder
+	b.WriteString(lipgloss.NewStyle().Bold(true).Render("Keybindings"))
+	if a.help.searching || a.help.search.Value() != "" {
+		b.WriteString("  " + a.help.search.View())
+	}
+	b.WriteString("\n\n")
+	if len(lines) == 0 {
+		b.WriteString(theme.Queued.Render("No matching keybindings") + "\n")
+	}
+	for _, l := range shown {
+		b.WriteString(l + "\n")
+	}
+
+	footer := "[/] search  [esc] close"
+	if len(lines) > height {
+		footer = "[↑/↓] scroll  " + footer + "  " +
+			strconv.Itoa(offset+1) + "-" + strconv.Itoa(offset+len(shown)) + " of " + strconv.Itoa(len(lines))
+	}
+	b.WriteString("\n" + theme.Queued.Render(footer))
+	return lipgloss.Place(a.width, a.height,
+		lipgloss.Center, lipgloss.Center,
+		theme.HelpPopup.Render(b.String()))
+}
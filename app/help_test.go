@@ -0,0 +1,71 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestApp_HelpSections_Context(t *testing.T) {
+	app := New()
+	app.focusedPane = JobsPane
+	app.detailTab = LogsTab
+
+	active := map[string]bool{}
+	for _, s := range app.helpSections() {
+		active[s.title] = s.active
+	}
+	want := map[string]bool{
+		"Global": true, "Workflows": false, "Runs": false,
+		"Jobs": true, "Log view": true, "Fullscreen log": false,
+	}
+	for title, w := range want {
+		if active[title] != w {
+			t.Errorf("section %q active = %v, want %v", title, active[title], w)
+		}
+	}
+}
+
+func TestApp_Help_Search(t *testing.T) {
+	app := New()
+	app.width, app.height = 100, 60
+	app.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'?'}})
+	app.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+	typeText(app, "rerun")
+
+	help := app.renderHelp()
+	if !strings.Contains(help, "Rerun failed jobs") || strings.Contains(help, "Quit") || strings.Contains(help, "Global") {
+		t.Errorf("help should only show the matching keybindings:\n%s", help)
+	}
+
+	// Esc clears the search, then closes the help
+	app.handleKeyPress(tea.KeyMsg{Type: tea.KeyEsc})
+	if !app.showHelp || !strings.Contains(app.renderHelp(), "Quit") {
+		t.Error("esc should clear the search first")
+	}
+	app.handleKeyPress(tea.KeyMsg{Type: tea.KeyEsc})
+	if app.showHelp {
+		t.Error("esc should close the help")
+	}
+}
+
+func TestApp_Help_Scroll(t *testing.T) {
+	app := New()
+	app.width, app.height = 100, 20
+	app.openHelp()
+
+	if help := app.renderHelp(); !strings.Contains(help, "1-12 of") || strings.Contains(help, "Exit fullscreen") {
+		t.Errorf("help taller than the terminal should show its first lines:\n%s", help)
+	}
+	for range 100 {
+		app.handleKeyPress(tea.KeyMsg{Type: tea.KeyDown})
+	}
+	if help := app.renderHelp(); !strings.Contains(help, "Exit fullscreen") || strings.Contains(help, "Move in list") {
+		t.Errorf("help should scroll to its last lines:\n%s", help)
+	}
+	app.handleKeyPress(tea.KeyMsg{Type: tea.KeyPgUp})
+	if app.help.offset != len(app.helpLines())-2*app.helpHeight() {
+		t.Errorf("offset = %d after a page up", app.help.offset)
+	}
+}
@@ -17,29 +17,84 @@ func (a *App) handleKeyPress(msg tea.KeyMsg) tea.Cmd {
 		return a.handleConfirmInput(msg)
 	}
 
+	// Handle ref picker
+	if a.refPicker != nil {
+		return a.handleRefPickerInput(msg)
+	}
+
+	// Handle workflow_dispatch input form
+	if a.dispatchForm != nil {
+		return a.handleDispatchFormInput(msg)
+	}
+
+	// Handle remote switcher
+	if a.remoteSwitcher != nil {
+		return a.handleRemoteSwitcherInput(msg)
+	}
+
+	// Handle command palette
+	if a.palette != nil {
+		return a.handlePaletteInput(msg)
+	}
+
+	// Handle help popup
+	if a.showHelp {
+		return a.handleHelpInput(msg)
+	}
+
+	// Handle log search input
+	if a.logSearch.typing {
+		return a.handleLogSearchInput(msg)
+	}
+
+	// Handle the log line to go to, and the log lines selected
+	if a.logGoto != nil {
+		return a.handleLogGotoInput(msg)
+	}
+	if a.logVisual != nil {
+		return a.handleLogVisualInput(msg)
+	}
+
+	// A key typed after the first key of a chord completes it
+	if a.keyPrefix != "" {
+		msg = keyMsg(a.keyPrefix + msg.String())
+		a.keyPrefix = ""
+	}
+
 	switch {
 	case key.Matches(msg, a.keys.Quit):
 		return tea.Quit
 
 	case key.Matches(msg, a.keys.Help):
-		a.showHelp = !a.showHelp
+		a.openHelp()
+
+	case key.Matches(msg, a.keys.Palette):
+		a.openPalette()
 
 	case key.Matches(msg, a.keys.Escape):
-		if a.showHelp {
-			a.showHelp = false
+		if a.inLogView() && a.logSearch.active() {
+			a.clearLogSearch()
 		} else if a.fullscreenLog {
 			a.fullscreenLog = false
 		} else if a.detailTab == LogsTab && a.focusedPane == JobsPane && !a.stepListFocused {
 			// Return focus to step list from log content
 			a.stepListFocused = true
+		} else if a.detailOnDemand() && a.layout.detailShown {
+			a.layout.detailShown = false
 		} else if a.err != nil {
 			a.err = nil
 			return a.refreshAll()
 		}
 
 	case key.Matches(msg, a.keys.Enter):
-		// When in Logs tab with step list focused, Enter focuses on log content
-		if a.detailTab == LogsTab && a.focusedPane == JobsPane && a.stepListFocused {
+		// In a compact layout without room for it, Enter shows the detail panel
+		if a.detailOnDemand() && !a.layout.detailShown {
+			a.showDetail()
+		} else if a.inLogView() {
+			// In the log content, Enter folds or unfolds a group
+			a.toggleLogFold()
+		} else if a.detailTab == LogsTab && a.focusedPane == JobsPane && a.stepListFocused {
+			// When in Logs tab with step list focused, Enter focuses on log content
 			a.stepListFocused = false
 		}
 
@@ -49,17 +104,14 @@ func (a *App) handleKeyPress(msg tea.KeyMsg) tea.Cmd {
 	case key.Matches(msg, a.keys.Down):
 		return a.navigateDown()
 
-	case key.Matches(msg, a.keys.JobUp):
-		if a.focusedPane == JobsPane {
-			a.jobs.SelectPrev()
-			return a.onJobSelectionChange()
-		}
+	// Actions of a pane match only there, so that panes may share keys; see actionPanes
+	case a.focusedPane == JobsPane && key.Matches(msg, a.keys.JobUp):
+		a.jobs.SelectPrev()
+		return a.onJobSelectionChange()
 
-	case key.Matches(msg, a.keys.JobDown):
-		if a.focusedPane == JobsPane {
-			a.jobs.SelectNext()
-			return a.onJobSelectionChange()
-		}
+	case a.focusedPane == JobsPane && key.Matches(msg, a.keys.JobDown):
+		a.jobs.SelectNext()
+		return a.onJobSelectionChange()
 
 	case key.Matches(msg, a.keys.PanelUp):
 		return a.focusPrevPaneWithSelect()
@@ -67,40 +119,79 @@ func (a *App) handleKeyPress(msg tea.KeyMsg) tea.Cmd {
 	case key.Matches(msg, a.keys.PanelDown):
 		return a.focusNextPaneWithSelect()
 
+	// Unwrapped log lines scroll horizontally
+	case a.inLogView() && !a.logWrap && key.Matches(msg, a.keys.Left):
+		a.logView.ScrollLeft()
+
+	case a.inLogView() && !a.logWrap && key.Matches(msg, a.keys.Right):
+		a.logView.ScrollRight()
+
 	case key.Matches(msg, a.keys.Left), key.Matches(msg, a.keys.ShiftTab):
 		a.focusPrevPane()
 
 	case key.Matches(msg, a.keys.Right), key.Matches(msg, a.keys.Tab):
 		a.focusNextPane()
 
+	// In the log content, the filter key searches the logs
+	case a.inLogView() && key.Matches(msg, a.keys.Filter):
+		a.startLogSearch()
+
+	case a.inLogView() && key.Matches(msg, a.keys.NextMatch):
+		a.nextLogMatch(1)
+
+	case a.inLogView() && key.Matches(msg, a.keys.PrevMatch):
+		a.nextLogMatch(-1)
+
+	case a.inLogView() && key.Matches(msg, a.keys.GotoLine):
+		a.startLogGoto()
+
+	case a.inLogView() && key.Matches(msg, a.keys.Visual):
+		a.startLogVisual()
+
+	case a.inLogView() && key.Matches(msg, a.keys.Fold):
+		a.toggleLogFold()
+
+	case a.inLogView() && key.Matches(msg, a.keys.UnfoldAll):
+		a.setLogFolds(true)
+
+	case a.inLogView() && key.Matches(msg, a.keys.FoldAll):
+		a.setLogFolds(false)
+
+	case a.inLogView() && startsChord(msg, a.keys.Fold, a.keys.UnfoldAll, a.keys.FoldAll):
+		a.keyPrefix = msg.String()
+
 	case key.Matches(msg, a.keys.Filter):
 		a.filtering = true
+		a.filterInput.SetValue(a.paneFilter())
+		a.filterInput.CursorEnd()
 		a.filterInput.Focus()
 
-	case key.Matches(msg, a.keys.FullLog):
-		if a.focusedPane == JobsPane {
-			a.fullscreenLog = true
-		}
+	case a.focusedPane == JobsPane && key.Matches(msg, a.keys.FullLog):
+		a.fullscreenLog = true
 
-	case key.Matches(msg, a.keys.Cancel):
-		if a.focusedPane == RunsPane {
-			return a.confirmCancelRun()
-		}
+	case a.focusedPane == JobsPane && key.Matches(msg, a.keys.Wrap):
+		return a.toggleLogWrap()
 
-	case key.Matches(msg, a.keys.Rerun):
-		if a.focusedPane == RunsPane {
-			return a.rerunWorkflow()
-		}
+	case a.focusedPane == JobsPane && key.Matches(msg, a.keys.LineNumbers):
+		a.toggleLogLineNumbers()
 
-	case key.Matches(msg, a.keys.RerunFailed):
-		if a.focusedPane == RunsPane {
-			return a.rerunFailedJobs()
-		}
+	case a.focusedPane == RunsPane && key.Matches(msg, a.keys.Cancel):
+		return a.confirmCancelRun()
 
-	case key.Matches(msg, a.keys.Trigger):
-		if a.focusedPane == WorkflowsPane {
-			return a.triggerWorkflow()
-		}
+	case a.focusedPane == RunsPane && key.Matches(msg, a.keys.Rerun):
+		return a.rerunWorkflow()
+
+	case a.focusedPane == RunsPane && key.Matches(msg, a.keys.RerunFailed):
+		return a.rerunFailedJobs()
+
+	case a.focusedPane == WorkflowsPane && key.Matches(msg, a.keys.Trigger):
+		return a.triggerWorkflow()
+
+	case key.Matches(msg, a.keys.SwitchRemote):
+		return a.openRemoteSwitcher()
+
+	case key.Matches(msg, a.keys.Dashboard):
+		return a.toggleDashboard()
 
 	case key.Matches(msg, a.keys.Yank):
 		return a.yankURL()
@@ -108,11 +199,34 @@ func (a *App) handleKeyPress(msg tea.KeyMsg) tea.Cmd {
 	case key.Matches(msg, a.keys.Refresh):
 		return a.refreshAll()
 
+	case key.Matches(msg, a.keys.ShrinkSidebar):
+		a.resizeSidebar(-LayoutResizeStep)
+
+	case key.Matches(msg, a.keys.GrowSidebar):
+		a.resizeSidebar(LayoutResizeStep)
+
+	case key.Matches(msg, a.keys.ShrinkPane):
+		a.resizePane(-1)
+
+	case key.Matches(msg, a.keys.GrowPane):
+		a.resizePane(1)
+
+	case key.Matches(msg, a.keys.Zoom):
+		a.toggleZoom()
+
+	case key.Matches(msg, a.keys.Layout):
+		return a.cycleLayout()
+
+	case key.Matches(msg, a.keys.Compact):
+		return a.toggleCompact()
+
 	case key.Matches(msg, a.keys.InfoTab):
 		a.detailTab = InfoTab
+		a.showDetail()
 
 	case key.Matches(msg, a.keys.LogsTab):
 		a.detailTab = LogsTab
+		a.showDetail()
 	}
 
 	return nil
@@ -124,17 +238,21 @@ func (a *App) handleFilterInput(msg tea.KeyMsg) tea.Cmd {
 	case "esc":
 		a.filtering = false
 		a.filterInput.Blur()
-		a.applyFilter("")
+		return a.applyFilter("")
 	case "enter":
 		a.filtering = false
 		a.filterInput.Blur()
-		a.applyFilter(a.filterInput.Value())
+		return a.applyFilter(a.filterInput.Value())
 	default:
 		var cmd tea.Cmd
+		prev := a.filterInput.Value()
 		a.filterInput, cmd = a.filterInput.Update(msg)
+		if filter := a.filterInput.Value(); filter != prev {
+			// Filter live on every keystroke
+			return tea.Batch(cmd, a.previewFilter(filter))
+		}
 		return cmd
 	}
-	return nil
 }
 
 // handleConfirmInput handles input when in confirm dialog
@@ -152,21 +270,135 @@ func (a *App) handleConfirmInput(msg tea.KeyMsg) tea.Cmd {
 	return nil
 }
 
-// applyFilter applies filter to the currently focused pane
-func (a *App) applyFilter(filter string) {
+// handleRefPickerInput handles input when the ref picker is shown
+func (a *App) handleRefPickerInput(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc":
+		a.refPicker = nil
+	case "enter":
+		return a.selectRef()
+	default:
+		return a.refPicker.update(msg)
+	}
+	return nil
+}
+
+// handleDispatchFormInput handles input when the dispatch form is shown
+func (a *App) handleDispatchFormInput(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc":
+		a.dispatchForm = nil
+	case "enter":
+		return a.submitDispatchForm()
+	case "tab", "down":
+		a.dispatchForm.focus(a.dispatchForm.focused + 1)
+	case "shift+tab", "up":
+		a.dispatchForm.focus(a.dispatchForm.focused - 1)
+	default:
+		return a.dispatchForm.update(msg)
+	}
+	return nil
+}
+
+// handleRemoteSwitcherInput handles input when the remote switcher is shown
+func (a *App) handleRemoteSwitcherInput(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc":
+		a.remoteSwitcher = nil
+	case "enter":
+		if r, ok := a.remoteSwitcher.list.Selected(); ok {
+			return a.switchRemote(r)
+		}
+		a.remoteSwitcher = nil
+	case "up", "k", "ctrl+p":
+		a.remoteSwitcher.list.SelectPrev()
+	case "down", "j", "ctrl+n":
+		a.remoteSwitcher.list.SelectNext()
+	}
+	return nil
+}
+
+// handlePaletteInput handles input when the command palette is shown
+func (a *App) handlePaletteInput(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc":
+		a.palette = nil
+	case "enter":
+		return a.runPaletteCommand()
+	default:
+		return a.palette.update(msg)
+	}
+	return nil
+}
+
+// applyFilter applies filter to the currently focused pane.
+// Terms of a Runs pane filter may reload the runs from the API.
+func (a *App) applyFilter(filter string) tea.Cmd {
 	switch a.focusedPane {
+	case ReposPane:
+		a.repos.SetFilter(filter)
 	case WorkflowsPane:
 		a.workflows.SetFilter(filter)
 	case RunsPane:
-		a.runs.SetFilter(filter)
+		a.runHistory.filter = filter
+		return a.applyRunsFilter()
 	case JobsPane:
 		a.jobs.SetFilter(filter)
 	}
+	return nil
+}
+
+// paneFilter returns the filter of the currently focused pane, as typed
+func (a *App) paneFilter() string {
+	switch a.focusedPane {
+	case ReposPane:
+		return a.repos.Filter()
+	case WorkflowsPane:
+		return a.workflows.Filter()
+	case RunsPane:
+		return a.runHistory.filter
+	case JobsPane:
+		return a.jobs.Filter()
+	}
+	return ""
+}
+
+// previewFilter applies filter to the currently focused pane while it is
+// typed. Server-side terms of a Runs pane filter wait for typing to pause.
+func (a *App) previewFilter(filter string) tea.Cmd {
+	if a.focusedPane == RunsPane {
+		return a.previewRunsFilter(filter)
+	}
+	return a.applyFilter(filter)
+}
+
+// filterError returns the syntax error of the filter being typed, if any
+func (a *App) filterError() error {
+	filter := a.filterInput.Value()
+	var err error
+	switch a.focusedPane {
+	case ReposPane:
+		_, err = parseQuery(filter, repoFields)
+	case WorkflowsPane:
+		_, err = parseQuery(filter, workflowFields)
+	case RunsPane:
+		if a.dashboard != nil {
+			_, err = parseQuery(filter, dashboardFields(a.dashboard))
+		} else {
+			_, err = parseQuery(filter, runFields)
+		}
+	case JobsPane:
+		_, err = parseQuery(filter, jobFields)
+	}
+	return err
 }
 
 // navigateUp moves selection up in the current pane
 func (a *App) navigateUp() tea.Cmd {
 	switch a.focusedPane {
+	case ReposPane:
+		a.repos.SelectPrev()
+		return a.onRepoSelectionChange()
 	case WorkflowsPane:
 		a.workflows.SelectPrev()
 		return a.onWorkflowSelectionChange()
@@ -193,6 +425,9 @@ func (a *App) navigateUp() tea.Cmd {
 // navigateDown moves selection down in the current pane
 func (a *App) navigateDown() tea.Cmd {
 	switch a.focusedPane {
+	case ReposPane:
+		a.repos.SelectNext()
+		return a.onRepoSelectionChange()
 	case WorkflowsPane:
 		a.workflows.SelectNext()
 		return a.onWorkflowSelectionChange()
@@ -216,47 +451,54 @@ func (a *App) navigateDown() tea.Cmd {
 	return nil
 }
 
-// focusPrevPane moves focus to the previous pane
-func (a *App) focusPrevPane() {
-	switch a.focusedPane {
-	case RunsPane:
-		a.focusedPane = WorkflowsPane
-	case JobsPane:
-		a.focusedPane = RunsPane
+// focusPrevPane moves focus to the previous pane and reports whether it moved
+func (a *App) focusPrevPane() bool {
+	panes := a.leftPanes()
+	for i, p := range panes {
+		if p == a.focusedPane && i > 0 {
+			a.focusedPane = panes[i-1]
+			return true
+		}
 	}
+	return false
 }
 
-// focusNextPane moves focus to the next pane
-func (a *App) focusNextPane() {
-	switch a.focusedPane {
-	case WorkflowsPane:
-		a.focusedPane = RunsPane
-	case RunsPane:
-		a.focusedPane = JobsPane
+// focusNextPane moves focus to the next pane and reports whether it moved
+func (a *App) focusNextPane() bool {
+	panes := a.leftPanes()
+	for i, p := range panes {
+		if p == a.focusedPane && i < len(panes)-1 {
+			a.focusedPane = panes[i+1]
+			return true
+		}
 	}
+	return false
 }
 
 // focusPrevPaneWithSelect moves to previous panel and triggers data loading
 func (a *App) focusPrevPaneWithSelect() tea.Cmd {
-	switch a.focusedPane {
-	case RunsPane:
-		a.focusedPane = WorkflowsPane
-		return a.onWorkflowSelectionChange()
-	case JobsPane:
-		a.focusedPane = RunsPane
-		return a.onRunSelectionChange()
+	if !a.focusPrevPane() {
+		return nil
 	}
-	return nil
+	return a.onPaneSelectionChange()
 }
 
 // focusNextPaneWithSelect moves to next panel and triggers data loading
 func (a *App) focusNextPaneWithSelect() tea.Cmd {
+	if !a.focusNextPane() {
+		return nil
+	}
+	return a.onPaneSelectionChange()
+}
+
+// onPaneSelectionChange loads the data of the selection in the focused pane
+func (a *App) onPaneSelectionChange() tea.Cmd {
 	switch a.focusedPane {
 	case WorkflowsPane:
-		a.focusedPane = RunsPane
-		return a.onRunSelectionChange()
+		return a.onWorkflowSelectionChange()
 	case RunsPane:
-		a.focusedPane = JobsPane
+		return a.onRunSelectionChange()
+	case JobsPane:
 		return a.onJobSelectionChange()
 	}
 	return nil
@@ -224,6 +224,52 @@ func TestApp_HandleFilterInput_Enter(t *testing.T) {
 	}
 }
 
+func TestApp_HandleFilterInput_Live(t *testing.T) {
+	app := New()
+	app.workflows.SetItems([]github.Workflow{{ID: 1, Name: "CI"}, {ID: 2, Name: "Deploy to production"}})
+	app.filtering = true
+	app.filterInput.Focus()
+
+	for _, r := range "dply" {
+		app.handleFilterInput(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	if wf, _ := app.workflows.Selected(); app.workflows.Len() != 1 || wf.ID != 2 {
+		t.Errorf("workflows = %+v, want the filter applied while typing", app.workflows.Items())
+	}
+	if !app.filtering {
+		t.Error("typing should stay in filtering mode")
+	}
+}
+
+func TestApp_HandleFilterInput_LiveRuns(t *testing.T) {
+	mock := newMockClient(nil)
+	app := New(WithClient(mock))
+	app.workflows.SetItems([]github.Workflow{{ID: 1, Name: "CI"}})
+	app.runs.SetItems([]github.Run{{ID: 1, Branch: "main"}, {ID: 2, Branch: "feature"}})
+	app.focusedPane = RunsPane
+	app.filtering = true
+	app.filterInput.Focus()
+
+	for _, r := range "status:failure" {
+		if cmd := app.handleFilterInput(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}}); cmd == nil {
+			t.Fatal("every keystroke should schedule the server-side filter")
+		}
+	}
+	if len(mock.ListRunsCalls()) != 0 || app.runs.Len() != 0 {
+		t.Fatal("runs should be filtered locally, not reloaded on every keystroke")
+	}
+
+	// Only the last keystroke applies the server-side terms
+	app.Update(RunsFilterDebounceMsg{Seq: app.runHistory.filterSeq - 1})
+	if app.runHistory.opts.Status != "" {
+		t.Fatal("a stale keystroke should not apply the filter")
+	}
+	_, reload := app.Update(RunsFilterDebounceMsg{Seq: app.runHistory.filterSeq})
+	if app.runHistory.opts.Status != "failure" || reload == nil {
+		t.Errorf("opts = %+v, want status:failure applied once typing paused", app.runHistory.opts)
+	}
+}
+
 func TestApp_HandleConfirmInput_Yes(t *testing.T) {
 	app := New()
 	app.showConfirm = true
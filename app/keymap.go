@@ -1,33 +1,64 @@
 package app
 
-import "github.com/charmbracelet/bubbles/key"
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nnnkkk7/lazyactions/config"
+)
 
 // KeyMap defines all keybindings for the application
 type KeyMap struct {
-	Up          key.Binding
-	Down        key.Binding
-	Left        key.Binding
-	Right       key.Binding
-	PanelUp     key.Binding
-	PanelDown   key.Binding
-	Tab         key.Binding
-	ShiftTab    key.Binding
-	Enter       key.Binding
-	Trigger     key.Binding
-	Cancel      key.Binding
-	Rerun       key.Binding
-	RerunFailed key.Binding
-	Yank        key.Binding
-	Filter      key.Binding
-	Refresh     key.Binding
-	FullLog     key.Binding
-	Help        key.Binding
-	Quit        key.Binding
-	Escape      key.Binding
-	InfoTab     key.Binding
-	LogsTab     key.Binding
-	JobUp       key.Binding
-	JobDown     key.Binding
+	Up            key.Binding
+	Down          key.Binding
+	Left          key.Binding
+	Right         key.Binding
+	PanelUp       key.Binding
+	PanelDown     key.Binding
+	Tab           key.Binding
+	ShiftTab      key.Binding
+	Enter         key.Binding
+	Trigger       key.Binding
+	Cancel        key.Binding
+	Rerun         key.Binding
+	RerunFailed   key.Binding
+	Yank          key.Binding
+	SwitchRemote  key.Binding
+	Dashboard     key.Binding
+	Filter        key.Binding
+	Refresh       key.Binding
+	FullLog       key.Binding
+	Help          key.Binding
+	Quit          key.Binding
+	Escape        key.Binding
+	InfoTab       key.Binding
+	LogsTab       key.Binding
+	JobUp         key.Binding
+	JobDown       key.Binding
+	Palette       key.Binding
+	ShrinkSidebar key.Binding
+	GrowSidebar   key.Binding
+	ShrinkPane    key.Binding
+	GrowPane      key.Binding
+	Zoom          key.Binding
+	Layout        key.Binding
+	Compact       key.Binding
+	NextMatch     key.Binding
+	PrevMatch     key.Binding
+	Wrap          key.Binding
+	LineNumbers   key.Binding
+	GotoLine      key.Binding
+	Visual        key.Binding
+	Fold          key.Binding
+	UnfoldAll     key.Binding
+	FoldAll       key.Binding
 }
 
 // DefaultKeyMap returns the default keybindings
@@ -43,11 +74,11 @@ func DefaultKeyMap() KeyMap {
 		),
 		Left: key.NewBinding(
 			key.WithKeys("h", "left"),
-			key.WithHelp("h/←", "detail view"),
+			key.WithHelp("h/←", "previous pane"),
 		),
 		Right: key.NewBinding(
 			key.WithKeys("l", "right"),
-			key.WithHelp("l/→", "detail view"),
+			key.WithHelp("l/→", "next pane"),
 		),
 		PanelUp: key.NewBinding(
 			key.WithKeys("k"),
@@ -89,6 +120,14 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("y"),
 			key.WithHelp("y", "copy to clipboard"),
 		),
+		SwitchRemote: key.NewBinding(
+			key.WithKeys("o"),
+			key.WithHelp("o", "switch git remote"),
+		),
+		Dashboard: key.NewBinding(
+			key.WithKeys("D"),
+			key.WithHelp("D", "dashboard of all repositories"),
+		),
 		Filter: key.NewBinding(
 			key.WithKeys("/"),
 			key.WithHelp("/", "filter"),
@@ -129,5 +168,349 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("s"),
 			key.WithHelp("s", "next job"),
 		),
+		Palette: key.NewBinding(
+			key.WithKeys("ctrl+p"),
+			key.WithHelp("ctrl+p", "command palette"),
+		),
+		ShrinkSidebar: key.NewBinding(
+			key.WithKeys("<"),
+			key.WithHelp("<", "shrink sidebar"),
+		),
+		GrowSidebar: key.NewBinding(
+			key.WithKeys(">"),
+			key.WithHelp(">", "grow sidebar"),
+		),
+		ShrinkPane: key.NewBinding(
+			key.WithKeys("-"),
+			key.WithHelp("-", "shrink pane"),
+		),
+		GrowPane: key.NewBinding(
+			key.WithKeys("+", "="),
+			key.WithHelp("+/=", "grow pane"),
+		),
+		Zoom: key.NewBinding(
+			key.WithKeys("Z"),
+			key.WithHelp("Z", "zoom pane"),
+		),
+		Layout: key.NewBinding(
+			key.WithKeys("V"),
+			key.WithHelp("V", "switch layout"),
+		),
+		Compact: key.NewBinding(
+			key.WithKeys("C"),
+			key.WithHelp("C", "compact layout"),
+		),
+		NextMatch: key.NewBinding(
+			key.WithKeys("n"),
+			key.WithHelp("n", "next match"),
+		),
+		PrevMatch: key.NewBinding(
+			key.WithKeys("N"),
+			key.WithHelp("N", "previous match"),
+		),
+		Wrap: key.NewBinding(
+			key.WithKeys("W"),
+			key.WithHelp("W", "wrap log lines"),
+		),
+		LineNumbers: key.NewBinding(
+			key.WithKeys("#"),
+			key.WithHelp("#", "show log line numbers"),
+		),
+		GotoLine: key.NewBinding(
+			key.WithKeys(":"),
+			key.WithHelp(":", "go to log line"),
+		),
+		Visual: key.NewBinding(
+			key.WithKeys("v"),
+			key.WithHelp("v", "select log lines to copy"),
+		),
+		// Fold commands are chords starting with z, as in vim
+		Fold: key.NewBinding(
+			key.WithKeys("za"),
+			key.WithHelp("za", "fold or unfold a group"),
+		),
+		UnfoldAll: key.NewBinding(
+			key.WithKeys("zR"),
+			key.WithHelp("zR", "unfold all groups"),
+		),
+		FoldAll: key.NewBinding(
+			key.WithKeys("zM"),
+			key.WithHelp("zM", "fold all groups"),
+		),
+	}
+}
+
+// bindings returns the bindings of the KeyMap by action name, the names the
+// keybindings config uses
+func (k *KeyMap) bindings() map[string]*key.Binding {
+	return map[string]*key.Binding{
+		"up":             &k.Up,
+		"down":           &k.Down,
+		"left":           &k.Left,
+		"right":          &k.Right,
+		"panel_up":       &k.PanelUp,
+		"panel_down":     &k.PanelDown,
+		"tab":            &k.Tab,
+		"shift_tab":      &k.ShiftTab,
+		"enter":          &k.Enter,
+		"trigger":        &k.Trigger,
+		"cancel":         &k.Cancel,
+		"rerun":          &k.Rerun,
+		"rerun_failed":   &k.RerunFailed,
+		"yank":           &k.Yank,
+		"switch_remote":  &k.SwitchRemote,
+		"dashboard":      &k.Dashboard,
+		"filter":         &k.Filter,
+		"refresh":        &k.Refresh,
+		"full_log":       &k.FullLog,
+		"help":           &k.Help,
+		"quit":           &k.Quit,
+		"escape":         &k.Escape,
+		"info_tab":       &k.InfoTab,
+		"logs_tab":       &k.LogsTab,
+		"job_up":         &k.JobUp,
+		"job_down":       &k.JobDown,
+		"palette":        &k.Palette,
+		"shrink_sidebar": &k.ShrinkSidebar,
+		"grow_sidebar":   &k.GrowSidebar,
+		"shrink_pane":    &k.ShrinkPane,
+		"grow_pane":      &k.GrowPane,
+		"zoom":           &k.Zoom,
+		"layout":         &k.Layout,
+		"compact":        &k.Compact,
+		"next_match":     &k.NextMatch,
+		"prev_match":     &k.PrevMatch,
+		"wrap":           &k.Wrap,
+		"line_numbers":   &k.LineNumbers,
+		"goto_line":      &k.GotoLine,
+		"visual":         &k.Visual,
+		"fold":           &k.Fold,
+		"unfold_all":     &k.UnfoldAll,
+		"fold_all":       &k.FoldAll,
+	}
+}
+
+// rebind replaces the keys of the bindings named in keys. Unknown action
+// names are ignored.
+func (k *KeyMap) rebind(keys map[string]config.Keys) {
+	bindings := k.bindings()
+	for action, ks := range keys {
+		b, ok := bindings[action]
+		if !ok || len(ks) == 0 {
+			continue
+		}
+		b.SetKeys(ks...)
+		b.SetHelp(keyHelp(ks), b.Help().Desc)
+	}
+}
+
+// arrowSymbols are the symbols shown for arrow keys
+var arrowSymbols = map[string]string{
+	"up":    "↑",
+	"down":  "↓",
+	"left":  "←",
+	"right": "→",
+}
+
+// keyHelp returns how keys are shown in the help, e.g. "h/←"
+func keyHelp(keys []string) string {
+	shown := make([]string, len(keys))
+	for i, k := range keys {
+		shown[i] = cmp.Or(arrowSymbols[k], k)
 	}
+	return strings.Join(shown, "/")
+}
+
+// keyTypes maps the names of special keys, as tea.KeyMsg names them, to
+// their type
+var keyTypes = func() map[string]tea.KeyType {
+	types := make(map[string]tea.KeyType)
+	for t := tea.KeyF20; t <= tea.KeyBackspace; t++ {
+		if name := t.String(); name != "" {
+			types[name] = t
+		}
+	}
+	return types
+}()
+
+// isChord reports whether a binding key is a chord, two keys typed one
+// after the other such as "za"
+func isChord(k string) bool {
+	_, special := keyTypes[k]
+	return utf8.RuneCountInString(k) == 2 && !special
+}
+
+// chordPrefix returns the first key of a chord
+func chordPrefix(chord string) string {
+	r, _ := utf8.DecodeRuneInString(chord)
+	return string(r)
+}
+
+// startsChord reports whether a key press is the first key of a chord of
+// the bindings
+func startsChord(msg tea.KeyMsg, bindings ...key.Binding) bool {
+	for _, b := range bindings {
+		for _, k := range b.Keys() {
+			if isChord(k) && chordPrefix(k) == msg.String() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// keyMsg returns the key press that a binding key names, e.g. "ctrl+r"
+func keyMsg(k string) tea.KeyMsg {
+	alt := false
+	if rest, ok := strings.CutPrefix(k, "alt+"); ok && rest != "" {
+		alt, k = true, rest
+	}
+	if t, ok := keyTypes[k]; ok {
+		return tea.KeyMsg{Type: t, Alt: alt}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k), Alt: alt}
+}
+
+// Keybinding presets, selected by the keymap setting
+const (
+	PresetDefault = "default"
+	PresetVim     = "vim"
+	PresetLazygit = "lazygit"
+	PresetArrows  = "arrows"
+)
+
+// presets are the keys of each preset that differ from DefaultKeyMap
+var presets = map[string]map[string]config.Keys{
+	PresetDefault: {},
+	// j/k move in lists
+	PresetVim: {
+		"up":         {"k", "up"},
+		"down":       {"j", "down"},
+		"panel_up":   {"K"},
+		"panel_down": {"J"},
+		"job_up":     {"["},
+		"job_down":   {"]"},
+	},
+	// j/k move in lists, h/l and [/] between panels
+	PresetLazygit: {
+		"up":         {"k", "up"},
+		"down":       {"j", "down"},
+		"panel_up":   {"["},
+		"panel_down": {"]"},
+		"job_up":     {","},
+		"job_down":   {"."},
+	},
+	// No letters to move around
+	PresetArrows: {
+		"left":       {"left"},
+		"right":      {"right"},
+		"panel_up":   {"shift+up"},
+		"panel_down": {"shift+down"},
+		"job_up":     {"ctrl+up"},
+		"job_down":   {"ctrl+down"},
+	},
+}
+
+// actionPanes are the panes outside of which actions do nothing, so that
+// actions of different panes may share keys. The other actions apply in
+// every pane.
+var actionPanes = map[string]Pane{
+	"trigger":      WorkflowsPane,
+	"cancel":       RunsPane,
+	"rerun":        RunsPane,
+	"rerun_failed": RunsPane,
+	"job_up":       JobsPane,
+	"job_down":     JobsPane,
+	"full_log":     JobsPane,
+	"next_match":   JobsPane,
+	"prev_match":   JobsPane,
+	"wrap":         JobsPane,
+	"line_numbers": JobsPane,
+	"goto_line":    JobsPane,
+	"visual":       JobsPane,
+	"fold":         JobsPane,
+	"unfold_all":   JobsPane,
+	"fold_all":     JobsPane,
+}
+
+// newKeyMap returns the keys of a preset with the keys of some actions
+// replaced. Unknown presets and actions, and replaced keys that would
+// trigger two actions in the same pane, are reported and ignored.
+func newKeyMap(preset string, keys map[string]config.Keys) (KeyMap, error) {
+	k := DefaultKeyMap()
+	var errs []error
+	if p, ok := presets[preset]; ok {
+		k.rebind(p)
+	} else if preset != "" {
+		errs = append(errs, fmt.Errorf("keymap: unknown preset %q, want one of %s", preset, strings.Join(slices.Sorted(maps.Keys(presets)), ", ")))
+	}
+	base := k
+
+	bindings := k.bindings()
+	actions := slices.Sorted(maps.Keys(bindings))
+	for _, action := range slices.Sorted(maps.Keys(keys)) {
+		if _, ok := bindings[action]; !ok {
+			errs = append(errs, fmt.Errorf("keybindings: unknown action %q, want one of %s", action, strings.Join(actions, ", ")))
+		}
+	}
+	k.rebind(keys)
+
+	// Restore the preset keys of the actions causing conflicts until none is left;
+	// the presets have none, so each conflict has an action left to restore
+	baseBindings := base.bindings()
+	restored := make(map[string]bool)
+	restorable := func(action string) bool {
+		_, replaced := keys[action]
+		return replaced && !restored[action]
+	}
+	for {
+		a, b, key, ok := k.conflict(actions)
+		if !ok {
+			break
+		}
+		if !restorable(b) {
+			a, b = b, a
+		}
+		if !restorable(b) {
+			break
+		}
+		errs = append(errs, fmt.Errorf("keybindings.%s: %q is already bound to %s", b, key, a))
+		*bindings[b] = *baseBindings[b]
+		restored[b] = true
+	}
+	return k, errors.Join(errs...)
+}
+
+// conflict returns two actions that share a key in a pane, in the order
+// of actions. The first key of a chord is taken too, as it waits for the
+// next key instead of triggering an action.
+func (k *KeyMap) conflict(actions []string) (a, b, key string, ok bool) {
+	bindings := k.bindings()
+	owners := make(map[string][]string) // actions of each key
+	chords := make(map[string][]string) // actions of the chords starting with each key
+	for _, action := range actions {
+		for _, key := range bindings[action].Keys() {
+			others := slices.Concat(owners[key], chords[key])
+			if isChord(key) {
+				others = slices.Concat(owners[key], owners[chordPrefix(key)])
+			}
+			for _, other := range others {
+				if other != action && sharePane(other, action) {
+					return other, action, key, true
+				}
+			}
+			owners[key] = append(owners[key], action)
+			if isChord(key) {
+				chords[chordPrefix(key)] = append(chords[chordPrefix(key)], action)
+			}
+		}
+	}
+	return "", "", "", false
+}
+
+// sharePane reports whether two actions apply in a same pane
+func sharePane(a, b string) bool {
+	paneA, scopedA := actionPanes[a]
+	paneB, scopedB := actionPanes[b]
+	return !scopedA || !scopedB || paneA == paneB
 }
@@ -1,9 +1,15 @@
 package app
 
 import (
+	"maps"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nnnkkk7/lazyactions/config"
+	"github.com/nnnkkk7/lazyactions/github"
 )
 
 // =============================================================================
@@ -115,8 +121,8 @@ func TestDefaultKeyMap_HelpText(t *testing.T) {
 	}{
 		{"Up", km.Up, "↑", "move up in list"},
 		{"Down", km.Down, "↓", "move down in list"},
-		{"Left", km.Left, "h/←", "detail view"},
-		{"Right", km.Right, "l/→", "detail view"},
+		{"Left", km.Left, "h/←", "previous pane"},
+		{"Right", km.Right, "l/→", "next pane"},
 		{"PanelUp", km.PanelUp, "k", "previous panel"},
 		{"PanelDown", km.PanelDown, "j", "next panel"},
 		{"Tab", km.Tab, "tab", "next pane"},
@@ -307,3 +313,119 @@ func TestKeyMapStruct_AllFieldsDefined(t *testing.T) {
 
 	// If we get here without a compile error, all fields exist
 }
+
+// =============================================================================
+// Presets and Remapping Tests
+// =============================================================================
+
+func TestPresets_NoConflicts(t *testing.T) {
+	for name := range presets {
+		km, err := newKeyMap(name, nil)
+		if err != nil {
+			t.Errorf("preset %s: %v", name, err)
+		}
+		bindings := km.bindings()
+		if a, b, k, ok := km.conflict(slices.Sorted(maps.Keys(bindings))); ok {
+			t.Errorf("preset %s binds %q to %s and %s", name, k, a, b)
+		}
+	}
+}
+
+func TestNewKeyMap_Presets(t *testing.T) {
+	km, _ := newKeyMap(PresetVim, nil)
+	if !key.Matches(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'j'}}, km.Down) {
+		t.Error("vim: j should move down in lists")
+	}
+	if key.Matches(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'w'}}, km.JobUp) {
+		t.Error("vim: w should not move between jobs")
+	}
+
+	km, _ = newKeyMap(PresetArrows, nil)
+	for _, b := range []key.Binding{km.Left, km.Right, km.PanelUp, km.PanelDown, km.JobUp, km.JobDown} {
+		for _, k := range b.Keys() {
+			if len([]rune(k)) == 1 {
+				t.Errorf("arrows: %q is a letter key", k)
+			}
+		}
+	}
+
+	if _, err := newKeyMap("emacs", nil); err == nil || !strings.Contains(err.Error(), `unknown preset "emacs"`) {
+		t.Errorf("newKeyMap() error = %v, want unknown preset", err)
+	}
+}
+
+func TestNewKeyMap_Conflicts(t *testing.T) {
+	// Actions of different panes may share a key
+	km, err := newKeyMap("", map[string]config.Keys{"trigger": {"x"}, "rerun": {"x"}})
+	if err != nil {
+		t.Errorf("newKeyMap() error = %v", err)
+	}
+	if keys := km.Rerun.Keys(); len(keys) != 1 || keys[0] != "x" {
+		t.Errorf("Rerun keys = %v, want [x]", keys)
+	}
+
+	// A key of a pane cannot be a key of every pane, nor bound twice in a pane
+	km, err = newKeyMap("", map[string]config.Keys{"rerun": {"q"}, "cancel": {"R"}, "yank": {"Y"}})
+	for _, want := range []string{
+		`keybindings.rerun: "q" is already bound to quit`,
+		`keybindings.cancel: "R" is already bound to rerun_failed`,
+	} {
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("newKeyMap() error = %v, want %q", err, want)
+		}
+	}
+	if km.Rerun.Keys()[0] != "r" || km.Cancel.Keys()[0] != "c" {
+		t.Error("conflicting keys should be ignored")
+	}
+	if km.Yank.Keys()[0] != "Y" {
+		t.Error("keys without conflicts should be kept")
+	}
+
+	// Restoring the keys of an action may conflict with the keys of another one
+	km, err = newKeyMap("", map[string]config.Keys{"help": {"q"}, "quit": {"c"}})
+	for _, want := range []string{
+		`keybindings.quit: "c" is already bound to cancel`,
+		`keybindings.help: "q" is already bound to quit`,
+	} {
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("newKeyMap() error = %v, want %q", err, want)
+		}
+	}
+	if km.Quit.Keys()[0] != "q" || km.Help.Keys()[0] != "?" {
+		t.Errorf("quit = %v, help = %v, want their default keys", km.Quit.Keys(), km.Help.Keys())
+	}
+
+	// Swapping keys is not a conflict
+	km, err = newKeyMap("", map[string]config.Keys{"rerun": {"R"}, "rerun_failed": {"r"}})
+	if err != nil || km.Rerun.Keys()[0] != "R" {
+		t.Errorf("newKeyMap() = %v, %v, want keys swapped", km.Rerun.Keys(), err)
+	}
+}
+
+func TestNewKeyMap_ChordConflicts(t *testing.T) {
+	// The first key of a chord cannot trigger an action where the chord applies
+	km, err := newKeyMap("", map[string]config.Keys{"zoom": {"z"}})
+	if err == nil || !strings.Contains(err.Error(), `keybindings.zoom: "z" is already bound to fold`) {
+		t.Errorf("newKeyMap() error = %v, want a conflict with the fold chords", err)
+	}
+	if km.Zoom.Keys()[0] != "Z" {
+		t.Error("the conflicting key should be ignored")
+	}
+
+	// Chords may share their first key, and actions of other panes may use it
+	if _, err := newKeyMap("", map[string]config.Keys{"fold": {"gf"}, "unfold_all": {"gR"}, "trigger": {"g"}}); err != nil {
+		t.Errorf("newKeyMap() error = %v, want none", err)
+	}
+}
+
+func TestApp_HandleKeyPress_SharedPaneKeys(t *testing.T) {
+	app := New(
+		WithClient(newMockClient(nil)),
+		WithConfig(&config.Config{Keybindings: map[string]config.Keys{"trigger": {"x"}, "rerun": {"x"}}}),
+	)
+	app.runs.SetItems([]github.Run{{ID: 1}})
+	app.focusedPane = RunsPane
+	if cmd := app.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'x'}}); cmd == nil {
+		t.Error("x should rerun in the Runs pane")
+	}
+}
@@ -1,10 +1,199 @@
 package app
 
+import (
+	"math"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/nnnkkk7/lazyactions/config"
+	"github.com/nnnkkk7/lazyactions/state"
+)
+
 // Layout calculation functions for the TUI application.
 // These functions calculate dimensions and positions for panels.
 
+// Layout modes, cycled by the layout key
+const (
+	// LayoutAuto is the sidebar layout, stacked on terminals narrower than StackedLayoutWidth
+	LayoutAuto = "auto"
+	// LayoutSidebar shows the panes in a sidebar left of the detail panel
+	LayoutSidebar = "sidebar"
+	// LayoutStacked shows the panes above the detail panel, at full width
+	LayoutStacked = "stacked"
+)
+
+// layoutModes are the layout modes in the order the layout key cycles them
+var layoutModes = []string{LayoutAuto, LayoutSidebar, LayoutStacked}
+
+// paneNames name the panes in the persisted layout
+var paneNames = map[Pane]string{
+	ReposPane:     "repositories",
+	WorkflowsPane: "workflows",
+	RunsPane:      "runs",
+	JobsPane:      "jobs",
+}
+
+// paneTabs name the panes in the tabs of the compact layout, in full and
+// abbreviated
+var paneTabs = map[Pane][2]string{
+	ReposPane:     {"Repos", "Rep"},
+	WorkflowsPane: {"Workflows", "Wf"},
+	RunsPane:      {"Runs", "Run"},
+	JobsPane:      {"Jobs", "Job"},
+}
+
+// paneLayout is the arrangement of the panes chosen by the user
+type paneLayout struct {
+	mode         string
+	sidebarRatio float64 // share of the width taken by the sidebar
+	stackedRatio float64 // share of the height taken by the panes when stacked
+	weights      map[Pane]float64
+	zoomed       bool // whether the focused pane fills the screen
+
+	// The compact layout shows the focused pane alone, with tabs to switch
+	// panes. It follows the terminal size unless set by the compact key.
+	compact       bool
+	compactForced bool
+	detailShown   bool // whether the detail panel replaces the list when there is no room for both
+}
+
+// rect is the area of a panel on screen
+type rect struct {
+	x, y, width, height int
+}
+
+// contains reports whether the cell at x, y is in r
+func (r rect) contains(x, y int) bool {
+	return x >= r.x && x < r.x+r.width && y >= r.y && y < r.y+r.height
+}
+
+// restoreLayout applies the persisted layout over the settings
+func (a *App) restoreLayout() {
+	l := a.state.Layout()
+	a.layout = paneLayout{
+		mode:         LayoutAuto,
+		sidebarRatio: a.settings.leftPanelWidthRatio,
+		stackedRatio: StackedPanesRatio,
+		weights:      map[Pane]float64{},
+	}
+	if slices.Contains(layoutModes, l.Mode) {
+		a.layout.mode = l.Mode
+	}
+	if l.SidebarWidth >= config.MinLeftWidth && l.SidebarWidth <= config.MaxLeftWidth {
+		a.layout.sidebarRatio = l.SidebarWidth
+	}
+	if l.StackedHeight >= config.MinLeftWidth && l.StackedHeight <= config.MaxLeftWidth {
+		a.layout.stackedRatio = l.StackedHeight
+	}
+	for pane, name := range paneNames {
+		if w := l.PaneHeights[name]; w > 0 {
+			a.layout.weights[pane] = w
+		}
+	}
+}
+
+// saveLayout records the layout in the persisted state
+func (a *App) saveLayout() {
+	heights := make(map[string]float64, len(a.layout.weights))
+	for pane, w := range a.layout.weights {
+		heights[paneNames[pane]] = w
+	}
+	a.state.SetLayout(state.Layout{
+		Mode:          a.layout.mode,
+		SidebarWidth:  a.layout.sidebarRatio,
+		StackedHeight: a.layout.stackedRatio,
+		PaneHeights:   heights,
+	})
+}
+
+// stacked reports whether the panes are shown above the detail panel
+func (a *App) stacked() bool {
+	switch a.layout.mode {
+	case LayoutStacked:
+		return true
+	case LayoutSidebar:
+		return false
+	}
+	if a.layout.compact {
+		return true
+	}
+	// Stack the panes on narrow terminals tall enough for them
+	totalHeight, _ := a.panelLayout()
+	height := int(float64(totalHeight) * a.layout.stackedRatio)
+	return a.width < StackedLayoutWidth && height >= len(a.leftPanes())*MinPanelHeight
+}
+
+// compactSize reports whether a terminal of width and height is too small
+// for the panes side by side or stacked
+func compactSize(width, height int) bool {
+	return width < CompactLayoutWidth || height < CompactLayoutHeight
+}
+
+// autoCompact picks the compact layout after the terminal size, unless the
+// compact key set it
+func (a *App) autoCompact() {
+	if !a.layout.compactForced {
+		a.layout.compact = compactSize(a.width, a.height)
+	}
+}
+
+// toggleCompact switches the compact layout on or off. Once back to the
+// layout the terminal size picks, the layout follows the size again.
+func (a *App) toggleCompact() tea.Cmd {
+	a.layout.compact = !a.layout.compact
+	a.layout.compactForced = a.layout.compact != compactSize(a.width, a.height)
+	a.layout.detailShown = false
+	if a.layout.compact {
+		return flashMessage("Compact layout: on", FlashDurationInfo)
+	}
+	return flashMessage("Compact layout: off", FlashDurationInfo)
+}
+
+// detailOnDemand reports whether the compact layout is too short for the
+// detail panel below the list, so that it replaces the list when shown
+func (a *App) detailOnDemand() bool {
+	totalHeight, _ := a.panelLayout()
+	return a.layout.compact && totalHeight < CompactDetailHeight
+}
+
+// showDetail shows the detail panel in place of the list when the compact
+// layout has no room for both
+func (a *App) showDetail() {
+	if a.detailOnDemand() {
+		a.layout.detailShown = true
+	}
+}
+
+// compactTabs renders the top border of the list of the compact layout: the
+// panes it switches between, the focused one highlighted. The names are
+// abbreviated when they do not fit.
+func (a *App) compactTabs(width int) string {
+	innerWidth := width - BorderWidth
+	var tabs string
+	for _, short := range []bool{false, true} {
+		var b strings.Builder
+		for _, pane := range a.leftPanes() {
+			name := paneTabs[pane][0]
+			if short {
+				name = paneTabs[pane][1]
+			}
+			if pane == a.focusedPane {
+				b.WriteString(theme.FocusedTitle.Render(" " + name + " "))
+			} else {
+				b.WriteString(" " + name + " ")
+			}
+		}
+		if tabs = b.String(); lipgloss.Width(tabs) <= innerWidth {
+			break
+		}
+	}
+	return buildBorderHeader(tabs, innerWidth, getPanelBorderStyle(true))
+}
+
 func (a *App) leftPanelWidth() int {
-	w := int(float64(a.width) * LeftPanelWidthRatio)
+	w := int(float64(a.width) * a.layout.sidebarRatio)
 	if w < MinLeftPanelWidth {
 		w = MinLeftPanelWidth
 	}
@@ -27,29 +216,181 @@ func (a *App) logPaneHeight() int {
 // panelLayout returns the total height and individual panel height for the left sidebar
 func (a *App) panelLayout() (totalHeight, panelHeight int) {
 	totalHeight = a.height - StatusBarHeight
-	if totalHeight < MinTotalHeight {
-		totalHeight = MinTotalHeight
+	minHeight := MinTotalHeight
+	if a.layout.compact {
+		// The compact layout fits any terminal with room for a line of the list
+		minHeight = BorderWidth + 1
+	}
+	if totalHeight < minHeight {
+		totalHeight = minHeight
 	}
-	panelHeight = totalHeight / NumLeftPanels
+	panelHeight = totalHeight / len(a.leftPanes())
 	if panelHeight < MinPanelHeight {
 		panelHeight = MinPanelHeight
 	}
 	return totalHeight, panelHeight
 }
 
-// panelStartY returns the starting Y position for a given pane
-func (a *App) panelStartY(pane Pane) int {
-	_, panelHeight := a.panelLayout()
-	switch pane {
-	case WorkflowsPane:
-		return 0
-	case RunsPane:
-		return panelHeight
-	case JobsPane:
-		return 2 * panelHeight
-	default:
-		return 0
+// leftPanes returns the panes of the left sidebar from top to bottom
+func (a *App) leftPanes() []Pane {
+	if a.dashboard != nil {
+		return []Pane{RunsPane, JobsPane}
+	}
+	if a.showReposPane() {
+		return []Pane{ReposPane, WorkflowsPane, RunsPane, JobsPane}
+	}
+	return []Pane{WorkflowsPane, RunsPane, JobsPane}
+}
+
+// paneRects returns the areas of the panes shown and of the detail panel,
+// whose width is 0 when the focused pane is zoomed or the compact layout
+// shows the list only
+func (a *App) paneRects() (panes map[Pane]rect, detail rect) {
+	totalHeight, _ := a.panelLayout()
+	panes = make(map[Pane]rect)
+	switch {
+	case a.layout.zoomed:
+		panes[a.focusedPane] = rect{0, 0, a.width, totalHeight}
+		return panes, rect{}
+	case a.detailOnDemand() && a.layout.detailShown:
+		return panes, rect{0, 0, a.width, totalHeight}
+	case a.detailOnDemand():
+		panes[a.focusedPane] = rect{0, 0, a.width, totalHeight}
+		return panes, rect{}
+	case a.layout.compact:
+		area, detail := a.panesArea()
+		panes[a.focusedPane] = area
+		return panes, detail
+	}
+
+	area, detail := a.panesArea()
+	y := area.y
+	for i, h := range a.paneHeights(area.height) {
+		pane := a.leftPanes()[i]
+		panes[pane] = rect{area.x, y, area.width, h}
+		y += h
 	}
+	return panes, detail
+}
+
+// panesArea returns the area shared by the left panes and the area of the
+// detail panel
+func (a *App) panesArea() (panes, detail rect) {
+	totalHeight, _ := a.panelLayout()
+	if a.stacked() {
+		height := int(float64(totalHeight) * a.layout.stackedRatio)
+		height = max(min(height, totalHeight-MinPanelHeight), MinPanelHeight)
+		return rect{0, 0, a.width, height}, rect{0, height, a.width, totalHeight - height}
+	}
+	width := a.leftPanelWidth()
+	return rect{0, 0, width, totalHeight}, rect{width, 0, a.width - width, totalHeight}
+}
+
+// paneHeights splits height between the left panes after their weights
+func (a *App) paneHeights(height int) []int {
+	panes := a.leftPanes()
+	weights := make([]float64, len(panes))
+	var sum float64
+	for i, p := range panes {
+		weights[i] = a.paneWeight(p)
+		sum += weights[i]
+	}
+
+	heights := make([]int, len(panes))
+	var cum float64
+	prev := 0
+	for i := range panes {
+		cum += weights[i]
+		// Round the boundaries rather than the heights so they add up
+		end := int(math.Floor(float64(height)*cum/sum + 1e-9))
+		if i == len(panes)-1 {
+			end = height
+		}
+		heights[i] = end - prev
+		prev = end
+	}
+	return heights
+}
+
+// paneWeight returns the relative height of a left pane
+func (a *App) paneWeight(p Pane) float64 {
+	if w, ok := a.layout.weights[p]; ok {
+		return w
+	}
+	return 1
+}
+
+// setPaneHeights records the heights of the left panes as their weights,
+// relative to the average height so that the weight of a pane is 1 by default
+func (a *App) setPaneHeights(heights []int) {
+	var total int
+	for _, h := range heights {
+		total += h
+	}
+	avg := float64(total) / float64(len(heights))
+	for i, p := range a.leftPanes() {
+		a.layout.weights[p] = float64(heights[i]) / avg
+	}
+	a.saveLayout()
+}
+
+// resizePane grows the focused pane by delta rows, taken from or given to
+// the pane below it, or above it for the last pane. The compact layout shows
+// a single pane.
+func (a *App) resizePane(delta int) {
+	panes := a.leftPanes()
+	i := slices.Index(panes, a.focusedPane)
+	if i < 0 || len(panes) < 2 || a.layout.compact {
+		return
+	}
+	other := i + 1
+	if other == len(panes) {
+		other = i - 1
+	}
+	area, _ := a.panesArea()
+	heights := a.paneHeights(area.height)
+	if delta > 0 {
+		delta = min(delta, max(heights[other]-MinPanelHeight, 0))
+	} else {
+		delta = max(delta, min(MinPanelHeight-heights[i], 0))
+	}
+	if delta == 0 {
+		return
+	}
+	heights[i] += delta
+	heights[other] -= delta
+	a.setPaneHeights(heights)
+}
+
+// resizeSidebar changes the share of the screen taken by the panes: their
+// width, or their height when stacked
+func (a *App) resizeSidebar(delta float64) {
+	ratio := &a.layout.sidebarRatio
+	if a.stacked() {
+		ratio = &a.layout.stackedRatio
+	}
+	*ratio = math.Round(max(min(*ratio+delta, config.MaxLeftWidth), config.MinLeftWidth)*100) / 100
+	a.saveLayout()
+}
+
+// toggleZoom maximizes the focused pane, or restores the layout
+func (a *App) toggleZoom() {
+	a.layout.zoomed = !a.layout.zoomed
+}
+
+// cycleLayout switches to the next layout mode
+func (a *App) cycleLayout() tea.Cmd {
+	i := slices.Index(layoutModes, a.layout.mode)
+	a.layout.mode = layoutModes[(i+1)%len(layoutModes)]
+	a.saveLayout()
+	return flashMessage("Layout: "+a.layout.mode, FlashDurationInfo)
+}
+
+// itemHovered reports whether the mouse is over the i-th visible item of pane
+func (a *App) itemHovered(pane Pane, i int) bool {
+	panes, _ := a.paneRects()
+	r, ok := panes[pane]
+	return ok && r.contains(a.mouseX, a.mouseY) && a.mouseY == r.y+i+BorderOffset
 }
 
 func (a *App) workflowsPaneWidth() int {
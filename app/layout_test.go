@@ -1,7 +1,11 @@
 package app
 
 import (
+	"strings"
 	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nnnkkk7/lazyactions/github"
 )
 
 func TestApp_PaneWidths(t *testing.T) {
@@ -50,3 +54,206 @@ func TestApp_PanelLayout(t *testing.T) {
 		t.Errorf("logPaneHeight = %d, want 48", logHeight)
 	}
 }
+
+func pressKey(app *App, k string) tea.Cmd {
+	return app.handleKeyPress(keyMsg(k))
+}
+
+func TestApp_PaneRects(t *testing.T) {
+	app := New()
+	app.width, app.height = 120, 40
+
+	panes, detail := app.paneRects()
+	if r := panes[WorkflowsPane]; r != (rect{0, 0, 36, 13}) {
+		t.Errorf("Workflows = %+v, want the top of the sidebar", r)
+	}
+	if r := panes[JobsPane]; r != (rect{0, 26, 36, 13}) {
+		t.Errorf("Jobs = %+v, want the bottom of the sidebar", r)
+	}
+	if detail != (rect{36, 0, 84, 39}) {
+		t.Errorf("detail = %+v, want right of the sidebar", detail)
+	}
+
+	// Narrow terminals stack the panes above the detail panel
+	app.width = 80
+	panes, detail = app.paneRects()
+	if r := panes[WorkflowsPane]; r.width != 80 || r.y != 0 {
+		t.Errorf("Workflows = %+v, want at full width", r)
+	}
+	if detail != (rect{0, 19, 80, 20}) {
+		t.Errorf("detail = %+v, want below the panes", detail)
+	}
+	if lines := strings.Count(app.View(), "\n"); lines != 39 {
+		t.Errorf("View() has %d lines, want 39 and the status bar", lines)
+	}
+}
+
+func TestApp_ResizeLayout(t *testing.T) {
+	app := New()
+	app.width, app.height = 100, 40
+	app.focusedPane = RunsPane
+
+	pressKey(app, ">")
+	if w := app.leftPanelWidth(); w != 35 {
+		t.Errorf("leftPanelWidth() = %d, want 35", w)
+	}
+	pressKey(app, "+")
+	pressKey(app, "+")
+	panes, _ := app.paneRects()
+	if h := panes[RunsPane].height; h != 15 {
+		t.Errorf("Runs height = %d, want 15", h)
+	}
+	if h := panes[JobsPane].height; h != 11 {
+		t.Errorf("Jobs height = %d, want 11, the rows taken by Runs", h)
+	}
+
+	// Panes keep a minimum height
+	for range 20 {
+		pressKey(app, "+")
+	}
+	if panes, _ := app.paneRects(); panes[JobsPane].height != MinPanelHeight {
+		t.Errorf("Jobs height = %d, want %d", panes[JobsPane].height, MinPanelHeight)
+	}
+
+	// The layout persists across sessions
+	restored := New(WithState(app.state))
+	restored.width, restored.height = 100, 40
+	if w := restored.leftPanelWidth(); w != 35 {
+		t.Errorf("restored leftPanelWidth() = %d, want 35", w)
+	}
+	if panes, _ := restored.paneRects(); panes[JobsPane].height != MinPanelHeight {
+		t.Errorf("restored Jobs height = %d, want %d", panes[JobsPane].height, MinPanelHeight)
+	}
+}
+
+func TestApp_ZoomAndLayoutModes(t *testing.T) {
+	app := New()
+	app.width, app.height = 120, 40
+	app.focusedPane = RunsPane
+
+	pressKey(app, "Z")
+	panes, detail := app.paneRects()
+	if len(panes) != 1 || panes[RunsPane] != (rect{0, 0, 120, 39}) || detail.width != 0 {
+		t.Errorf("zoomed = %+v, %+v, want the Runs pane only", panes, detail)
+	}
+	pressKey(app, "Z")
+	if panes, _ := app.paneRects(); len(panes) != 3 {
+		t.Errorf("zoom should toggle back, got %+v", panes)
+	}
+
+	pressKey(app, "V")
+	pressKey(app, "V")
+	if app.layout.mode != LayoutStacked || !app.stacked() {
+		t.Errorf("layout = %q, want %q", app.layout.mode, LayoutStacked)
+	}
+	if l := app.state.Layout(); l.Mode != LayoutStacked {
+		t.Errorf("persisted layout = %q, want %q", l.Mode, LayoutStacked)
+	}
+	pressKey(app, "V")
+	if app.layout.mode != LayoutAuto {
+		t.Errorf("layout = %q, want %q", app.layout.mode, LayoutAuto)
+	}
+}
+
+func TestApp_DragBorders(t *testing.T) {
+	app := New()
+	app.width, app.height = 100, 40
+	app.focusedPane = RunsPane
+	drag := func(fromX, fromY, toX, toY int) {
+		app.handleMouseEvent(tea.MouseMsg{X: fromX, Y: fromY, Button: tea.MouseButtonLeft, Action: tea.MouseActionPress})
+		app.handleMouseEvent(tea.MouseMsg{X: toX, Y: toY, Button: tea.MouseButtonLeft, Action: tea.MouseActionMotion})
+		app.handleMouseEvent(tea.MouseMsg{X: toX, Y: toY, Button: tea.MouseButtonLeft, Action: tea.MouseActionRelease})
+	}
+
+	drag(29, 20, 49, 20)
+	if w := app.leftPanelWidth(); w != 50 {
+		t.Errorf("leftPanelWidth() = %d, want 50", w)
+	}
+	if app.focusedPane != RunsPane {
+		t.Error("dragging a border should not click")
+	}
+
+	// The bottom border of Workflows
+	drag(10, 12, 10, 17)
+	panes, _ := app.paneRects()
+	if h := panes[WorkflowsPane].height; h != 18 {
+		t.Errorf("Workflows height = %d, want 18", h)
+	}
+	if h := panes[RunsPane].height; h != 8 {
+		t.Errorf("Runs height = %d, want 8", h)
+	}
+}
+
+func TestApp_CompactLayout(t *testing.T) {
+	app := New()
+	app.Update(tea.WindowSizeMsg{Width: 80, Height: 20})
+	if !app.layout.compact {
+		t.Fatal("an 80x20 terminal should use the compact layout")
+	}
+
+	// One list with tabs, the detail below, the status bar on screen
+	panes, detail := app.paneRects()
+	if len(panes) != 1 || panes[WorkflowsPane] != (rect{0, 0, 80, 9}) || detail != (rect{0, 9, 80, 10}) {
+		t.Errorf("paneRects() = %+v, %+v, want the Workflows list above the detail", panes, detail)
+	}
+	view := app.View()
+	if lines := strings.Split(view, "\n"); len(lines) != 20 {
+		t.Errorf("View() has %d lines, want 20", len(lines))
+	}
+	if header := strings.Split(view, "\n")[0]; !strings.Contains(header, "Workflows") || !strings.Contains(header, "Runs") || !strings.Contains(header, "Jobs") {
+		t.Errorf("the list should have tabs for each pane: %q", header)
+	}
+	pressKey(app, "l")
+	if panes, _ := app.paneRects(); panes[RunsPane].height == 0 {
+		t.Errorf("the next pane should replace the list, got %+v", panes)
+	}
+
+	// Too short for both: the detail is shown on demand
+	app.Update(tea.WindowSizeMsg{Width: 50, Height: 12})
+	if panes, detail := app.paneRects(); len(panes) != 1 || detail.width != 0 {
+		t.Errorf("paneRects() = %+v, %+v, want the list only", panes, detail)
+	}
+	pressKey(app, "2")
+	if panes, detail := app.paneRects(); len(panes) != 0 || detail != (rect{0, 0, 50, 11}) {
+		t.Errorf("paneRects() = %+v, %+v, want the detail only", panes, detail)
+	}
+	pressKey(app, "esc")
+	if _, detail := app.paneRects(); detail.width != 0 {
+		t.Error("esc should go back to the list")
+	}
+	if lines := strings.Split(app.View(), "\n"); len(lines) != 12 {
+		t.Errorf("View() has %d lines, want 12", len(lines))
+	}
+}
+
+func TestApp_CompactLayout_Override(t *testing.T) {
+	app := New()
+	app.Update(tea.WindowSizeMsg{Width: 80, Height: 20})
+
+	pressKey(app, "C")
+	if app.layout.compact {
+		t.Fatal("the compact key should turn the compact layout off")
+	}
+	app.Update(tea.WindowSizeMsg{Width: 70, Height: 18})
+	if app.layout.compact {
+		t.Error("the layout chosen by the key should survive a resize")
+	}
+
+	pressKey(app, "C")
+	app.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	if app.layout.compact {
+		t.Error("back to the automatic layout, a large terminal should not be compact")
+	}
+}
+
+func TestApp_NarrowRunsPanel(t *testing.T) {
+	app := New()
+	app.runs.SetItems([]github.Run{{ID: 1, RunNumber: 7, Event: "push", Branch: "main"}})
+
+	if wide := strings.Join(app.buildRunsPanel(40, 5), "\n"); !strings.Contains(wide, "#7 push main") {
+		t.Errorf("wide panel should show the event:\n%s", wide)
+	}
+	if narrow := strings.Join(app.buildRunsPanel(24, 5), "\n"); !strings.Contains(narrow, "#7 main") {
+		t.Errorf("narrow panel should leave out the event:\n%s", narrow)
+	}
+}
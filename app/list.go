@@ -1,6 +1,7 @@
 package app
 
 import (
+	"slices"
 	"sync"
 )
 
@@ -15,7 +16,8 @@ type FilteredList[T any] struct {
 	selectedIdx   int
 	scrollOffset  int
 	visibleHeight int
-	matchFn       func(item T, filter string) bool
+	total         int // items available at the source, if more than were loaded
+	scoreFn       func(item T, filter string) (int, bool)
 }
 
 // NewFilteredList creates a new FilteredList with the provided match function.
@@ -25,10 +27,23 @@ func NewFilteredList[T any](matchFn func(T, string) bool) *FilteredList[T] {
 	if matchFn == nil {
 		panic("matchFn cannot be nil")
 	}
+	return NewRankedList(func(item T, filter string) (int, bool) {
+		return 0, matchFn(item, filter)
+	})
+}
+
+// NewRankedList creates a new FilteredList with the provided score function.
+// The scoreFn determines if an item matches the current filter and how well;
+// filtered items are ordered by decreasing score, then by their order.
+// Panics if scoreFn is nil.
+func NewRankedList[T any](scoreFn func(T, string) (int, bool)) *FilteredList[T] {
+	if scoreFn == nil {
+		panic("scoreFn cannot be nil")
+	}
 	return &FilteredList[T]{
 		allItems:    make([]T, 0),
 		filtered:    make([]T, 0),
-		matchFn:     matchFn,
+		scoreFn:     scoreFn,
 		selectedIdx: 0,
 	}
 }
@@ -47,6 +62,24 @@ func (l *FilteredList[T]) SetItems(items []T) {
 	l.applyFilter()
 }
 
+// SetTotal records how many items are available at the source
+// when only part of them were loaded.
+func (l *FilteredList[T]) SetTotal(total int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.total = total
+}
+
+// Truncated returns the number of loaded items and of items available at the
+// source, and whether items are missing from the list.
+func (l *FilteredList[T]) Truncated() (loaded, total int, ok bool) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	return len(l.allItems), l.total, l.total > len(l.allItems)
+}
+
 // SetFilter sets the filter string and refilters the items.
 // An empty filter shows all items.
 func (l *FilteredList[T]) SetFilter(filter string) {
@@ -64,13 +97,22 @@ func (l *FilteredList[T]) applyFilter() {
 		// No filter, show all items
 		l.filtered = l.allItems
 	} else {
-		// Apply filter
-		l.filtered = make([]T, 0)
+		// Apply filter, best matches first
+		type scored struct {
+			item  T
+			score int
+		}
+		var matches []scored
 		for _, item := range l.allItems {
-			if l.matchFn(item, l.filter) {
-				l.filtered = append(l.filtered, item)
+			if score, ok := l.scoreFn(item, l.filter); ok {
+				matches = append(matches, scored{item, score})
 			}
 		}
+		slices.SortStableFunc(matches, func(a, b scored) int { return b.score - a.score })
+		l.filtered = make([]T, 0, len(matches))
+		for _, m := range matches {
+			l.filtered = append(l.filtered, m.item)
+		}
 	}
 
 	// Clamp selectedIdx to valid range
@@ -170,6 +212,22 @@ func (l *FilteredList[T]) Items() []T {
 	return l.filtered
 }
 
+// Filter returns the current filter.
+func (l *FilteredList[T]) Filter() string {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	return l.filter
+}
+
+// AllItems returns all items, ignoring the filter.
+func (l *FilteredList[T]) AllItems() []T {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	return l.allItems
+}
+
 // Selected returns the currently selected item and true, or zero value and false
 // if the list is empty.
 func (l *FilteredList[T]) Selected() (T, bool) {
@@ -241,6 +299,22 @@ func (l *FilteredList[T]) Select(idx int) {
 	l.clampScrollOffset()
 }
 
+// SelectMatching selects the first filtered item for which match returns true.
+// Returns false and leaves the selection unchanged if no item matches.
+func (l *FilteredList[T]) SelectMatching(match func(T) bool) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for i, item := range l.filtered {
+		if match(item) {
+			l.selectedIdx = i
+			l.clampScrollOffset()
+			return true
+		}
+	}
+	return false
+}
+
 // Reset clears the filter and resets the selection to the first item.
 func (l *FilteredList[T]) Reset() {
 	l.mu.Lock()
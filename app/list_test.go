@@ -1,6 +1,7 @@
 package app
 
 import (
+	"slices"
 	"strings"
 	"sync"
 	"testing"
@@ -188,6 +189,31 @@ func TestSetFilter_FiltersItems(t *testing.T) {
 	}
 }
 
+func TestSetFilter_RanksByScore(t *testing.T) {
+	list := NewRankedList(func(item testItem, filter string) (int, bool) {
+		score, _, ok := fuzzyMatch(filter, item.Name)
+		return score, ok
+	})
+
+	list.SetItems([]testItem{
+		{Name: "build", ID: 1},
+		{Name: "redeploy", ID: 2},
+		{Name: "Deploy to production", ID: 3},
+		{Name: "deploy-docs", ID: 4},
+	})
+
+	list.SetFilter("dply")
+
+	var ids []int
+	for _, item := range list.Items() {
+		ids = append(ids, item.ID)
+	}
+	// Equal scores keep their order
+	if want := []int{3, 4, 2}; !slices.Equal(ids, want) {
+		t.Errorf("Items() IDs = %v, want %v", ids, want)
+	}
+}
+
 func TestSetFilter_EmptyFilterShowsAllItems(t *testing.T) {
 	list := NewFilteredList(testMatchFn)
 
@@ -1284,6 +1310,38 @@ func TestSelect_AdjustsScrollOffsetForArbitraryIndex(t *testing.T) {
 	}
 }
 
+// =============================================================================
+// SelectMatching Tests
+// =============================================================================
+
+func TestSelectMatching(t *testing.T) {
+	list := NewFilteredList(testMatchFn)
+	list.SetItems([]testItem{
+		{Name: "A", ID: 1},
+		{Name: "B", ID: 2},
+		{Name: "C", ID: 3},
+		{Name: "D", ID: 4},
+	})
+	list.SetVisibleHeight(2)
+
+	if !list.SelectMatching(func(item testItem) bool { return item.ID == 3 }) {
+		t.Fatal("SelectMatching() = false, want true")
+	}
+	if list.SelectedIndex() != 2 {
+		t.Errorf("SelectedIndex() = %d, want 2", list.SelectedIndex())
+	}
+	if list.ScrollOffset() != 1 {
+		t.Errorf("ScrollOffset() = %d, want 1", list.ScrollOffset())
+	}
+
+	if list.SelectMatching(func(item testItem) bool { return item.ID == 99 }) {
+		t.Error("SelectMatching() = true for missing item, want false")
+	}
+	if list.SelectedIndex() != 2 {
+		t.Errorf("SelectedIndex() = %d after failed match, want 2", list.SelectedIndex())
+	}
+}
+
 // =============================================================================
 // Reset Scroll Offset Tests
 // =============================================================================
@@ -0,0 +1,95 @@
+package app
+
+import (
+	"strconv"
+)
+
+// logGroupSummary renders what follows the ##[group] line of a nested group:
+// whether it is unfolded, or the number of lines it hides and of errors in
+// them
+func (a *App) logGroupSummary(g *LogGroup) string {
+	if a.logUnfolded[g.StartLine] {
+		return theme.Dimmed.Render("▾")
+	}
+	summary := theme.Dimmed.Render("▸ " + pluralize(a.parsedLogs.groupLineCount(g), "line"))
+	if g.Errors > 0 {
+		summary += "  " + theme.Failure.Render("✗ "+pluralize(g.Errors, "error"))
+	}
+	return summary
+}
+
+// logFoldTarget returns the group that a fold command folds or unfolds: the
+// innermost group around the line last gone to if it is in view, otherwise
+// the first group in view
+func (a *App) logFoldTarget() *LogGroup {
+	if a.parsedLogs == nil {
+		return nil
+	}
+	var target *LogGroup
+	if a.logLineShown(a.logCursor) {
+		for start, g := range a.parsedLogs.groups {
+			if a.logCursor >= start && a.logCursor <= g.EndLine && (target == nil || start > target.StartLine) {
+				target = g
+			}
+		}
+		if target != nil {
+			return target
+		}
+	}
+	for start, g := range a.parsedLogs.groups {
+		if a.logLineShown(start) && (target == nil || start < target.StartLine) {
+			target = g
+		}
+	}
+	return target
+}
+
+// toggleLogFold folds or unfolds the group of logFoldTarget, keeping its
+// ##[group] line in view
+func (a *App) toggleLogFold() {
+	g := a.logFoldTarget()
+	if g == nil {
+		return
+	}
+	if a.logUnfolded == nil {
+		a.logUnfolded = map[int]bool{}
+	}
+	a.logUnfolded[g.StartLine] = !a.logUnfolded[g.StartLine]
+	a.logCursor = g.StartLine
+	a.updateLogViewContent()
+	a.logView.ShowLine(a.logSearch.offsets[g.StartLine])
+}
+
+// setLogFolds unfolds every group, or folds them
+func (a *App) setLogFolds(unfolded bool) {
+	if a.parsedLogs == nil {
+		return
+	}
+	a.logUnfolded = map[int]bool{}
+	if unfolded {
+		for start := range a.parsedLogs.groups {
+			a.logUnfolded[start] = true
+		}
+	}
+	a.updateLogViewContent()
+}
+
+// revealLogLine unfolds the groups hiding line i of the logs
+func (a *App) revealLogLine(i int) {
+	for start, g := range a.parsedLogs.groups {
+		if i > start && i <= g.EndLine && !a.logUnfolded[start] {
+			if a.logUnfolded == nil {
+				a.logUnfolded = map[int]bool{}
+			}
+			a.logUnfolded[start] = true
+		}
+	}
+}
+
+// pluralize returns n followed by noun, in the plural unless n is 1
+func pluralize(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return strconv.Itoa(n) + " " + noun + "s"
+}
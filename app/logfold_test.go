@@ -0,0 +1,64 @@
+package app
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestApp_LogFolding(t *testing.T) {
+	app := New()
+	app.width, app.height = 120, 40
+	app.focusedPane = JobsPane
+	app.detailTab = LogsTab
+	app.stepListFocused = false
+	app.parsedLogs = ParseLogs(strings.Join([]string{
+		"##[group]Run go test",
+		"##[group]ok pkg/a",
+		"=== RUN TestA",
+		"##[endgroup]",
+		"##[group]FAIL pkg/b",
+		"##[error]TestB failed",
+		"hidden detail",
+		"##[endgroup]",
+		"##[endgroup]",
+	}, "\n"))
+	app.selectedStepIdx = 0
+	app.updateLogViewContent()
+
+	// Groups are folded, with a summary
+	view := app.View()
+	if strings.Contains(view, "hidden detail") || !strings.Contains(view, "FAIL pkg/b  ▸ 2 lines  ✗ 1 error") {
+		t.Fatalf("nested groups should be folded with a summary:\n%s", view)
+	}
+
+	// Enter unfolds the first group in view, za folds it back
+	pressKey(app, "enter")
+	if !strings.Contains(app.View(), "=== RUN TestA") {
+		t.Errorf("enter should unfold the first group:\n%s", app.View())
+	}
+	pressKey(app, "z")
+	pressKey(app, "a")
+	if strings.Contains(app.View(), "=== RUN TestA") || app.layout.zoomed {
+		t.Error("za should fold the group back, without zooming")
+	}
+
+	// zR and zM unfold and fold every group
+	pressKey(app, "z")
+	pressKey(app, "R")
+	if !strings.Contains(app.View(), "hidden detail") {
+		t.Error("zR should unfold every group")
+	}
+	pressKey(app, "z")
+	pressKey(app, "M")
+	if strings.Contains(app.View(), "hidden detail") {
+		t.Error("zM should fold every group")
+	}
+
+	// Going to a line unfolds the groups hiding it
+	pressKey(app, ":")
+	typeText(app, "7")
+	pressKey(app, "enter")
+	if !strings.Contains(app.View(), "hidden detail") {
+		t.Error("going to a folded line should unfold its group")
+	}
+}
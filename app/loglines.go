@@ -0,0 +1,231 @@
+package app
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nnnkkk7/lazyactions/github"
+)
+
+// logVisual is the range of log lines selected to copy, from the line where
+// the selection started to the one moved to. Both are lines of
+// ParsedLogs.AllLines.
+type logVisual struct {
+	anchor, cursor int
+}
+
+// bounds returns the first and last lines selected
+func (v *logVisual) bounds() (first, last int) {
+	return min(v.anchor, v.cursor), max(v.anchor, v.cursor)
+}
+
+// contains reports whether line i is selected
+func (v *logVisual) contains(i int) bool {
+	first, last := v.bounds()
+	return i >= first && i <= last
+}
+
+// logGutterWidth returns the width of the line numbers of the log view and
+// the space after them, 0 when they are hidden
+func (a *App) logGutterWidth() int {
+	if !a.logLineNumbers || a.parsedLogs == nil {
+		return 0
+	}
+	return len(strconv.Itoa(len(a.parsedLogs.AllLines))) + 1
+}
+
+// logLineView renders line i of the logs as the lines displayed: with syntax
+// highlighting and the matches of the search, or selected, after its number
+// if shown, and wrapped if lines wrap
+func (a *App) logLineView(i int) []string {
+	line := FormatLogLineWithColor(a.parsedLogs.AllLines[i])
+	if a.logVisual != nil && a.logVisual.contains(i) {
+		line = theme.LogSelection.Render(stripANSI(line))
+	} else {
+		line = a.highlightLogLine(i, line)
+	}
+	if g := a.parsedLogs.groupAt(i); g != nil {
+		line += "  " + a.logGroupSummary(g)
+	}
+
+	gutter := a.logGutterWidth()
+	lines := []string{line}
+	if a.logWrap {
+		lines = strings.Split(wrapLines(line, max(a.logView.Width()-gutter, 1)), "\n")
+	}
+	if gutter > 0 {
+		number := strconv.Itoa(i + 1)
+		lines[0] = theme.LogLineNumber.Render(strings.Repeat(" ", gutter-1-len(number))+number) + " " + lines[0]
+		for j := 1; j < len(lines); j++ {
+			lines[j] = strings.Repeat(" ", gutter) + lines[j]
+		}
+	}
+	return lines
+}
+
+// logViewHeader renders the line above the log view: the prompt of the line
+// to go to, the lines selected or the search, empty if none of them is shown
+func (a *App) logViewHeader() string {
+	switch {
+	case a.logGoto != nil:
+		return a.logGoto.View()
+	case a.logVisual != nil:
+		first, last := a.logVisual.bounds()
+		return theme.Running.Render("-- VISUAL --") + "  " + pluralize(last-first+1, "line") + "  " +
+			theme.Queued.Render(keyHint("copy", a.keys.Yank)+" "+keyHint("cancel", a.keys.Escape))
+	case a.logSearch.active():
+		return a.logSearch.view()
+	}
+	return ""
+}
+
+// toggleLogLineNumbers shows or hides the numbers of the log lines. The
+// choice is kept for the next sessions.
+func (a *App) toggleLogLineNumbers() {
+	a.logLineNumbers = !a.logLineNumbers
+	a.state.SetShowLogLineNumbers(a.logLineNumbers)
+	if a.parsedLogs != nil {
+		a.updateLogViewContent()
+	}
+}
+
+// startLogGoto shows the prompt of the line to go to
+func (a *App) startLogGoto() {
+	ti := textinput.New()
+	ti.Prompt = ":"
+	ti.Placeholder = "line number"
+	ti.Focus()
+	a.logGoto = &ti
+}
+
+// handleLogGotoInput handles input while the line to go to is typed
+func (a *App) handleLogGotoInput(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "esc":
+		a.logGoto = nil
+		return nil
+	case "enter":
+		value := strings.TrimSpace(a.logGoto.Value())
+		a.logGoto = nil
+		n, err := strconv.Atoi(value)
+		if err != nil || a.parsedLogs == nil || n < 1 || n > len(a.parsedLogs.AllLines) {
+			return flashMessage("No log line "+value, FlashDurationInfo)
+		}
+		a.gotoLogLine(n - 1)
+		return nil
+	}
+	var cmd tea.Cmd
+	*a.logGoto, cmd = a.logGoto.Update(msg)
+	return cmd
+}
+
+// gotoLogLine scrolls the log view to line i of the logs, selecting the step
+// of the line if it is not shown and unfolding the groups hiding it
+func (a *App) gotoLogLine(i int) {
+	if lo, hi := a.parsedLogs.stepRange(a.selectedStepIdx); i < lo || i >= hi {
+		a.selectedStepIdx = a.parsedLogs.stepOf(i)
+	}
+	a.revealLogLine(i)
+	a.updateLogViewContent()
+	a.logView.ScrollTo(a.logSearch.offsets[i])
+	a.logView.SetXOffset(0)
+	a.logCursor = i
+}
+
+// logLineDisplayed reports whether line i of the logs is displayed, not in a
+// folded group nor another step
+func (a *App) logLineDisplayed(i int) bool {
+	_, ok := a.logSearch.offsets[i]
+	return ok
+}
+
+// logLineShown reports whether line i of the logs is in the log view
+func (a *App) logLineShown(i int) bool {
+	offset, ok := a.logSearch.offsets[i]
+	top := a.logView.YOffset()
+	return ok && offset >= top && offset < top+a.logView.Height()
+}
+
+// startLogVisual selects the line last gone to, if in view, or the line at
+// the top of the log view
+func (a *App) startLogVisual() {
+	if a.parsedLogs == nil || len(a.parsedLogs.AllLines) == 0 {
+		return
+	}
+	line := a.logCursor
+	if !a.logLineShown(line) {
+		line = a.logViewTopLine()
+	}
+	lo, hi := a.parsedLogs.stepRange(a.selectedStepIdx)
+	line = min(max(line, lo), hi-1)
+	a.logVisual = &logVisual{anchor: line, cursor: line}
+	a.updateLogViewContent()
+}
+
+// handleLogVisualInput handles input while log lines are selected: moving
+// extends the selection, which is copied or cancelled
+func (a *App) handleLogVisualInput(msg tea.KeyMsg) tea.Cmd {
+	switch {
+	case key.Matches(msg, a.keys.Quit):
+		return tea.Quit
+	case key.Matches(msg, a.keys.Escape), key.Matches(msg, a.keys.Visual):
+		a.logVisual = nil
+		a.updateLogViewContent()
+	case key.Matches(msg, a.keys.Up):
+		a.moveLogVisual(-1)
+	case key.Matches(msg, a.keys.Down):
+		a.moveLogVisual(1)
+	case msg.String() == "pgup":
+		a.moveLogVisual(-a.logView.Height())
+	case msg.String() == "pgdown":
+		a.moveLogVisual(a.logView.Height())
+	case key.Matches(msg, a.keys.Yank):
+		return a.copyLogVisual()
+	}
+	return nil
+}
+
+// moveLogVisual moves the end of the selection by delta lines, within the
+// lines shown and over folded groups, and scrolls the log view to it
+func (a *App) moveLogVisual(delta int) {
+	lo, hi := a.parsedLogs.stepRange(a.selectedStepIdx)
+	v := a.logVisual
+	dir := 1
+	if delta < 0 {
+		dir, delta = -1, -delta
+	}
+	for range delta {
+		next := v.cursor + dir
+		for next >= lo && next < hi && !a.logLineDisplayed(next) {
+			next += dir
+		}
+		if next < lo || next >= hi {
+			break
+		}
+		v.cursor = next
+	}
+	a.logCursor = v.cursor
+	a.updateLogViewContent()
+	a.logView.ShowLine(a.logSearch.offsets[v.cursor])
+}
+
+// copyLogVisual copies the lines selected to the clipboard as in the raw
+// logs, without their timestamps and secrets, and ends the selection
+func (a *App) copyLogVisual() tea.Cmd {
+	first, last := a.logVisual.bounds()
+	a.logVisual = nil
+	a.updateLogViewContent()
+
+	lines := a.parsedLogs.AllLines[first : last+1]
+	raw := make([]string, len(lines))
+	for i, line := range lines {
+		raw[i] = timestampRegex.ReplaceAllString(line, "")
+	}
+	if err := a.clipboard.WriteAll(github.SanitizeLogs(strings.Join(raw, "\n"))); err != nil {
+		return flashMessage("Clipboard not available", FlashDurationInfo)
+	}
+	return flashMessage("Copied "+pluralize(len(lines), "log line"), FlashDurationSuccess)
+}
@@ -0,0 +1,95 @@
+package app
+
+import (
+	"strings"
+	"testing"
+)
+
+// fakeClipboard records the text written to the clipboard
+type fakeClipboard struct {
+	text string
+}
+
+func (c *fakeClipboard) WriteAll(text string) error {
+	c.text = text
+	return nil
+}
+
+func TestApp_LogLineNumbers(t *testing.T) {
+	app := newLogSearchApp()
+	app.selectedStepIdx = 1
+	app.updateLogViewContent()
+
+	pressKey(app, "#")
+	if !app.logLineNumbers || !app.state.ShowLogLineNumbers() {
+		t.Fatal("# should show the line numbers, in the state too")
+	}
+	// Lines are numbered as in the whole job log
+	if view := app.View(); !strings.Contains(view, "6 test FAIL: TestA") {
+		t.Errorf("the lines of the step should keep their number in the job log:\n%s", view)
+	}
+}
+
+func TestApp_LogGotoLine(t *testing.T) {
+	app := newLogSearchApp()
+	app.selectedStepIdx = 0
+	app.updateLogViewContent()
+
+	pressKey(app, ":")
+	typeText(app, "7")
+	if !strings.Contains(app.View(), ":7") {
+		t.Errorf("the prompt should show the line typed:\n%s", app.View())
+	}
+	pressKey(app, "enter")
+	if app.logGoto != nil || app.selectedStepIdx != 1 || app.logCursor != 6 {
+		t.Errorf("going to line 7 should select its step, step = %d, cursor = %d", app.selectedStepIdx, app.logCursor)
+	}
+
+	pressKey(app, ":")
+	typeText(app, "99")
+	pressKey(app, "enter")
+	if app.logCursor != 6 {
+		t.Error("a line past the end should not move")
+	}
+}
+
+func TestApp_LogVisualCopy(t *testing.T) {
+	app := New()
+	clipboard := &fakeClipboard{}
+	app.clipboard = clipboard
+	app.width, app.height = 120, 40
+	app.focusedPane = JobsPane
+	app.detailTab = LogsTab
+	app.stepListFocused = false
+	app.parsedLogs = ParseLogs(strings.Join([]string{
+		"2024-01-15T10:30:00.1234567Z ##[group]Deploy",
+		"2024-01-15T10:30:01.1234567Z login password=hunter2hunter2",
+		"2024-01-15T10:30:02.1234567Z deployed",
+		"2024-01-15T10:30:03.1234567Z ##[endgroup]",
+	}, "\n"))
+	app.selectedStepIdx = -1
+	app.updateLogViewContent()
+
+	pressKey(app, ":")
+	typeText(app, "2")
+	pressKey(app, "enter")
+	pressKey(app, "v")
+	pressKey(app, "down")
+	if !strings.Contains(app.View(), "-- VISUAL --  2 lines") {
+		t.Errorf("the selection should show its size:\n%s", app.View())
+	}
+
+	pressKey(app, "y")
+	if want := "login [REDACTED]\ndeployed"; clipboard.text != want {
+		t.Errorf("copied %q, want %q without timestamps nor secrets", clipboard.text, want)
+	}
+	if app.logVisual != nil {
+		t.Error("copying should end the selection")
+	}
+
+	pressKey(app, "v")
+	pressKey(app, "esc")
+	if app.logVisual != nil {
+		t.Error("esc should cancel the selection")
+	}
+}
@@ -2,15 +2,28 @@ package app
 
 import (
 	"regexp"
+	"slices"
 	"strings"
+
+	"github.com/nnnkkk7/lazyactions/github"
 )
 
 // StepLog represents a parsed step with its log lines
 type StepLog struct {
-	Name      string   // Step name extracted from ##[group]
-	Lines     []string // Log lines for this step
-	StartLine int      // Starting line number in the original logs
-	EndLine   int      // Ending line number in the original logs
+	Name      string      // Step name extracted from ##[group]
+	Lines     []string    // Log lines for this step
+	StartLine int         // Starting line number in the original logs
+	EndLine   int         // Ending line number in the original logs
+	Groups    []*LogGroup // Groups nested in the step
+}
+
+// LogGroup is a ##[group] nested in a step, which may nest groups in turn
+type LogGroup struct {
+	Name      string      // Group name extracted from ##[group]
+	StartLine int         // Line of the ##[group] in the original logs
+	EndLine   int         // Line of the ##[endgroup], or the last line if unclosed
+	Errors    int         // Number of ##[error] lines in the group
+	Groups    []*LogGroup // Groups nested in the group
 }
 
 // ParsedLogs represents the parsed structure of GitHub Actions logs
@@ -18,6 +31,8 @@ type ParsedLogs struct {
 	Steps    []StepLog // Parsed steps
 	RawLogs  string    // Original raw logs
 	AllLines []string  // All lines split from raw logs
+
+	groups map[int]*LogGroup // Groups nested in the steps by their StartLine
 }
 
 // groupStartRegex matches ##[group]<step name>
@@ -29,12 +44,44 @@ var groupEndRegex = regexp.MustCompile(`##\[endgroup\]`)
 // timestampRegex matches ISO 8601 timestamps at the start of log lines
 var timestampRegex = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2}T(\d{2}:\d{2}:\d{2})\.\d+Z)\s*`)
 
-// ParseLogs parses GitHub Actions log output and extracts steps
+// Step boundaries in the logs of a job as written by the runner: a step
+// starts with a ##[group] of the command or action it runs, whose output
+// follows the ##[endgroup], and post steps and the completion of the job
+// start with a line of their own
+var (
+	stepHeaderRegex  = regexp.MustCompile(`##\[group\]Run `)
+	actionStepRegex  = regexp.MustCompile(`^Run (\./\S*|docker://\S+|[\w.-]+/[\w./-]+@\S+)$`)
+	postStepRegex    = regexp.MustCompile(`^Post job cleanup\.$`)
+	completeJobRegex = regexp.MustCompile(`^Cleaning up orphan processes$`)
+)
+
+// Names of the steps that have no ##[group] header in the logs of a job
+const (
+	SetUpJobStep    = "Set up job"
+	PostJobStep     = "Post job cleanup"
+	CompleteJobStep = "Complete job"
+)
+
+// ParseLogs parses GitHub Actions log output and extracts steps.
+//
+// In the logs of a job as written by the runner, a step runs from its
+// header to the next one, and every other ##[group], such as the ones a step
+// logs with ::group::, is nested in its step. Other logs have a step for
+// each ##[group] that is not nested in another, and lines outside of them
+// belong to no step.
 func ParseLogs(rawLogs string) *ParsedLogs {
+	return ParseJobLogs(rawLogs, nil)
+}
+
+// ParseJobLogs parses the logs of a job like ParseLogs, telling the steps of
+// the job from those of the composite actions it runs, whose headers are
+// logged alike, by the steps of the job
+func ParseJobLogs(rawLogs string, steps []github.Step) *ParsedLogs {
 	parsed := &ParsedLogs{
 		RawLogs:  rawLogs,
 		Steps:    []StepLog{},
 		AllLines: []string{},
+		groups:   map[int]*LogGroup{},
 	}
 
 	if rawLogs == "" {
@@ -43,53 +90,215 @@ func ParseLogs(rawLogs string) *ParsedLogs {
 
 	lines := strings.Split(rawLogs, "\n")
 	parsed.AllLines = lines
+	byRunner := slices.ContainsFunc(lines, stepHeaderRegex.MatchString)
+	var stepStarts map[int]bool
+	if byRunner {
+		stepStarts = stepHeaders(lines, steps)
+	}
 
 	var currentStep *StepLog
-	var inGroup bool
+	var open []*LogGroup // groups open in the current step, innermost last
+	headerOpen := false  // whether the ##[group] starting the current step is open
+
+	endStep := func(end int) {
+		// Groups left open end with the step
+		for _, group := range open {
+			group.EndLine = end
+		}
+		open = nil
+		headerOpen = false
+		currentStep.EndLine = end
+		currentStep.Lines = lines[currentStep.StartLine : end+1 : end+1]
+		parsed.Steps = append(parsed.Steps, *currentStep)
+		currentStep = nil
+	}
+	startStep := func(name string, i int) {
+		if currentStep != nil && i > currentStep.StartLine {
+			endStep(i - 1)
+		}
+		currentStep = &StepLog{Name: name, StartLine: i}
+	}
+
+	// The job is set up before the header of its first step
+	if byRunner {
+		startStep(SetUpJobStep, 0)
+	}
 
 	for i, line := range lines {
-		// Check for group start
-		if match := groupStartRegex.FindStringSubmatch(line); match != nil {
-			// Close previous unclosed group if any
-			if currentStep != nil && inGroup {
-				currentStep.EndLine = i - 1
-				parsed.Steps = append(parsed.Steps, *currentStep)
+		match := groupStartRegex.FindStringSubmatch(line)
+		text := timestampRegex.ReplaceAllString(line, "")
+		between := byRunner && len(open) == 0 && !headerOpen // outside of any group
+
+		switch {
+		// Check for step start
+		case match != nil && stepStarts[i],
+			match != nil && !byRunner && currentStep == nil:
+			startStep(match[1], i)
+			headerOpen = true
+		case between && postStepRegex.MatchString(text):
+			startStep(PostJobStep, i)
+		case between && completeJobRegex.MatchString(text):
+			startStep(CompleteJobStep, i)
+
+		// A group in a step is nested in the innermost group open
+		case match != nil && currentStep != nil:
+			group := &LogGroup{Name: match[1], StartLine: i}
+			if len(open) > 0 {
+				parent := open[len(open)-1]
+				parent.Groups = append(parent.Groups, group)
+			} else {
+				currentStep.Groups = append(currentStep.Groups, group)
+			}
+			open = append(open, group)
+			parsed.groups[i] = group
+
+		// Check for group end, of the innermost group open or of the header
+		case groupEndRegex.MatchString(line) && currentStep != nil:
+			switch {
+			case len(open) > 0:
+				open[len(open)-1].EndLine = i
+				open = open[:len(open)-1]
+			case headerOpen:
+				headerOpen = false
+				if !byRunner {
+					endStep(i)
+				}
 			}
 
-			currentStep = &StepLog{
-				Name:      match[1],
-				Lines:     []string{line},
-				StartLine: i,
+		case errorMarkerRegex.MatchString(line):
+			for _, group := range open {
+				group.Errors++
 			}
-			inGroup = true
-			continue
 		}
+	}
 
-		// Check for group end
-		if groupEndRegex.MatchString(line) {
-			if currentStep != nil && inGroup {
-				currentStep.Lines = append(currentStep.Lines, line)
-				currentStep.EndLine = i
-				parsed.Steps = append(parsed.Steps, *currentStep)
-				currentStep = nil
-				inGroup = false
+	// Handle unclosed groups (still running)
+	if currentStep != nil {
+		endStep(len(lines) - 1)
+	}
+
+	return parsed
+}
+
+// stepHeaders returns the lines of the headers starting a step of the job.
+// When there are more headers than steps that ran, those of the steps of
+// composite actions are nested in the step running the action.
+func stepHeaders(lines []string, steps []github.Step) map[int]bool {
+	var headers []int
+	var names []string
+	depth := 0
+	for i, line := range lines {
+		switch {
+		case groupStartRegex.MatchString(line):
+			if depth == 0 && stepHeaderRegex.MatchString(line) {
+				headers = append(headers, i)
+				names = append(names, groupStartRegex.FindStringSubmatch(line)[1])
 			}
-			continue
+			depth++
+		case groupEndRegex.MatchString(line) && depth > 0:
+			depth--
 		}
+	}
 
-		// Add line to current step if in a group
-		if currentStep != nil && inGroup {
-			currentStep.Lines = append(currentStep.Lines, line)
+	starts := alignStepHeaders(names, headerSteps(steps))
+	stepStarts := make(map[int]bool, len(headers))
+	for h, i := range headers {
+		stepStarts[i] = starts == nil || starts[h]
+	}
+	return stepStarts
+}
+
+// headerSteps returns the names of the steps of a job that ran and have a
+// header in its logs: all but those setting up and completing the job and
+// the post steps
+func headerSteps(steps []github.Step) []string {
+	var names []string
+	for _, s := range steps {
+		if s.Conclusion != "skipped" {
+			names = append(names, s.Name)
 		}
 	}
+	if len(names) > 0 && names[0] == SetUpJobStep {
+		names = names[1:]
+	}
+	for len(names) > 0 && (names[len(names)-1] == CompleteJobStep || strings.HasPrefix(names[len(names)-1], "Post ")) {
+		names = names[:len(names)-1]
+	}
+	return names
+}
 
-	// Handle unclosed group (still running)
-	if currentStep != nil && inGroup {
-		currentStep.EndLine = len(lines) - 1
-		parsed.Steps = append(parsed.Steps, *currentStep)
+// alignStepHeaders returns which headers start the steps named, the others
+// being nested in the step of an action before them. Among the possible
+// choices, it picks the one whose headers are named like the most steps,
+// then the one with the fewest composite actions. It returns nil when each
+// header starts a step or no choice is possible.
+func alignStepHeaders(headers, steps []string) []bool {
+	n, m := len(headers), len(steps)
+	if m == 0 || n <= m {
+		return nil
 	}
 
-	return parsed
+	// score[i][j] is the best score of the headers up to i when header i
+	// starts step j, -1 if it cannot; prev[i][j] is the header starting
+	// step j-1 then
+	score := make([][]int, n)
+	prev := make([][]int, n)
+	for i := range score {
+		score[i] = slices.Repeat([]int{-1}, m)
+		prev[i] = make([]int, m)
+	}
+	match := func(i, j int) int {
+		if headers[i] == steps[j] {
+			return n + 1 // outweighs any number of composite actions
+		}
+		return 0
+	}
+	// nests reports the cost of nesting the headers after p up to i
+	// excluded in the step starting at p, -1 if they cannot be
+	nests := func(p, i int) int {
+		switch {
+		case p == i-1:
+			return 0
+		case actionStepRegex.MatchString(headers[p]):
+			return 1
+		}
+		return -1
+	}
+
+	score[0][0] = n + match(0, 0) // keeps scores positive
+	for j := 1; j < m; j++ {
+		for i := j; i < n; i++ {
+			for p := j - 1; p < i; p++ {
+				cost := nests(p, i)
+				if score[p][j-1] < 0 || cost < 0 {
+					continue
+				}
+				if s := score[p][j-1] - cost + match(i, j); s > score[i][j] {
+					score[i][j], prev[i][j] = s, p
+				}
+			}
+		}
+	}
+
+	best, last := -1, -1
+	for i := m - 1; i < n; i++ {
+		cost := nests(i, n)
+		if score[i][m-1] < 0 || cost < 0 {
+			continue
+		}
+		if s := score[i][m-1] - cost; s > best {
+			best, last = s, i
+		}
+	}
+	if last < 0 {
+		return nil
+	}
+
+	starts := make([]bool, n)
+	for i, j := last, m-1; j >= 0; i, j = prev[i][j], j-1 {
+		starts[i] = true
+	}
+	return starts
 }
 
 // GetStepLogs returns the log content for a specific step
@@ -112,6 +321,25 @@ func (p *ParsedLogs) GetStepLogs(stepIndex int) string {
 	return strings.Join(p.Steps[stepIndex].Lines, "\n")
 }
 
+// stepRange returns the range of AllLines a step shows, all of them for -1
+func (p *ParsedLogs) stepRange(stepIndex int) (lo, hi int) {
+	if stepIndex < 0 || stepIndex >= len(p.Steps) {
+		return 0, len(p.AllLines)
+	}
+	return p.Steps[stepIndex].StartLine, p.Steps[stepIndex].EndLine + 1
+}
+
+// stepOf returns the index of the step of line i of AllLines, or -1 if it is
+// outside of any step
+func (p *ParsedLogs) stepOf(i int) int {
+	for s, step := range p.Steps {
+		if i >= step.StartLine && i <= step.EndLine {
+			return s
+		}
+	}
+	return -1
+}
+
 // formatStepLogsWithFunc formats all lines in the logs using the provided formatter function
 func (p *ParsedLogs) formatStepLogsWithFunc(stepIndex int, formatter func(string) string) string {
 	logs := p.GetStepLogs(stepIndex)
@@ -157,33 +385,33 @@ func FormatLogLineWithColor(line string) string {
 	// Check for GitHub Actions markers (these color the entire line)
 	if errorMarkerRegex.MatchString(rest) {
 		if timestamp != "" {
-			return LogTimestampStyle.Render(timestamp) + " " + LogErrorStyle.Render(rest)
+			return theme.LogTimestamp.Render(timestamp) + " " + theme.LogError.Render(rest)
 		}
-		return LogErrorStyle.Render(rest)
+		return theme.LogError.Render(rest)
 	}
 	if warningMarkerRegex.MatchString(rest) {
 		if timestamp != "" {
-			return LogTimestampStyle.Render(timestamp) + " " + LogWarningStyle.Render(rest)
+			return theme.LogTimestamp.Render(timestamp) + " " + theme.LogWarning.Render(rest)
 		}
-		return LogWarningStyle.Render(rest)
+		return theme.LogWarning.Render(rest)
 	}
 	if noticeMarkerRegex.MatchString(rest) {
 		if timestamp != "" {
-			return LogTimestampStyle.Render(timestamp) + " " + LogNoticeStyle.Render(rest)
+			return theme.LogTimestamp.Render(timestamp) + " " + theme.LogNotice.Render(rest)
 		}
-		return LogNoticeStyle.Render(rest)
+		return theme.LogNotice.Render(rest)
 	}
 	if groupStartRegex.MatchString(rest) {
 		if timestamp != "" {
-			return LogTimestampStyle.Render(timestamp) + " " + LogGroupStyle.Render(rest)
+			return theme.LogTimestamp.Render(timestamp) + " " + theme.LogGroup.Render(rest)
 		}
-		return LogGroupStyle.Render(rest)
+		return theme.LogGroup.Render(rest)
 	}
 	if groupEndRegex.MatchString(rest) {
 		if timestamp != "" {
-			return LogTimestampStyle.Render(timestamp) + " " + LogEndGroupStyle.Render(rest)
+			return theme.LogTimestamp.Render(timestamp) + " " + theme.LogEndGroup.Render(rest)
 		}
-		return LogEndGroupStyle.Render(rest)
+		return theme.LogEndGroup.Render(rest)
 	}
 
 	// Apply keyword highlighting to the rest of the line
@@ -191,7 +419,7 @@ func FormatLogLineWithColor(line string) string {
 
 	// Combine timestamp and rest
 	if timestamp != "" {
-		return LogTimestampStyle.Render(timestamp) + " " + rest
+		return theme.LogTimestamp.Render(timestamp) + " " + rest
 	}
 	return rest
 }
@@ -200,17 +428,17 @@ func FormatLogLineWithColor(line string) string {
 func highlightKeywords(text string) string {
 	// Apply error keywords (red)
 	text = errorKeywordRegex.ReplaceAllStringFunc(text, func(match string) string {
-		return LogErrorKeyword.Render(match)
+		return theme.LogErrorKeyword.Render(match)
 	})
 
 	// Apply warning keywords (orange)
 	text = warnKeywordRegex.ReplaceAllStringFunc(text, func(match string) string {
-		return LogWarningKeyword.Render(match)
+		return theme.LogWarningKeyword.Render(match)
 	})
 
 	// Apply success keywords (green)
 	text = successKeywordRegex.ReplaceAllStringFunc(text, func(match string) string {
-		return LogSuccessKeyword.Render(match)
+		return theme.LogSuccessKeyword.Render(match)
 	})
 
 	return text
@@ -220,3 +448,17 @@ func highlightKeywords(text string) string {
 func (p *ParsedLogs) FormatStepLogsWithColor(stepIndex int) string {
 	return p.formatStepLogsWithFunc(stepIndex, FormatLogLineWithColor)
 }
+
+// groupAt returns the group nested in a step whose ##[group] is line i of
+// AllLines, or nil
+func (p *ParsedLogs) groupAt(i int) *LogGroup {
+	return p.groups[i]
+}
+
+// groupLineCount returns the number of lines between the markers of a group
+func (p *ParsedLogs) groupLineCount(g *LogGroup) int {
+	if groupEndRegex.MatchString(p.AllLines[g.EndLine]) {
+		return g.EndLine - g.StartLine - 1
+	}
+	return g.EndLine - g.StartLine
+}
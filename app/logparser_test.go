@@ -1,7 +1,11 @@
 package app
 
 import (
+	"os"
+	"slices"
 	"testing"
+
+	"github.com/nnnkkk7/lazyactions/github"
 )
 
 func TestParseLogs_SingleStep(t *testing.T) {
@@ -51,6 +55,157 @@ func TestParseLogs_MultipleSteps(t *testing.T) {
 	}
 }
 
+func TestParseLogs_NestedGroups(t *testing.T) {
+	rawLogs := `2024-01-15T10:00:00.000Z ##[group]Run npm ci
+2024-01-15T10:00:01.000Z ##[group]Install
+2024-01-15T10:00:02.000Z ##[group]Resolve
+2024-01-15T10:00:03.000Z ##[error]lockfile out of date
+2024-01-15T10:00:04.000Z ##[endgroup]
+2024-01-15T10:00:05.000Z added 12 packages
+2024-01-15T10:00:06.000Z ##[endgroup]
+2024-01-15T10:00:07.000Z ##[endgroup]
+2024-01-15T10:00:08.000Z ##[group]Run npm test
+2024-01-15T10:00:09.000Z ##[group]Unit
+2024-01-15T10:00:10.000Z still running`
+
+	parsed := ParseLogs(rawLogs)
+
+	if len(parsed.Steps) != 2 {
+		t.Fatalf("expected 2 steps with nested groups, got %d", len(parsed.Steps))
+	}
+	step := parsed.Steps[0]
+	if step.EndLine != 7 || len(step.Lines) != 8 || len(step.Groups) != 1 {
+		t.Fatalf("step 0: EndLine %d, %d lines, %d groups", step.EndLine, len(step.Lines), len(step.Groups))
+	}
+
+	install := step.Groups[0]
+	if install.Name != "Install" || install.StartLine != 1 || install.EndLine != 6 || install.Errors != 1 {
+		t.Errorf("unexpected group %+v", *install)
+	}
+	if len(install.Groups) != 1 || install.Groups[0].Name != "Resolve" || install.Groups[0].EndLine != 4 {
+		t.Errorf("Install should nest Resolve, got %d groups", len(install.Groups))
+	}
+	if n := parsed.groupLineCount(install); n != 4 {
+		t.Errorf("groupLineCount(Install) = %d, want 4", n)
+	}
+
+	// Unclosed groups end with the logs
+	unit := parsed.Steps[1].Groups[0]
+	if unit.EndLine != 10 || parsed.groupLineCount(unit) != 1 {
+		t.Errorf("unclosed group: EndLine %d, %d lines", unit.EndLine, parsed.groupLineCount(unit))
+	}
+}
+
+func TestParseJobLogs_RunnerLog(t *testing.T) {
+	rawLogs, err := os.ReadFile("testdata/job_log.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	jobSteps := []github.Step{
+		{Name: "Set up job", Conclusion: "success", Number: 1},
+		{Name: "Checkout", Conclusion: "success", Number: 2},
+		{Name: "Set up Go", Conclusion: "success", Number: 3},
+		{Name: "Install tools", Conclusion: "success", Number: 4},
+		{Name: "Lint", Conclusion: "skipped", Number: 5},
+		{Name: "Run go test ./...", Conclusion: "failure", Number: 6},
+		{Name: "Upload coverage", Conclusion: "skipped", Number: 7},
+		{Name: "Post Set up Go", Conclusion: "success", Number: 8},
+		{Name: "Post Checkout", Conclusion: "success", Number: 9},
+		{Name: "Complete job", Conclusion: "success", Number: 10},
+	}
+
+	parsed := ParseJobLogs(string(rawLogs), jobSteps)
+
+	// Headers close before the output of their step, whose groups are nested,
+	// as are the headers of the steps of a composite action
+	wantSteps := []struct {
+		name       string
+		startLine  int
+		groupCount int
+		jobStep    int
+	}{
+		{"Set up job", 0, 4, 1},
+		{"Run actions/checkout@v4", 26, 4, 2},
+		{"Run actions/setup-go@v5", 58, 1, 3},
+		{"Run ./.github/actions/tools", 76, 2, 4},
+		{"Run go test ./...", 89, 2, 6},
+		{"Post job cleanup", 107, 0, 8},
+		{"Post job cleanup", 109, 0, 9},
+		{"Complete job", 114, 0, 10},
+	}
+	if len(parsed.Steps) != len(wantSteps) {
+		t.Fatalf("expected %d steps, got %d", len(wantSteps), len(parsed.Steps))
+	}
+	for i, want := range wantSteps {
+		step := parsed.Steps[i]
+		if step.Name != want.name || step.StartLine != want.startLine || len(step.Groups) != want.groupCount {
+			t.Errorf("step %d: got %q at line %d with %d groups, want %q at line %d with %d groups",
+				i, step.Name, step.StartLine, len(step.Groups), want.name, want.startLine, want.groupCount)
+		}
+		if jobStep, _ := MatchJobStep(jobSteps, i, step.Name); jobStep.Number != want.jobStep {
+			t.Errorf("step %d shows job step %d, want %d", i, jobStep.Number, want.jobStep)
+		}
+	}
+
+	tools := parsed.Steps[3]
+	if tools.Groups[0].Name != "Run go install golang.org/x/tools/cmd/stringer@v0.23.0" || tools.Groups[1].Name != "Run go generate ./..." {
+		t.Errorf("the steps of the composite action should be nested, got %q and %q", tools.Groups[0].Name, tools.Groups[1].Name)
+	}
+	test := parsed.Steps[4]
+	if test.EndLine != 106 {
+		t.Errorf("the output of the test step should run to its ##[error], EndLine %d", test.EndLine)
+	}
+	if failed := test.Groups[1]; failed.Name != "FAIL github.com/example/app/server" || failed.Errors != 1 {
+		t.Errorf("unexpected group %+v", *failed)
+	}
+
+	// Without the steps of the job, each header starts a step
+	if n := len(ParseLogs(string(rawLogs)).Steps); n != len(wantSteps)+2 {
+		t.Errorf("expected %d steps without the steps of the job, got %d", len(wantSteps)+2, n)
+	}
+}
+
+func TestAlignStepHeaders(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers []string
+		steps   []string
+		want    []bool
+	}{
+		{
+			name:    "a header per step",
+			headers: []string{"Run a", "Run b"},
+			steps:   []string{"A", "B"},
+			want:    nil,
+		},
+		{
+			name:    "composite action of unnamed steps",
+			headers: []string{"Run ./setup", "Run npm ci", "Run npm test", "Run npm test"},
+			steps:   []string{"Run ./setup", "Run npm test"},
+			want:    []bool{true, false, false, true},
+		},
+		{
+			name:    "fewest composite actions",
+			headers: []string{"Run actions/checkout@v4", "Run ./setup", "Run npm ci", "Run npm test", "Run make"},
+			steps:   []string{"Checkout", "Setup", "Build"},
+			want:    []bool{true, true, false, false, true},
+		},
+		{
+			name:    "steps of a command cannot be nested",
+			headers: []string{"Run make", "Run npm ci", "Run npm test"},
+			steps:   []string{"Build", "Test"},
+			want:    nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := alignStepHeaders(tt.headers, tt.steps); !slices.Equal(got, tt.want) {
+				t.Errorf("alignStepHeaders() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestParseLogs_NoSteps(t *testing.T) {
 	rawLogs := `2024-01-15T10:00:00.000Z Some plain log output
 2024-01-15T10:00:01.000Z Another line without step markers`
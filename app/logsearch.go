@@ -0,0 +1,258 @@
+package app
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// logSearch is the search of the log view. Matches are searched in the text
+// of the lines as displayed, without their colors.
+type logSearch struct {
+	input    textinput.Model
+	typing   bool // whether the search input has focus
+	regex    bool // whether the query is a regular expression, otherwise literal
+	allSteps bool // whether every step is searched, otherwise the selected one
+
+	query   string // empty without a search
+	re      *regexp.Regexp
+	err     error // why the query does not compile
+	matches []logMatch
+	current int           // index of the current match
+	byLine  map[int][]int // indexes of the matches of each line
+	offsets map[int]int   // first display line of each log line shown
+}
+
+// logMatch is a match of the search: a line of ParsedLogs.AllLines and the
+// byte range of the match in its displayed text
+type logMatch struct {
+	line, start, end int
+}
+
+// active reports whether there is a search to show
+func (s *logSearch) active() bool {
+	return s.typing || s.query != ""
+}
+
+// compile sets the query searched. The search ignores case unless the query
+// has upper case letters.
+func (s *logSearch) compile(query string) {
+	s.query, s.re, s.err = query, nil, nil
+	if query == "" {
+		return
+	}
+	pattern := query
+	if !s.regex {
+		pattern = regexp.QuoteMeta(query)
+	}
+	if !strings.ContainsFunc(query, unicode.IsUpper) {
+		pattern = "(?i)" + pattern
+	}
+	s.re, s.err = regexp.Compile(pattern)
+}
+
+// view renders the search line of the log view: the query, or the input while
+// typed, the position of the current match and the search options
+func (s *logSearch) view() string {
+	var b strings.Builder
+	if s.typing {
+		b.WriteString(s.input.View())
+	} else {
+		b.WriteString("/" + s.query)
+	}
+	switch {
+	case s.err != nil:
+		b.WriteString("  " + theme.Failure.Render("invalid regex"))
+	case s.query == "":
+	case len(s.matches) == 0:
+		b.WriteString("  " + theme.Queued.Render("no matches"))
+	default:
+		b.WriteString("  " + ScrollPosition(s.current, len(s.matches)))
+	}
+
+	mode, scope := "literal", "step"
+	if s.regex {
+		mode = "regex"
+	}
+	if s.allSteps {
+		scope = "all steps"
+	}
+	options := "[" + mode + ", " + scope + "]"
+	if s.typing {
+		options += " ctrl+r regex, tab scope"
+	}
+	b.WriteString("  " + theme.Queued.Render(options))
+	return b.String()
+}
+
+// inLogView reports whether the log content has the focus, in the detail
+// panel or fullscreen
+func (a *App) inLogView() bool {
+	return a.fullscreenLog || (a.focusedPane == JobsPane && a.detailTab == LogsTab && !a.stepListFocused)
+}
+
+// startLogSearch focuses the search input of the log view, with the query
+// searched last
+func (a *App) startLogSearch() {
+	ti := textinput.New()
+	ti.Prompt = "/"
+	ti.Placeholder = "search logs"
+	ti.SetValue(a.logSearch.query)
+	ti.CursorEnd()
+	ti.Focus()
+	a.logSearch.input = ti
+	a.logSearch.typing = true
+}
+
+// handleLogSearchInput handles input while the log search is typed. The
+// search runs on every keystroke.
+func (a *App) handleLogSearchInput(msg tea.KeyMsg) tea.Cmd {
+	s := &a.logSearch
+	switch msg.String() {
+	case "esc":
+		a.clearLogSearch()
+		return nil
+	case "enter":
+		s.typing = false
+		if s.query == "" || s.err != nil {
+			a.clearLogSearch()
+		}
+		return nil
+	case "ctrl+r":
+		s.regex = !s.regex
+	case "tab":
+		s.allSteps = !s.allSteps
+	default:
+		var cmd tea.Cmd
+		s.input, cmd = s.input.Update(msg)
+		if s.input.Value() == s.query {
+			return cmd
+		}
+		a.runLogSearch()
+		return cmd
+	}
+	a.runLogSearch()
+	return nil
+}
+
+// runLogSearch searches the input and goes to the first match from the top
+// of the log view
+func (a *App) runLogSearch() {
+	a.logSearch.compile(a.logSearch.input.Value())
+	a.updateLogViewContent()
+
+	s := &a.logSearch
+	if len(s.matches) == 0 {
+		return
+	}
+	top := a.logViewTopLine()
+	s.current = 0
+	for i, m := range s.matches {
+		if m.line >= top {
+			s.current = i
+			break
+		}
+	}
+	a.gotoLogMatch(s.current)
+}
+
+// clearLogSearch ends the search and removes its highlights
+func (a *App) clearLogSearch() {
+	a.logSearch.typing = false
+	a.logSearch.compile("")
+	a.updateLogViewContent()
+}
+
+// findLogMatches finds the matches of the search in the lines searched: those
+// of every step, or of the selected one. The current match is kept if there
+// is still one at its index.
+func (a *App) findLogMatches() {
+	s := &a.logSearch
+	s.matches, s.byLine = nil, map[int][]int{}
+	if s.re == nil || a.parsedLogs == nil {
+		s.current = 0
+		return
+	}
+	lo, hi := 0, len(a.parsedLogs.AllLines)
+	if !s.allSteps {
+		lo, hi = a.parsedLogs.stepRange(a.selectedStepIdx)
+	}
+	for i := lo; i < hi; i++ {
+		text := stripANSI(FormatLogLineWithColor(a.parsedLogs.AllLines[i]))
+		for _, loc := range s.re.FindAllStringIndex(text, -1) {
+			if loc[0] == loc[1] {
+				continue
+			}
+			s.byLine[i] = append(s.byLine[i], len(s.matches))
+			s.matches = append(s.matches, logMatch{line: i, start: loc[0], end: loc[1]})
+		}
+	}
+	if s.current >= len(s.matches) {
+		s.current = 0
+	}
+}
+
+// highlightLogLine highlights the matches of line i of the logs, formatted
+// as displayed
+func (a *App) highlightLogLine(i int, formatted string) string {
+	s := &a.logSearch
+	indexes := s.byLine[i]
+	if len(indexes) == 0 {
+		return formatted
+	}
+	ranges := make([]highlightRange, len(indexes))
+	for j, idx := range indexes {
+		m := s.matches[idx]
+		style := theme.LogMatch
+		if idx == s.current {
+			style = theme.LogMatchCurrent
+		}
+		ranges[j] = highlightRange{m.start, m.end, style}
+	}
+	return highlightANSI(formatted, ranges)
+}
+
+// nextLogMatch goes delta matches forward, or backward if negative, wrapping
+// around
+func (a *App) nextLogMatch(delta int) {
+	n := len(a.logSearch.matches)
+	if n == 0 || a.parsedLogs == nil {
+		return
+	}
+	a.gotoLogMatch(((a.logSearch.current+delta)%n + n) % n)
+}
+
+// gotoLogMatch makes match i current and scrolls the log view to it,
+// selecting the step of the match if it is not shown. Unwrapped lines scroll
+// sideways when the match is past the width of the view.
+func (a *App) gotoLogMatch(i int) {
+	s := &a.logSearch
+	s.current = i
+	m := s.matches[i]
+	a.gotoLogLine(m.line)
+	if !a.logWrap {
+		text := stripANSI(FormatLogLineWithColor(a.parsedLogs.AllLines[m.line]))
+		gutter := a.logGutterWidth()
+		start, end := gutter+lipgloss.Width(text[:m.start]), gutter+lipgloss.Width(text[:m.end])
+		width := a.logView.Width()
+		if end > width {
+			a.logView.SetXOffset(start - width/3)
+		}
+	}
+}
+
+// logViewTopLine returns the log line at the top of the log view
+func (a *App) logViewTopLine() int {
+	top := a.logView.YOffset()
+	line := 0
+	for l, offset := range a.logSearch.offsets {
+		if offset <= top && l > line {
+			line = l
+		}
+	}
+	return line
+}
@@ -0,0 +1,129 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// newLogSearchApp returns an App with the focus in the log content of a job
+// of two steps, with "fail" in three lines
+func newLogSearchApp() *App {
+	app := New()
+	app.width, app.height = 120, 40
+	app.focusedPane = JobsPane
+	app.detailTab = LogsTab
+	app.parsedLogs = ParseLogs(strings.Join([]string{
+		"##[group]Build",
+		"build failed once",
+		"retry",
+		"##[endgroup]",
+		"##[group]Test",
+		"test FAIL: TestA",
+		"test failed",
+		"##[endgroup]",
+	}, "\n"))
+	app.selectedStepIdx = -1
+	app.stepListFocused = false
+	app.updateLogViewContent()
+	return app
+}
+
+func TestApp_LogSearch(t *testing.T) {
+	app := newLogSearchApp()
+
+	// The filter key filters the jobs until the log content has the focus
+	app.stepListFocused = true
+	pressKey(app, "/")
+	if !app.filtering || app.logSearch.typing {
+		t.Fatal("/ should filter the jobs from the step list")
+	}
+	pressKey(app, "esc")
+	app.stepListFocused = false
+
+	pressKey(app, "/")
+	typeText(app, "fail")
+	if n := len(app.logSearch.matches); n != 3 {
+		t.Fatalf("matches = %d, want 3 ignoring case", n)
+	}
+	pressKey(app, "enter")
+	if app.logSearch.typing || !strings.Contains(app.View(), "/fail  1/3") {
+		t.Errorf("the search should stay with its counter:\n%s", app.View())
+	}
+
+	pressKey(app, "n")
+	pressKey(app, "n")
+	if app.logSearch.current != 2 {
+		t.Errorf("current = %d after n twice, want 2", app.logSearch.current)
+	}
+	pressKey(app, "n")
+	if app.logSearch.current != 0 {
+		t.Errorf("n should wrap around, current = %d", app.logSearch.current)
+	}
+	pressKey(app, "N")
+	if app.logSearch.current != 2 {
+		t.Errorf("N should wrap around, current = %d", app.logSearch.current)
+	}
+
+	// Upper case letters match case
+	pressKey(app, "/")
+	app.handleKeyPress(tea.KeyMsg{Type: tea.KeyCtrlU})
+	typeText(app, "FAIL")
+	if n := len(app.logSearch.matches); n != 1 {
+		t.Errorf("matches = %d, want 1 matching case", n)
+	}
+
+	// Regular expressions
+	app.handleKeyPress(tea.KeyMsg{Type: tea.KeyCtrlU})
+	app.handleKeyPress(tea.KeyMsg{Type: tea.KeyCtrlR})
+	typeText(app, "^test")
+	if n := len(app.logSearch.matches); n != 2 || !app.logSearch.regex {
+		t.Errorf("matches = %d, want 2 for a regex", n)
+	}
+	typeText(app, "(")
+	if app.logSearch.err == nil || !strings.Contains(app.View(), "invalid regex") {
+		t.Error("an invalid regex should be reported")
+	}
+
+	pressKey(app, "esc")
+	if app.logSearch.active() || len(app.logSearch.matches) != 0 {
+		t.Error("esc should clear the search")
+	}
+}
+
+func TestApp_LogSearch_Steps(t *testing.T) {
+	app := newLogSearchApp()
+	app.selectedStepIdx = 0
+	app.updateLogViewContent()
+
+	pressKey(app, "/")
+	typeText(app, "fail")
+	if n := len(app.logSearch.matches); n != 1 {
+		t.Errorf("matches = %d in the selected step, want 1", n)
+	}
+
+	// Across steps, going to a match selects its step
+	pressKey(app, "tab")
+	pressKey(app, "enter")
+	if n := len(app.logSearch.matches); n != 3 {
+		t.Fatalf("matches = %d in all steps, want 3", n)
+	}
+	pressKey(app, "n")
+	if app.selectedStepIdx != 1 {
+		t.Errorf("selectedStepIdx = %d, want the step of the match", app.selectedStepIdx)
+	}
+
+	// The search works in the fullscreen log too
+	app.stepListFocused = true
+	pressKey(app, "L")
+	pressKey(app, "n")
+	if app.logSearch.current != 2 || !strings.Contains(app.View(), "3/3") {
+		t.Errorf("n should go to the next match in the fullscreen log:\n%s", app.View())
+	}
+	pressKey(app, "esc")
+	pressKey(app, "esc")
+	if app.logSearch.active() || app.fullscreenLog {
+		t.Error("esc should clear the search, then leave the fullscreen log")
+	}
+}
@@ -9,6 +9,8 @@ import (
 const (
 	// ScrollLineCount is the number of lines to scroll per scroll action
 	ScrollLineCount = 1
+	// ScrollColumnCount is the number of columns to scroll per horizontal scroll action
+	ScrollColumnCount = 8
 )
 
 // LogViewport wraps a viewport with autoscroll functionality.
@@ -43,6 +45,11 @@ func (lv *LogViewport) SetSize(width, height int) {
 	lv.viewport.Height = height
 }
 
+// Width returns the width of the viewport.
+func (lv *LogViewport) Width() int {
+	return lv.viewport.Width
+}
+
 // View returns the rendered content of the viewport.
 func (lv *LogViewport) View() string {
 	return lv.viewport.View()
@@ -81,8 +88,94 @@ func (lv *LogViewport) ScrollDown() {
 	lv.autoscroll = lv.isAtBottom()
 }
 
+// YOffset returns the index of the first line shown.
+func (lv *LogViewport) YOffset() int {
+	return lv.viewport.YOffset
+}
+
+// Height returns the height of the viewport.
+func (lv *LogViewport) Height() int {
+	return lv.viewport.Height
+}
+
+// ShowLine scrolls the viewport as little as needed for line to be visible.
+func (lv *LogViewport) ShowLine(line int) {
+	switch y := lv.viewport.YOffset; {
+	case line < y:
+		lv.viewport.SetYOffset(line)
+	case line >= y+lv.viewport.Height:
+		lv.viewport.SetYOffset(line - lv.viewport.Height + 1)
+	}
+	lv.autoscroll = lv.isAtBottom()
+}
+
+// ScrollTo scrolls the viewport to show line, a third of the way down.
+func (lv *LogViewport) ScrollTo(line int) {
+	lv.viewport.SetYOffset(line - lv.viewport.Height/3)
+	lv.autoscroll = lv.isAtBottom()
+}
+
+// ScrollLeft scrolls the viewport left by ScrollColumnCount columns.
+func (lv *LogViewport) ScrollLeft() {
+	lv.viewport.ScrollLeft(ScrollColumnCount)
+}
+
+// ScrollRight scrolls the viewport right by ScrollColumnCount columns, up
+// to the end of the longest line.
+func (lv *LogViewport) ScrollRight() {
+	lv.viewport.ScrollRight(ScrollColumnCount)
+}
+
+// SetXOffset scrolls the viewport horizontally to show column n first.
+func (lv *LogViewport) SetXOffset(n int) {
+	lv.viewport.SetXOffset(n)
+}
+
 // GotoTop scrolls to the top of the content.
 func (lv *LogViewport) GotoTop() {
 	lv.viewport.GotoTop()
 	lv.autoscroll = false
 }
+
+// layoutLogView sizes the log view to the area View renders it in, if shown:
+// the fullscreen view, or the Logs tab below its steps
+func (a *App) layoutLogView() {
+	if a.width == 0 || a.height == 0 {
+		return
+	}
+	if a.fullscreenLog {
+		// The border takes two columns and lines, the title a line
+		a.fitLogView(a.width-BorderWidth, a.height-BorderWidth-1)
+		return
+	}
+	if _, detail := a.paneRects(); detail.width > 0 && a.detailTab == LogsTab {
+		maxWidth := detail.width - ContentPadding
+		a.fitLogView(maxWidth-4, detail.height-BorderWidth-len(a.buildLogsHeader(maxWidth)))
+	}
+}
+
+// fitLogView sizes the log view to the area it is rendered in, laying the
+// logs out again when its width changes
+func (a *App) fitLogView(width, height int) {
+	width, height = max(width, 1), max(height, 1)
+	resized := width != a.logView.Width()
+	a.logView.SetSize(width, height)
+	if resized && a.parsedLogs != nil {
+		a.updateLogViewContent()
+	}
+}
+
+// toggleLogWrap switches the log view between wrapping long lines and
+// scrolling them horizontally. The mode is kept for the next sessions.
+func (a *App) toggleLogWrap() tea.Cmd {
+	a.logWrap = !a.logWrap
+	a.state.SetLogWrap(a.logWrap)
+	a.logView.SetXOffset(0)
+	if a.parsedLogs != nil {
+		a.updateLogViewContent()
+	}
+	if a.logWrap {
+		return flashMessage("Log lines: wrapped", FlashDurationInfo)
+	}
+	return flashMessage("Log lines: scroll with "+bindingKeys(a.keys.Left, a.keys.Right), FlashDurationInfo)
+}
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 )
 
 // TestNewLogViewport tests the creation of a new LogViewport.
@@ -294,3 +295,78 @@ func TestLogViewport_SetSizeMultipleTimes(t *testing.T) {
 		t.Error("expected non-empty view after multiple resizes")
 	}
 }
+
+func TestApp_LogWrap(t *testing.T) {
+	app := New()
+	app.width, app.height = 60, 20
+	app.focusedPane = JobsPane
+	app.detailTab = LogsTab
+	app.stepListFocused = false
+	app.fullscreenLog = true
+	app.parsedLogs = ParseLogs("short\n" + strings.Repeat("x", 150) + "END")
+	app.selectedStepIdx = -1
+	app.updateLogViewContent()
+	app.Update(tea.WindowSizeMsg{Width: 60, Height: 20})
+
+	// Long lines wrap by default, within the fullscreen log
+	view := app.View()
+	if !strings.Contains(view, "END") {
+		t.Errorf("the end of a long line should wrap into view:\n%s", view)
+	}
+	for _, l := range strings.Split(view, "\n") {
+		if w := lipgloss.Width(l); w > app.width {
+			t.Fatalf("line of width %d wider than the terminal: %q", w, l)
+		}
+	}
+
+	// Unwrapped, long lines scroll sideways
+	pressKey(app, "W")
+	if app.logWrap || app.state.LogWrap() {
+		t.Fatal("W should turn wrapping off, in the state too")
+	}
+	if strings.Contains(app.View(), "END") {
+		t.Error("the end of a long line should be hidden without wrapping")
+	}
+	for range 20 {
+		pressKey(app, "l")
+	}
+	if !strings.Contains(app.View(), "END") {
+		t.Errorf("l should scroll to the end of the line:\n%s", app.View())
+	}
+	app.handleMouseEvent(tea.MouseMsg{Button: tea.MouseButtonWheelUp, Shift: true})
+	pressKey(app, "h")
+	if strings.Contains(app.View(), "END") {
+		t.Error("h and shift+wheel should scroll back left")
+	}
+
+	// The mode persists across sessions
+	if restored := New(WithState(app.state)); restored.logWrap {
+		t.Error("the restored log view should not wrap")
+	}
+}
+
+func TestApp_LogViewLayout(t *testing.T) {
+	app := newLogSearchApp()
+	app.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	_, detail := app.paneRects()
+	maxWidth := detail.width - ContentPadding
+	if w, h := app.logView.Width(), app.logView.Height(); w != maxWidth-4 || h != detail.height-BorderWidth-len(app.buildLogsHeader(maxWidth)) {
+		t.Errorf("log view size = %dx%d, want it to fill the Logs tab below the steps", w, h)
+	}
+
+	// Rendering leaves the size alone: only updates change it
+	app.width = 80
+	app.View()
+	if app.logView.Width() != maxWidth-4 {
+		t.Error("View should not resize the log view")
+	}
+
+	app.Update(keyMsg("L"))
+	if w, h := app.logView.Width(), app.logView.Height(); w != 80-BorderWidth || h != 40-BorderWidth-1 {
+		t.Errorf("fullscreen log view size = %dx%d, want %dx%d", w, h, 80-BorderWidth, 40-BorderWidth-1)
+	}
+	app.Update(tea.WindowSizeMsg{Width: 100, Height: 30})
+	if w, h := app.logView.Width(), app.logView.Height(); w != 100-BorderWidth || h != 30-BorderWidth-1 {
+		t.Errorf("resized fullscreen log view size = %dx%d, want %dx%d", w, h, 100-BorderWidth, 30-BorderWidth-1)
+	}
+}
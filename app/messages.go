@@ -9,21 +9,31 @@ import (
 // === Data Loading Results ===
 
 // WorkflowsLoadedMsg is sent when workflows have been fetched from GitHub.
+// Total is the number of workflows reported by the API, which exceeds
+// len(Workflows) if the listing was truncated.
 type WorkflowsLoadedMsg struct {
 	Workflows []github.Workflow
+	Total     int
 	Err       error
 }
 
-// RunsLoadedMsg is sent when workflow runs have been fetched from GitHub.
+// RunsLoadedMsg is sent when a page of workflow runs has been fetched from
+// GitHub. Opts are the options the page was listed with; pages 0 and 1 are
+// the first page. Total is the number of runs matching Opts reported by the API.
 type RunsLoadedMsg struct {
-	Runs []github.Run
-	Err  error
+	Runs  []github.Run
+	Opts  github.ListRunsOpts
+	Total int
+	Err   error
 }
 
 // JobsLoadedMsg is sent when jobs have been fetched from GitHub.
+// Total is the number of jobs reported by the API, which exceeds
+// len(Jobs) if the listing was truncated.
 type JobsLoadedMsg struct {
-	Jobs []github.Job
-	Err  error
+	Jobs  []github.Job
+	Total int
+	Err   error
 }
 
 // LogsLoadedMsg is sent when job logs have been fetched from GitHub.
@@ -33,6 +43,32 @@ type LogsLoadedMsg struct {
 	Err   error
 }
 
+// RepoStatusLoadedMsg is sent when the latest run of a workspace repository
+// has been fetched. Run is nil if the repository has no runs.
+type RepoStatusLoadedMsg struct {
+	Repo github.Repository
+	Run  *github.Run
+	Err  error
+}
+
+// ViewerLoadedMsg is sent when the login of the authenticated user has been
+// fetched, to resolve actor:@me in filters.
+type ViewerLoadedMsg struct {
+	Login string
+	Err   error
+}
+
+// DashboardLoadedMsg is sent when the runs of all repositories of an owner
+// have been fetched, newest first. Repos is the number of repositories
+// scanned and Failed the number whose runs could not be loaded.
+type DashboardLoadedMsg struct {
+	Runs     []github.Run
+	RunRepos map[int64]github.Repository
+	Repos    int
+	Failed   int
+	Err      error
+}
+
 // === Action Results ===
 
 // RunCancelledMsg is sent when a workflow run has been cancelled.
@@ -53,6 +89,26 @@ type RerunFailedJobsMsg struct {
 	Err   error
 }
 
+// RefsLoadedMsg is sent when the refs offered by the ref picker have been loaded.
+// TagsErr reports tags that failed to load, the branches being loaded.
+type RefsLoadedMsg struct {
+	DefaultBranch string
+	Branches      []string
+	Tags          []string
+	Err           error
+	TagsErr       error
+}
+
+// DispatchInputsLoadedMsg is sent when the workflow_dispatch inputs of a workflow
+// have been read from its workflow file.
+type DispatchInputsLoadedMsg struct {
+	Workflow     github.Workflow
+	Ref          string
+	Inputs       []github.WorkflowInput
+	Environments []string
+	Err          error
+}
+
 // WorkflowTriggeredMsg is sent when a workflow has been triggered.
 type WorkflowTriggeredMsg struct {
 	Workflow string
@@ -70,6 +126,12 @@ type FlashMsg struct {
 // FlashClearMsg is sent to clear the flash message.
 type FlashClearMsg struct{}
 
+// RunsFilterDebounceMsg is sent once typing in the Runs filter pauses, to
+// apply its server-side terms. Seq identifies the keystroke it follows.
+type RunsFilterDebounceMsg struct {
+	Seq int
+}
+
 // TickMsg is sent on each polling interval.
 type TickMsg struct {
 	Time time.Time
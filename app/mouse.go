@@ -1,7 +1,10 @@
 package app
 
 import (
+	"strings"
+
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nnnkkk7/lazyactions/config"
 	"github.com/nnnkkk7/lazyactions/github"
 )
 
@@ -11,55 +14,174 @@ func (a *App) handleMouseEvent(msg tea.MouseMsg) (tea.Model, tea.Cmd) {
 	a.mouseX = msg.X
 	a.mouseY = msg.Y
 
+	// The wheel scrolls the fullscreen log
+	if a.fullscreenLog && !a.showHelp && a.palette == nil {
+		switch {
+		case msg.Button == tea.MouseButtonWheelLeft, msg.Button == tea.MouseButtonWheelUp && msg.Shift:
+			return a.scrollLogSideways(false)
+		case msg.Button == tea.MouseButtonWheelRight, msg.Button == tea.MouseButtonWheelDown && msg.Shift:
+			return a.scrollLogSideways(true)
+		case msg.Button == tea.MouseButtonWheelUp:
+			a.logView.ScrollUp()
+		case msg.Button == tea.MouseButtonWheelDown:
+			a.logView.ScrollDown()
+		}
+		return a, nil
+	}
+
 	// Ignore actions when popups are shown
-	if a.showHelp || a.showConfirm || a.fullscreenLog || a.filtering {
+	if a.showHelp || a.showConfirm || a.fullscreenLog || a.filtering || a.refPicker != nil || a.dispatchForm != nil || a.remoteSwitcher != nil || a.palette != nil {
 		return a, nil
 	}
 
 	switch msg.Button {
+	case tea.MouseButtonWheelLeft:
+		return a.scrollLogSideways(false)
+	case tea.MouseButtonWheelRight:
+		return a.scrollLogSideways(true)
 	case tea.MouseButtonWheelUp:
+		if msg.Shift {
+			return a.scrollLogSideways(false)
+		}
 		return a.handleScrollUp()
 	case tea.MouseButtonWheelDown:
+		if msg.Shift {
+			return a.scrollLogSideways(true)
+		}
 		return a.handleScrollDown()
 	case tea.MouseButtonLeft:
-		if msg.Action == tea.MouseActionRelease {
+		switch msg.Action {
+		case tea.MouseActionPress:
+			a.drag = a.borderAt(msg.X, msg.Y)
+		case tea.MouseActionMotion:
+			if a.drag != nil {
+				a.dragBorder(msg.X, msg.Y)
+			}
+		case tea.MouseActionRelease:
+			// A border dragged is not a click
+			drag := a.drag
+			a.drag = nil
+			if drag != nil && drag.moved {
+				return a, nil
+			}
 			return a.handleClick(msg.X, msg.Y)
 		}
 	}
 	return a, nil
 }
 
+// layoutDrag is a border between panels being dragged with the mouse
+type layoutDrag struct {
+	sidebar bool // the border between the left panes and the detail panel
+	pane    int  // otherwise, the index of the left pane above the border
+	moved   bool
+}
+
+// borderAt returns the border between panels at x, y, or nil. Both the
+// border lines meeting there can be dragged.
+func (a *App) borderAt(x, y int) *layoutDrag {
+	panes, detail := a.paneRects()
+	if detail.width == 0 || len(panes) == 0 {
+		return nil
+	}
+	area, _ := a.panesArea()
+	if a.stacked() {
+		if (y == area.height-1 || y == detail.y) && x < a.width {
+			return &layoutDrag{sidebar: true}
+		}
+	} else if (x == area.width-1 || x == detail.x) && y < detail.height {
+		return &layoutDrag{sidebar: true}
+	}
+	if a.layout.compact {
+		return nil
+	}
+
+	left := a.leftPanes()
+	for i := 0; i < len(left)-1; i++ {
+		above, below := panes[left[i]], panes[left[i+1]]
+		if x < area.width && (y == above.y+above.height-1 || y == below.y) {
+			return &layoutDrag{pane: i}
+		}
+	}
+	return nil
+}
+
+// dragBorder moves the border being dragged to x, y
+func (a *App) dragBorder(x, y int) {
+	a.drag.moved = true
+	if a.drag.sidebar {
+		// Half a cell more so that the size rounds down to the border
+		if a.stacked() {
+			totalHeight, _ := a.panelLayout()
+			a.layout.stackedRatio = max(min((float64(y)+1.5)/float64(totalHeight), config.MaxLeftWidth), config.MinLeftWidth)
+		} else {
+			a.layout.sidebarRatio = max(min((float64(x)+1.5)/float64(a.width), config.MaxLeftWidth), config.MinLeftWidth)
+		}
+		a.saveLayout()
+		return
+	}
+
+	i := a.drag.pane
+	panes, _ := a.paneRects()
+	left := a.leftPanes()
+	above, below := panes[left[i]], panes[left[i+1]]
+	combined := above.height + below.height
+	height := max(min(y+1-above.y, combined-MinPanelHeight), MinPanelHeight)
+	if height == above.height || combined < 2*MinPanelHeight {
+		return
+	}
+
+	area, _ := a.panesArea()
+	heights := a.paneHeights(area.height)
+	heights[i], heights[i+1] = height, combined-height
+	a.setPaneHeights(heights)
+}
+
 // handleClick handles mouse click events
 func (a *App) handleClick(x, y int) (tea.Model, tea.Cmd) {
-	leftWidth := a.leftPanelWidth()
-	totalHeight, panelHeight := a.panelLayout()
+	panes, detail := a.paneRects()
 
-	// Handle clicks in the right panel (detail view)
-	if x >= leftWidth {
-		return a.handleDetailPanelClick(x, y, leftWidth, totalHeight)
+	// Handle clicks in the detail panel
+	if detail.contains(x, y) {
+		return a.handleDetailPanelClick(x-detail.x, y-detail.y, detail.width, detail.height)
+	}
+
+	// Determine which panel was clicked
+	var r rect
+	found := false
+	for _, pane := range a.leftPanes() {
+		if r = panes[pane]; r.contains(x, y) {
+			a.focusedPane = pane
+			found = true
+			break
+		}
 	}
+	if !found {
+		return a, nil
+	}
+	itemIdx := y - r.y - BorderOffset
 
-	// Determine which panel was clicked (left sidebar)
-	if y < panelHeight {
-		// Workflows panel
-		a.focusedPane = WorkflowsPane
-		itemIdx := y - BorderOffset + a.workflows.ScrollOffset()
+	switch a.focusedPane {
+	case ReposPane:
+		itemIdx += a.repos.ScrollOffset()
+		if itemIdx >= 0 && itemIdx < a.repos.Len() {
+			a.repos.Select(itemIdx)
+			return a, a.onRepoSelectionChange()
+		}
+	case WorkflowsPane:
+		itemIdx += a.workflows.ScrollOffset()
 		if itemIdx >= 0 && itemIdx < a.workflows.Len() {
 			a.workflows.Select(itemIdx)
 			return a, a.onWorkflowSelectionChange()
 		}
-	} else if y < 2*panelHeight {
-		// Runs panel
-		a.focusedPane = RunsPane
-		itemIdx := y - panelHeight - BorderOffset + a.runs.ScrollOffset()
+	case RunsPane:
+		itemIdx += a.runs.ScrollOffset()
 		if itemIdx >= 0 && itemIdx < a.runs.Len() {
 			a.runs.Select(itemIdx)
 			return a, a.onRunSelectionChange()
 		}
-	} else if y < totalHeight {
-		// Jobs panel
-		a.focusedPane = JobsPane
-		itemIdx := y - 2*panelHeight - BorderOffset + a.jobs.ScrollOffset()
+	case JobsPane:
+		itemIdx += a.jobs.ScrollOffset()
 		if itemIdx >= 0 && itemIdx < a.jobs.Len() {
 			a.jobs.Select(itemIdx)
 			return a, a.onJobSelectionChange()
@@ -107,12 +229,26 @@ func (a *App) handleDetailPanelClick(_, y, _, _ int) (tea.Model, tea.Cmd) {
 }
 
 // handleScrollUp handles mouse wheel up
-func (a *App) handleScrollUp() (tea.Model, tea.Cmd) {
-	// Calculate left panel width for determining scroll context
-	leftWidth := a.leftPanelWidth()
+// scrollLogSideways scrolls unwrapped log lines right, or left, when the mouse
+// is over the log view
+func (a *App) scrollLogSideways(right bool) (tea.Model, tea.Cmd) {
+	_, detail := a.paneRects()
+	overLogs := a.fullscreenLog || (detail.contains(a.mouseX, a.mouseY) && a.detailTab == LogsTab)
+	if !overLogs || a.logWrap {
+		return a, nil
+	}
+	if right {
+		a.logView.ScrollRight()
+	} else {
+		a.logView.ScrollLeft()
+	}
+	return a, nil
+}
 
+func (a *App) handleScrollUp() (tea.Model, tea.Cmd) {
 	// If mouse is in the detail panel and we're in Logs tab with steps, scroll the step list
-	if a.mouseX >= leftWidth && a.detailTab == LogsTab && a.parsedLogs != nil && len(a.parsedLogs.Steps) > 0 {
+	_, detail := a.paneRects()
+	if detail.contains(a.mouseX, a.mouseY) && a.detailTab == LogsTab && a.parsedLogs != nil && len(a.parsedLogs.Steps) > 0 {
 		if a.stepListFocused {
 			a.navigateStepUp()
 		} else {
@@ -123,6 +259,9 @@ func (a *App) handleScrollUp() (tea.Model, tea.Cmd) {
 
 	// Otherwise, scroll the focused left panel
 	switch a.focusedPane {
+	case ReposPane:
+		a.repos.SelectPrev()
+		return a, a.onRepoSelectionChange()
 	case WorkflowsPane:
 		a.workflows.SelectPrev()
 		return a, a.onWorkflowSelectionChange()
@@ -138,11 +277,9 @@ func (a *App) handleScrollUp() (tea.Model, tea.Cmd) {
 
 // handleScrollDown handles mouse wheel down
 func (a *App) handleScrollDown() (tea.Model, tea.Cmd) {
-	// Calculate left panel width for determining scroll context
-	leftWidth := a.leftPanelWidth()
-
 	// If mouse is in the detail panel and we're in Logs tab with steps, scroll the step list
-	if a.mouseX >= leftWidth && a.detailTab == LogsTab && a.parsedLogs != nil && len(a.parsedLogs.Steps) > 0 {
+	_, detail := a.paneRects()
+	if detail.contains(a.mouseX, a.mouseY) && a.detailTab == LogsTab && a.parsedLogs != nil && len(a.parsedLogs.Steps) > 0 {
 		if a.stepListFocused {
 			a.navigateStepDown()
 		} else {
@@ -153,6 +290,9 @@ func (a *App) handleScrollDown() (tea.Model, tea.Cmd) {
 
 	// Otherwise, scroll the focused left panel
 	switch a.focusedPane {
+	case ReposPane:
+		a.repos.SelectNext()
+		return a, a.onRepoSelectionChange()
 	case WorkflowsPane:
 		a.workflows.SelectNext()
 		return a, a.onWorkflowSelectionChange()
@@ -166,9 +306,18 @@ func (a *App) handleScrollDown() (tea.Model, tea.Cmd) {
 	return a, nil
 }
 
+// onRepoSelectionChange switches to the selected workspace repository
+func (a *App) onRepoSelectionChange() tea.Cmd {
+	if r, ok := a.repos.Selected(); ok {
+		return a.switchRepository(r)
+	}
+	return nil
+}
+
 // onWorkflowSelectionChange handles workflow selection change
 func (a *App) onWorkflowSelectionChange() tea.Cmd {
 	if wf, ok := a.workflows.Selected(); ok {
+		a.resetRuns()
 		a.loading = true
 		return a.fetchRunsCmd(wf.ID)
 	}
@@ -179,7 +328,7 @@ func (a *App) onWorkflowSelectionChange() tea.Cmd {
 func (a *App) onRunSelectionChange() tea.Cmd {
 	if run, ok := a.runs.Selected(); ok {
 		a.loading = true
-		return a.fetchJobsCmd(run.ID)
+		return tea.Batch(a.fetchJobsCmd(run.ID), a.loadMoreRuns())
 	}
 	return nil
 }
@@ -194,6 +343,8 @@ func (a *App) onJobSelectionChange() tea.Cmd {
 	// Reset step selection for new job
 	a.parsedLogs = nil
 	a.selectedStepIdx = -1
+	a.logGoto, a.logVisual, a.logCursor = nil, nil, 0
+	a.logUnfolded = nil
 	a.stepListFocused = true
 
 	// GitHub API only provides logs for completed jobs
@@ -216,20 +367,25 @@ func jobStatusMessage(job github.Job) string {
 
 // updateLogViewContent updates the log view with the currently selected step's logs
 func (a *App) updateLogViewContent() {
-	if a.parsedLogs == nil {
+	a.findLogMatches()
+	if a.parsedLogs == nil || a.parsedLogs.GetStepLogs(a.selectedStepIdx) == "" {
 		a.logView.SetContent("No logs available")
 		return
 	}
 
-	// Get logs for the selected step (formatted with syntax highlighting)
-	logs := a.parsedLogs.FormatStepLogsWithColor(a.selectedStepIdx)
-	if logs == "" {
-		logs = "No logs available"
+	// Render the lines of the selected step, see logLineView
+	a.logSearch.offsets = map[int]int{}
+	lo, hi := a.parsedLogs.stepRange(a.selectedStepIdx)
+	var lines []string
+	for i := lo; i < hi; i++ {
+		a.logSearch.offsets[i] = len(lines)
+		lines = append(lines, a.logLineView(i)...)
+		// The lines of a folded group are hidden
+		if g := a.parsedLogs.groupAt(i); g != nil && !a.logUnfolded[i] {
+			i = g.EndLine
+		}
 	}
-
-	// Wrap log lines to fit within viewport width
-	wrappedLogs := wrapLines(logs, a.logPaneWidth()-4)
-	a.logView.SetContent(wrappedLogs)
+	a.logView.SetContent(strings.Join(lines, "\n"))
 }
 
 // navigateStepUp moves step selection up
@@ -0,0 +1,263 @@
+package app
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Command palette constants
+const (
+	// PaletteMaxWidth is the maximum width of the command palette
+	PaletteMaxWidth = 64
+	// PaletteHeight is the number of commands visible at once in the command palette
+	PaletteHeight = 12
+)
+
+// paletteCommand is an action listed in the command palette
+type paletteCommand struct {
+	desc    string
+	binding key.Binding
+	// disabled is why the action cannot run, empty if it can
+	disabled string
+}
+
+// commandPalette is the modal listing the actions of the current context.
+// Typing ranks the actions by how well their description matches.
+type commandPalette struct {
+	input textinput.Model
+	list  *FilteredList[paletteCommand]
+}
+
+// newCommandPalette creates a palette listing commands
+func newCommandPalette(commands []paletteCommand) *commandPalette {
+	ti := textinput.New()
+	ti.Placeholder = "type a command"
+	ti.Prompt = "> "
+	ti.Focus()
+
+	p := &commandPalette{
+		input: ti,
+		list: NewRankedList(func(c paletteCommand, filter string) (int, bool) {
+			score, _, ok := fuzzyMatch(filter, c.desc)
+			return score, ok
+		}),
+	}
+	p.list.SetVisibleHeight(PaletteHeight)
+	p.list.SetItems(commands)
+	return p
+}
+
+// update handles a key press that is not a palette-level command
+func (p *commandPalette) update(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "up", "ctrl+p":
+		p.list.SelectPrev()
+		return nil
+	case "down", "ctrl+n":
+		p.list.SelectNext()
+		return nil
+	}
+
+	var cmd tea.Cmd
+	p.input, cmd = p.input.Update(msg)
+	p.list.SetFilter(p.input.Value())
+	p.list.Select(0)
+	return cmd
+}
+
+// view renders the palette body
+func (p *commandPalette) view(width int) string {
+	var b strings.Builder
+	b.WriteString(lipgloss.NewStyle().Bold(true).Render("Commands"))
+	b.WriteString("\n\n")
+	b.WriteString(p.input.View())
+	b.WriteString("\n\n")
+
+	items := p.list.VisibleItems()
+	offset := p.list.ScrollOffset()
+	selectedIdx := p.list.SelectedIndex()
+	filter := p.list.Filter()
+	for i, c := range items {
+		keys := c.binding.Help().Key
+		label := keys
+		if c.disabled != "" {
+			label = c.disabled + "  " + keys
+		}
+		desc := truncateString(c.desc, width-lipgloss.Width(label)-3)
+		gap := max(width-lipgloss.Width(desc)-lipgloss.Width(label)-2, 1)
+		_, matches, _ := fuzzyMatch(filter, desc)
+
+		style := theme.NormalItem
+		if c.disabled != "" {
+			style = theme.Dimmed
+		}
+		if offset+i == selectedIdx {
+			b.WriteString(theme.Cursor.Render(">") + renderHighlighted(" ", desc, matches, theme.SelectedItemFocused))
+		} else {
+			b.WriteString(renderHighlighted("  ", desc, matches, style))
+		}
+		b.WriteString(strings.Repeat(" ", gap))
+		if c.disabled != "" {
+			b.WriteString(theme.Queued.Render(c.disabled) + "  ")
+		}
+		b.WriteString(theme.Running.Render(keys) + "\n")
+	}
+	if len(items) == 0 {
+		b.WriteString(theme.Queued.Render("  No matching commands") + "\n")
+	}
+	for i := max(len(items), 1); i < PaletteHeight; i++ {
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n[↑/↓] select  [Enter] run  [Esc] cancel")
+	return b.String()
+}
+
+// paletteCommands returns the actions of the focused pane, then those of
+// every pane, with the reason each cannot run if any
+func (a *App) paletteCommands() []paletteCommand {
+	k := a.keys
+	command := func(desc string, b key.Binding, disabled string) paletteCommand {
+		return paletteCommand{desc: desc, binding: b, disabled: disabled}
+	}
+
+	var commands []paletteCommand
+	switch a.focusedPane {
+	case WorkflowsPane:
+		disabled := ""
+		if _, ok := a.workflows.Selected(); !ok {
+			disabled = "no workflow selected"
+		}
+		commands = append(commands, command("Trigger workflow", k.Trigger, disabled))
+	case RunsPane:
+		run, ok := a.runs.Selected()
+		cancel, rerun, rerunFailed := "", "", ""
+		switch {
+		case !ok:
+			cancel, rerun, rerunFailed = "no run selected", "no run selected", "no run selected"
+		default:
+			if !run.IsRunning() {
+				cancel = "run is not in progress"
+			}
+			if !run.IsFailed() {
+				rerunFailed = "run did not fail"
+			}
+		}
+		commands = append(commands,
+			command("Cancel run", k.Cancel, cancel),
+			command("Rerun workflow", k.Rerun, rerun),
+			command("Rerun failed jobs", k.RerunFailed, rerunFailed),
+		)
+	case JobsPane:
+		search, matches := "", ""
+		switch {
+		case !a.inLogView():
+			search, matches = "log content not focused", "log content not focused"
+		case len(a.logSearch.matches) == 0:
+			matches = "no search matches"
+		}
+		wrap := "Wrap log lines"
+		if a.logWrap {
+			wrap = "Unwrap log lines"
+		}
+		fold := search
+		if fold == "" && (a.parsedLogs == nil || len(a.parsedLogs.groups) == 0) {
+			fold = "no log groups"
+		}
+		lineNumbers := "Show log line numbers"
+		if a.logLineNumbers {
+			lineNumbers = "Hide log line numbers"
+		}
+		commands = append(commands,
+			command("Full-screen log", k.FullLog, ""),
+			command("Previous job", k.JobUp, ""),
+			command("Next job", k.JobDown, ""),
+			command("Search logs", k.Filter, search),
+			command("Next search match", k.NextMatch, matches),
+			command("Previous search match", k.PrevMatch, matches),
+			command(wrap, k.Wrap, ""),
+			command(lineNumbers, k.LineNumbers, ""),
+			command("Go to log line", k.GotoLine, search),
+			command("Select log lines to copy", k.Visual, search),
+			command("Fold or unfold log group", k.Fold, fold),
+			command("Unfold all log groups", k.UnfoldAll, fold),
+			command("Fold all log groups", k.FoldAll, fold),
+		)
+	}
+
+	yank := ""
+	if run, ok := a.runs.Selected(); !ok {
+		yank = "no run selected"
+	} else if run.URL == "" {
+		yank = "run has no URL"
+	}
+	info, logs := "", ""
+	if a.detailTab == InfoTab {
+		info = "already shown"
+	} else {
+		logs = "already shown"
+	}
+	filter := ""
+	if a.inLogView() {
+		filter = "searches the logs here"
+	}
+	remote := ""
+	if len(a.remotes) < 2 {
+		remote = "no other GitHub remotes"
+	}
+	dashboard := "Open dashboard of all repositories"
+	if a.dashboard != nil {
+		dashboard = "Close dashboard"
+	}
+	zoom := "Zoom focused pane"
+	if a.layout.zoomed {
+		zoom = "Restore zoomed pane"
+	}
+	resizePane, compact := "", "Turn the compact layout on"
+	if a.layout.compact {
+		resizePane, compact = "compact layout shows one pane", "Turn the compact layout off"
+	}
+
+	return append(commands,
+		command("Copy run URL to clipboard", k.Yank, yank),
+		command("Refresh", k.Refresh, ""),
+		command("Filter pane", k.Filter, filter),
+		command("Show info tab", k.InfoTab, info),
+		command("Show logs tab", k.LogsTab, logs),
+		command("Switch git remote", k.SwitchRemote, remote),
+		command(dashboard, k.Dashboard, ""),
+		command(zoom, k.Zoom, ""),
+		command("Switch layout", k.Layout, ""),
+		command("Shrink sidebar", k.ShrinkSidebar, ""),
+		command("Grow sidebar", k.GrowSidebar, ""),
+		command("Shrink focused pane", k.ShrinkPane, resizePane),
+		command("Grow focused pane", k.GrowPane, resizePane),
+		command(compact, k.Compact, ""),
+		command("Toggle help", k.Help, ""),
+		command("Quit", k.Quit, ""),
+	)
+}
+
+// openPalette shows the command palette for the current context
+func (a *App) openPalette() {
+	a.palette = newCommandPalette(a.paletteCommands())
+}
+
+// runPaletteCommand closes the palette and runs the selected command as if
+// its key was pressed. Commands that cannot run keep the palette open.
+func (a *App) runPaletteCommand() tea.Cmd {
+	c, ok := a.palette.list.Selected()
+	if !ok || c.disabled != "" {
+		return nil
+	}
+	a.palette = nil
+	keys := c.binding.Keys()
+	if len(keys) == 0 {
+		return nil
+	}
+	return a.handleKeyPress(keyMsg(keys[0]))
+}
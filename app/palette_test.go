@@ -0,0 +1,221 @@
+package app
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nnnkkk7/lazyactions/github"
+)
+
+func typeText(app *App, text string) {
+	for _, r := range text {
+		app.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+}
+
+func TestKeyMsg(t *testing.T) {
+	// Every key of every preset makes a key press that triggers its binding
+	for name := range presets {
+		km, _ := newKeyMap(name, nil)
+		bindings := km.bindings()
+		for _, action := range slices.Sorted(maps.Keys(bindings)) {
+			for _, k := range bindings[action].Keys() {
+				if msg := keyMsg(k); msg.String() != k || !key.Matches(msg, *bindings[action]) {
+					t.Errorf("preset %s: keyMsg(%q) = %q, should trigger %s", name, k, msg.String(), action)
+				}
+			}
+		}
+	}
+
+	if msg := keyMsg("alt+x"); !msg.Alt || msg.String() != "alt+x" {
+		t.Errorf("keyMsg(alt+x) = %q", msg.String())
+	}
+}
+
+func TestApp_Palette(t *testing.T) {
+	app := New(WithClient(newMockClient(nil)), WithRepository(apiRepo))
+	app.width, app.height = 120, 40
+	app.runs.SetItems([]github.Run{{ID: 1, Status: "completed", Conclusion: "success"}})
+	app.focusedPane = RunsPane
+
+	app.handleKeyPress(tea.KeyMsg{Type: tea.KeyCtrlP})
+	if app.palette == nil {
+		t.Fatal("ctrl+p should open the command palette")
+	}
+	view := app.View()
+	for _, want := range []string{"Rerun failed jobs", "run did not fail", "Cancel run", "run is not in progress"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("palette should contain %q:\n%s", want, view)
+		}
+	}
+	if strings.Contains(view, "Trigger workflow") {
+		t.Errorf("palette should only list the actions of the Runs pane:\n%s", view)
+	}
+	typeText(app, "help")
+	if !strings.Contains(app.View(), "Toggle help") {
+		t.Errorf("palette should list the actions of every pane:\n%s", app.View())
+	}
+	app.handleKeyPress(tea.KeyMsg{Type: tea.KeyEsc})
+	app.handleKeyPress(tea.KeyMsg{Type: tea.KeyCtrlP})
+
+	// Typing ranks the commands; disabled commands do not run
+	typeText(app, "rerun fail")
+	if c, _ := app.palette.list.Selected(); c.desc != "Rerun failed jobs" {
+		t.Errorf("selected = %q, want the best match", c.desc)
+	}
+	if cmd := app.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter}); cmd != nil || app.palette == nil {
+		t.Error("a disabled command should not run")
+	}
+
+	// Commands run as their key would
+	app.handleKeyPress(tea.KeyMsg{Type: tea.KeyEsc})
+	app.handleKeyPress(tea.KeyMsg{Type: tea.KeyCtrlP})
+	typeText(app, "filter")
+	app.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	if app.palette != nil || !app.filtering {
+		t.Errorf("palette = %v, filtering = %v, want the filter started", app.palette, app.filtering)
+	}
+}
+
+func TestApp_Palette_RunsThroughKeyPress(t *testing.T) {
+	app := New(WithClient(newMockClient(nil)), WithRepository(apiRepo))
+	app.runs.SetItems([]github.Run{{ID: 1, Status: "in_progress"}})
+	app.focusedPane = RunsPane
+
+	app.openPalette()
+	typeText(app, "cancel")
+	app.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	if !app.showConfirm || app.confirmMsg != "Cancel this run?" {
+		t.Errorf("cancel should ask for confirmation like its key, got %q", app.confirmMsg)
+	}
+}
+
+func TestApp_Palette_LayoutCommands(t *testing.T) {
+	app := New(WithClient(newMockClient(nil)), WithRepository(apiRepo))
+	app.width, app.height = 120, 40
+	app.focusedPane = RunsPane
+
+	app.openPalette()
+	typeText(app, "zoom")
+	app.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	if !app.layout.zoomed {
+		t.Fatal("the zoom command should zoom the focused pane")
+	}
+	app.openPalette()
+	typeText(app, "restore zoom")
+	if c, _ := app.palette.list.Selected(); c.desc != "Restore zoomed pane" {
+		t.Errorf("selected = %q, want the zoom command to restore the layout", c.desc)
+	}
+
+	app.palette = nil
+	app.openPalette()
+	typeText(app, "compact on")
+	app.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	if !app.layout.compact {
+		t.Fatal("the compact command should turn the compact layout on")
+	}
+	app.openPalette()
+	typeText(app, "grow focused")
+	if !strings.Contains(app.View(), "compact layout shows one pane") {
+		t.Errorf("resizing a pane should be disabled in the compact layout:\n%s", app.View())
+	}
+}
+
+func TestApp_Palette_LogCommands(t *testing.T) {
+	app := newLogSearchApp()
+
+	app.openPalette()
+	typeText(app, "next search match")
+	if !strings.Contains(app.View(), "no search matches") {
+		t.Errorf("next match should be disabled without a search:\n%s", app.View())
+	}
+
+	app.palette = nil
+	app.openPalette()
+	typeText(app, "search logs")
+	app.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	if !app.logSearch.typing {
+		t.Fatal("the search command should start a log search")
+	}
+	typeText(app, "fail")
+	pressKey(app, "enter")
+	app.openPalette()
+	typeText(app, "next search match")
+	app.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	if app.logSearch.current != 1 {
+		t.Errorf("current match = %d, want the next one", app.logSearch.current)
+	}
+
+	wrap := app.logWrap
+	app.openPalette()
+	typeText(app, "wrap log lines")
+	app.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	if app.logWrap == wrap {
+		t.Error("the wrap command should toggle wrapping")
+	}
+
+	app.openPalette()
+	typeText(app, "show log line numbers")
+	app.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	if !app.logLineNumbers {
+		t.Error("the line numbers command should show them")
+	}
+	app.openPalette()
+	typeText(app, "go to log line")
+	app.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	if app.logGoto == nil {
+		t.Fatal("the go to line command should show its prompt")
+	}
+	pressKey(app, "esc")
+	app.openPalette()
+	typeText(app, "select log lines")
+	app.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	if app.logVisual == nil {
+		t.Fatal("the select command should start the visual mode")
+	}
+	pressKey(app, "esc")
+
+	// Out of the log content, log commands are disabled
+	app.stepListFocused = true
+	app.openPalette()
+	typeText(app, "search logs")
+	if !strings.Contains(app.View(), "log content not focused") {
+		t.Errorf("the log search should be disabled from the step list:\n%s", app.View())
+	}
+}
+
+func TestApp_Palette_FoldCommands(t *testing.T) {
+	app := newLogSearchApp()
+
+	app.openPalette()
+	typeText(app, "unfold all")
+	if !strings.Contains(app.View(), "no log groups") {
+		t.Errorf("fold commands should be disabled without groups:\n%s", app.View())
+	}
+
+	app.palette = nil
+	app.parsedLogs = ParseLogs(strings.Join([]string{
+		"##[group]Run go test",
+		"##[group]ok pkg/a",
+		"=== RUN TestA",
+		"##[endgroup]",
+		"##[endgroup]",
+	}, "\n"))
+	app.updateLogViewContent()
+	app.openPalette()
+	typeText(app, "unfold all")
+	app.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	if !app.logUnfolded[1] {
+		t.Error("the unfold command should unfold every group")
+	}
+	app.openPalette()
+	typeText(app, "fold or unfold")
+	app.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	if app.logUnfolded[1] {
+		t.Error("the fold command should run like its chord")
+	}
+}
@@ -0,0 +1,145 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Polling constants
+const (
+	// PollIntervalActive is the polling interval while the selected run or job is running
+	PollIntervalActive = 5 * time.Second
+	// PollIntervalIdle is the first polling interval once nothing selected is running
+	PollIntervalIdle = 15 * time.Second
+	// PollIntervalMax caps the idle backoff
+	PollIntervalMax = 2 * time.Minute
+	// PollRateLimitThreshold is the remaining API quota below which polling drops to PollIntervalMax
+	PollRateLimitThreshold = 100
+)
+
+// poller tracks background polling state.
+// Only one tick is ever in flight; a tick that arrives while paused ends the chain
+// and focus regain starts a new one.
+type poller struct {
+	interval    time.Duration // Interval of the most recently scheduled tick
+	pending     bool          // Whether a tick is in flight
+	paused      bool          // Paused while the terminal is unfocused
+	lastUpdated time.Time     // Time of the last successful data load
+}
+
+// schedulePoll schedules the next polling tick.
+// Returns nil if polling is paused or a tick is already pending.
+func (a *App) schedulePoll() tea.Cmd {
+	if a.poll.paused || a.poll.pending {
+		return nil
+	}
+	a.poll.interval = a.nextPollInterval()
+	a.poll.pending = true
+	return tick(a.poll.interval)
+}
+
+// nextPollInterval returns the interval for the next tick.
+// It polls fast while the selected run or job is running and doubles the
+// interval on each idle tick up to the maximum. The intervals default to
+// PollIntervalActive, PollIntervalIdle and PollIntervalMax.
+func (a *App) nextPollInterval() time.Duration {
+	s := a.settings
+	if a.client != nil && a.client.RateLimitRemaining() < PollRateLimitThreshold {
+		return s.pollIntervalMax
+	}
+	if a.hasRunningSelection() {
+		return s.pollIntervalActive
+	}
+	if a.poll.interval < s.pollIntervalIdle {
+		return s.pollIntervalIdle
+	}
+	next := a.poll.interval * 2
+	if next > s.pollIntervalMax {
+		next = s.pollIntervalMax
+	}
+	return next
+}
+
+// hasRunningSelection returns true if the selected run or job is still running
+func (a *App) hasRunningSelection() bool {
+	if run, ok := a.runs.Selected(); ok && run.IsRunning() {
+		return true
+	}
+	if job, ok := a.jobs.Selected(); ok && job.IsRunning() {
+		return true
+	}
+	return false
+}
+
+// handleTick refreshes data and schedules the next tick
+func (a *App) handleTick() tea.Cmd {
+	a.poll.pending = false
+	if a.poll.paused {
+		return nil
+	}
+	return tea.Batch(a.pollCmd(), a.refreshRepoStatuses(), a.schedulePoll())
+}
+
+// handleFocus resumes polling with an immediate refresh when the terminal regains focus
+func (a *App) handleFocus() tea.Cmd {
+	if !a.poll.paused {
+		return nil
+	}
+	a.poll.paused = false
+	a.poll.interval = 0
+	return tea.Batch(a.pollCmd(), a.schedulePoll())
+}
+
+// handleBlur pauses polling while the terminal is unfocused
+func (a *App) handleBlur() {
+	a.poll.paused = true
+}
+
+// pollCmd refreshes the runs of the selected workflow, or of the dashboard
+// at its own pace. Jobs and logs of the selection are reloaded by the
+// RunsLoadedMsg cascade.
+func (a *App) pollCmd() tea.Cmd {
+	if a.client == nil {
+		return nil
+	}
+	if a.dashboard != nil {
+		return a.pollDashboard()
+	}
+	if a.workflows.Len() == 0 {
+		return a.forView(fetchWorkflows(a.client, a.repo))
+	}
+	return a.refreshCurrentWorkflow()
+}
+
+// markUpdated records a successful data load
+func (a *App) markUpdated() {
+	a.poll.lastUpdated = time.Now()
+}
+
+// pollStatusText returns the "last updated" indicator for the status bar
+func (a *App) pollStatusText() string {
+	if a.poll.lastUpdated.IsZero() {
+		return ""
+	}
+	text := "updated " + formatAge(time.Since(a.poll.lastUpdated))
+	if a.poll.paused {
+		text += " (paused)"
+	}
+	return text
+}
+
+// formatAge formats a duration as a short relative age (e.g., "12s ago")
+func formatAge(d time.Duration) string {
+	switch {
+	case d < time.Second:
+		return "just now"
+	case d < time.Minute:
+		return fmt.Sprintf("%ds ago", int(d/time.Second))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d/time.Minute))
+	default:
+		return fmt.Sprintf("%dh ago", int(d/time.Hour))
+	}
+}
@@ -0,0 +1,228 @@
+package app
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nnnkkk7/lazyactions/github"
+)
+
+func TestApp_SchedulePoll(t *testing.T) {
+	app := New()
+
+	cmd := app.schedulePoll()
+	if cmd == nil {
+		t.Fatal("schedulePoll() returned nil")
+	}
+	if !app.poll.pending {
+		t.Error("poll.pending = false after scheduling, want true")
+	}
+
+	// Only one tick may be in flight
+	if cmd := app.schedulePoll(); cmd != nil {
+		t.Error("schedulePoll() with pending tick should return nil")
+	}
+}
+
+func TestApp_NextPollInterval(t *testing.T) {
+	t.Run("fast while selected run is running", func(t *testing.T) {
+		app := New()
+		app.runs.SetItems([]github.Run{{ID: 1, Status: "in_progress"}})
+
+		if got := app.nextPollInterval(); got != PollIntervalActive {
+			t.Errorf("nextPollInterval() = %v, want %v", got, PollIntervalActive)
+		}
+	})
+
+	t.Run("fast while selected job is queued", func(t *testing.T) {
+		app := New()
+		app.runs.SetItems([]github.Run{{ID: 1, Status: "completed"}})
+		app.jobs.SetItems([]github.Job{{ID: 2, Status: "queued"}})
+
+		if got := app.nextPollInterval(); got != PollIntervalActive {
+			t.Errorf("nextPollInterval() = %v, want %v", got, PollIntervalActive)
+		}
+	})
+
+	t.Run("backs off when idle", func(t *testing.T) {
+		app := New()
+		app.runs.SetItems([]github.Run{{ID: 1, Status: "completed", Conclusion: "success"}})
+
+		want := []time.Duration{PollIntervalIdle, 2 * PollIntervalIdle, 4 * PollIntervalIdle, PollIntervalMax, PollIntervalMax}
+		for i, w := range want {
+			app.poll.interval = app.nextPollInterval()
+			if app.poll.interval != w {
+				t.Errorf("interval[%d] = %v, want %v", i, app.poll.interval, w)
+			}
+		}
+	})
+
+	t.Run("slows down when rate limit is low", func(t *testing.T) {
+		app := New(WithClient(newMockClient(&mockClientState{rateLimit: PollRateLimitThreshold - 1})))
+		app.runs.SetItems([]github.Run{{ID: 1, Status: "in_progress"}})
+
+		if got := app.nextPollInterval(); got != PollIntervalMax {
+			t.Errorf("nextPollInterval() = %v, want %v", got, PollIntervalMax)
+		}
+	})
+}
+
+func TestApp_Update_TickMsg(t *testing.T) {
+	mock := newMockClient(&mockClientState{
+		runs: []github.Run{{ID: 100, Status: "in_progress"}},
+	})
+	app := New(WithClient(mock))
+	app.workflows.SetItems([]github.Workflow{{ID: 1, Name: "CI"}})
+	app.poll.pending = true
+
+	_, cmd := app.Update(TickMsg{Time: time.Now()})
+
+	if cmd == nil {
+		t.Fatal("TickMsg should return refresh and next tick commands")
+	}
+	if !app.poll.pending {
+		t.Error("TickMsg should schedule the next tick")
+	}
+
+	scoped, ok := app.pollCmd()().(repoMsg)
+	if !ok {
+		t.Fatal("pollCmd() should tag its result with the repository")
+	}
+	if _, ok := scoped.msg.(RunsLoadedMsg); !ok {
+		t.Errorf("pollCmd() produced %T, want RunsLoadedMsg", scoped.msg)
+	}
+}
+
+func TestApp_PollCmd_LoadsWorkflowsWhenEmpty(t *testing.T) {
+	mock := newMockClient(&mockClientState{
+		workflows: []github.Workflow{{ID: 1, Name: "CI"}},
+	})
+	app := New(WithClient(mock))
+
+	scoped, ok := app.pollCmd()().(repoMsg)
+	if !ok {
+		t.Fatal("pollCmd() should tag its result with the repository")
+	}
+	if _, ok := scoped.msg.(WorkflowsLoadedMsg); !ok {
+		t.Errorf("pollCmd() produced %T, want WorkflowsLoadedMsg", scoped.msg)
+	}
+}
+
+func TestApp_Update_BlurPausesPolling(t *testing.T) {
+	app := New(WithClient(newMockClient(nil)))
+	app.workflows.SetItems([]github.Workflow{{ID: 1, Name: "CI"}})
+	app.poll.pending = true
+
+	app.Update(tea.BlurMsg{})
+	if !app.poll.paused {
+		t.Fatal("BlurMsg should pause polling")
+	}
+
+	// A tick arriving while paused ends the tick chain
+	_, cmd := app.Update(TickMsg{Time: time.Now()})
+	if cmd != nil {
+		t.Error("TickMsg while paused should not return a command")
+	}
+	if app.poll.pending {
+		t.Error("TickMsg while paused should not schedule another tick")
+	}
+
+	// Focus resumes with an immediate refresh and a new tick
+	_, cmd = app.Update(tea.FocusMsg{})
+	if cmd == nil {
+		t.Fatal("FocusMsg should return refresh and tick commands")
+	}
+	if app.poll.paused {
+		t.Error("FocusMsg should resume polling")
+	}
+	if !app.poll.pending {
+		t.Error("FocusMsg should schedule a tick")
+	}
+}
+
+func TestApp_Update_FocusWithoutBlur(t *testing.T) {
+	app := New()
+
+	_, cmd := app.Update(tea.FocusMsg{})
+	if cmd != nil {
+		t.Error("FocusMsg without prior blur should not return a command")
+	}
+}
+
+func TestApp_Update_RefreshKeepsSelectionByID(t *testing.T) {
+	app := New()
+	app.runs.SetItems([]github.Run{{ID: 100}, {ID: 101}, {ID: 102}})
+	app.runs.Select(1)
+
+	// A new run appears at the top of the list
+	app.Update(RunsLoadedMsg{Runs: []github.Run{{ID: 103}, {ID: 100}, {ID: 101}, {ID: 102}}})
+
+	run, ok := app.runs.Selected()
+	if !ok || run.ID != 101 {
+		t.Errorf("selected run = %d, want 101", run.ID)
+	}
+}
+
+func TestApp_Update_LoadMarksUpdated(t *testing.T) {
+	app := New()
+	if app.pollStatusText() != "" {
+		t.Errorf("pollStatusText() before load = %q, want empty", app.pollStatusText())
+	}
+
+	app.Update(WorkflowsLoadedMsg{Workflows: []github.Workflow{{ID: 1}}})
+
+	if app.poll.lastUpdated.IsZero() {
+		t.Error("WorkflowsLoadedMsg should record the update time")
+	}
+	if !strings.HasPrefix(app.pollStatusText(), "updated ") {
+		t.Errorf("pollStatusText() = %q, want prefix %q", app.pollStatusText(), "updated ")
+	}
+}
+
+func TestApp_PollStatusText_Paused(t *testing.T) {
+	app := New()
+	app.poll.lastUpdated = time.Now().Add(-30 * time.Second)
+	app.poll.paused = true
+
+	text := app.pollStatusText()
+	if !strings.Contains(text, "30s ago") {
+		t.Errorf("pollStatusText() = %q, want to contain %q", text, "30s ago")
+	}
+	if !strings.Contains(text, "paused") {
+		t.Errorf("pollStatusText() = %q, want to contain %q", text, "paused")
+	}
+}
+
+func TestApp_RenderStatusBar_LastUpdated(t *testing.T) {
+	app := New()
+	app.width = 200
+	app.height = 40
+	app.poll.lastUpdated = time.Now().Add(-2 * time.Minute)
+
+	bar := app.renderStatusBar()
+	if !strings.Contains(bar, "updated 2m ago") {
+		t.Errorf("renderStatusBar() = %q, want to contain %q", bar, "updated 2m ago")
+	}
+}
+
+func TestFormatAge(t *testing.T) {
+	tests := []struct {
+		d    time.Duration
+		want string
+	}{
+		{500 * time.Millisecond, "just now"},
+		{12 * time.Second, "12s ago"},
+		{3 * time.Minute, "3m ago"},
+		{2 * time.Hour, "2h ago"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := formatAge(tt.d); got != tt.want {
+				t.Errorf("formatAge(%v) = %q, want %q", tt.d, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,288 @@
+package app
+
+import (
+	"errors"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// A filter is a list of space-separated terms. A term "field:value" tests a
+// field of the items of the pane, "field:a,b" any of several values and
+// "-field:value" excludes items; date fields accept comparisons such as
+// "created:>2d" or ranges such as "created:2024-01-01..2024-01-31". Any other
+// word fuzzily matches the text of the items; see fuzzyMatch.
+
+// queryField is a field of the items of a pane that filters can test.
+// Text fields match values exactly, ignoring case, or as path.Match patterns;
+// date fields match dates, comparisons and ranges.
+type queryField[T any] struct {
+	text func(T) []string
+	date func(T) time.Time
+}
+
+// queryFields are the fields of the items of a pane, and the text that
+// bare words match
+type queryFields[T any] struct {
+	fields map[string]queryField[T]
+	text   func(T) []string
+}
+
+// names returns the sorted names of the fields
+func (f queryFields[T]) names() []string {
+	names := make([]string, 0, len(f.fields))
+	for name := range f.fields {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// queryTerm is a "field:value" term of a filter
+type queryTerm struct {
+	field  string
+	values []string // any of which matches
+	negate bool
+
+	// Bounds of a date term: [from, until), zero when open
+	from, until time.Time
+}
+
+// hasPattern reports whether a value of the term is a pattern
+func (t queryTerm) hasPattern() bool {
+	for _, v := range t.values {
+		if strings.ContainsAny(v, "*?[") {
+			return true
+		}
+	}
+	return false
+}
+
+// query is a parsed filter
+type query[T any] struct {
+	fields *queryFields[T]
+	terms  []queryTerm
+	words  []string
+}
+
+// parseQuery parses filter against the fields of a pane. Invalid terms are
+// matched as words and reported by the error, the first one only.
+func parseQuery[T any](filter string, fields *queryFields[T]) (query[T], error) {
+	q := query[T]{fields: fields}
+	var errs []error
+	for _, word := range strings.Fields(filter) {
+		term, ok, err := parseTerm(word, fields)
+		switch {
+		case err != nil:
+			errs = append(errs, err)
+			q.words = append(q.words, strings.ToLower(word))
+		case ok:
+			q.terms = append(q.terms, term)
+		default:
+			q.words = append(q.words, strings.ToLower(word))
+		}
+	}
+	if len(errs) > 0 {
+		return q, errs[0]
+	}
+	return q, nil
+}
+
+// parseTerm parses a "field:value" term. ok is false if word is not a term.
+func parseTerm[T any](word string, fields *queryFields[T]) (term queryTerm, ok bool, err error) {
+	name, value, found := strings.Cut(word, ":")
+	negate := strings.HasPrefix(name, "-")
+	name = strings.ToLower(strings.TrimPrefix(name, "-"))
+	if !found || name == "" || strings.Contains(value, "//") {
+		// Not a term, e.g. a URL
+		return queryTerm{}, false, nil
+	}
+	field, known := fields.fields[name]
+	if !known {
+		return queryTerm{}, false, errors.New("unknown field \"" + name + "\", try " + strings.Join(fields.names(), ", "))
+	}
+	if value == "" {
+		return queryTerm{}, false, errors.New("missing value after \"" + name + ":\"")
+	}
+
+	term = queryTerm{field: name, negate: negate}
+	if field.date != nil {
+		term.from, term.until, err = parseDateRange(value, time.Now())
+		if err != nil {
+			return queryTerm{}, false, errors.New(name + ": " + err.Error())
+		}
+		return term, true, nil
+	}
+	for _, v := range strings.Split(value, ",") {
+		if v != "" {
+			term.values = append(term.values, v)
+		}
+	}
+	if len(term.values) == 0 {
+		return queryTerm{}, false, errors.New("missing value after \"" + name + ":\"")
+	}
+	return term, true, nil
+}
+
+// parseDateRange parses a date, a comparison such as ">=2024-01-01" or
+// "<2d", or a range such as "2024-01-01..2024-01-31" into the bounds
+// [from, until). Durations are relative to now: "2d" is two days ago.
+func parseDateRange(value string, now time.Time) (from, until time.Time, err error) {
+	if lo, hi, ok := strings.Cut(value, ".."); ok {
+		start, _, err := parseDate(lo, now)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		end, day, err := parseDate(hi, now)
+		if err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		if day {
+			end = end.AddDate(0, 0, 1)
+		}
+		return start, end, nil
+	}
+
+	op := ""
+	for _, o := range []string{">=", "<=", ">", "<"} {
+		if strings.HasPrefix(value, o) {
+			op, value = o, strings.TrimPrefix(value, o)
+			break
+		}
+	}
+	t, day, err := parseDate(value, now)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	// The end of a day is the start of the next
+	end := t
+	if day {
+		end = t.AddDate(0, 0, 1)
+	}
+	switch op {
+	case ">":
+		return end, time.Time{}, nil
+	case ">=":
+		return t, time.Time{}, nil
+	case "<":
+		return time.Time{}, t, nil
+	case "<=":
+		return time.Time{}, end, nil
+	}
+	if !day {
+		return time.Time{}, time.Time{}, errors.New("\"" + value + "\" needs a comparison, e.g. >" + value)
+	}
+	return t, end, nil
+}
+
+// dateUnits are the units of relative dates
+var dateUnits = map[byte]time.Duration{
+	'm': time.Minute,
+	'h': time.Hour,
+	'd': 24 * time.Hour,
+	'w': 7 * 24 * time.Hour,
+}
+
+// parseDate parses a date ("2024-01-31") or a duration before now ("2d").
+// day reports whether value is a whole day.
+func parseDate(value string, now time.Time) (t time.Time, day bool, err error) {
+	if t, err := time.ParseInLocation(time.DateOnly, value, time.Local); err == nil {
+		return t, true, nil
+	}
+	if len(value) > 1 {
+		if unit, ok := dateUnits[value[len(value)-1]]; ok {
+			if n, err := strconv.Atoi(value[:len(value)-1]); err == nil && n >= 0 {
+				// Round so that the same filter yields the same bounds for a while
+				return now.Add(-time.Duration(n) * unit).Truncate(time.Minute), false, nil
+			}
+		}
+	}
+	return time.Time{}, false, errors.New("invalid date \"" + value + "\", use YYYY-MM-DD or a duration such as 2d")
+}
+
+// match reports whether item matches every term and word of the query
+func (q query[T]) match(item T) bool {
+	_, ok := q.score(item)
+	return ok
+}
+
+// score reports whether item matches every term and word of the query, and
+// scores how well the words match; see fuzzyMatch
+func (q query[T]) score(item T) (int, bool) {
+	for _, term := range q.terms {
+		if q.matchTerm(item, term) == term.negate {
+			return 0, false
+		}
+	}
+	if len(q.words) == 0 {
+		return 0, true
+	}
+	texts := q.fields.text(item)
+	total := 0
+	for _, w := range q.words {
+		best, found := 0, false
+		for _, text := range texts {
+			if s, _, ok := fuzzyMatch(w, text); ok && (!found || s > best) {
+				best, found = s, true
+			}
+		}
+		if !found {
+			return 0, false
+		}
+		total += best
+	}
+	return total, true
+}
+
+// matchTerm reports whether the field of item matches the term
+func (q query[T]) matchTerm(item T, term queryTerm) bool {
+	field := q.fields.fields[term.field]
+	if field.date != nil {
+		t := field.date(item)
+		return (term.from.IsZero() || !t.Before(term.from)) &&
+			(term.until.IsZero() || t.Before(term.until))
+	}
+	for _, got := range field.text(item) {
+		for _, want := range term.values {
+			if strings.EqualFold(got, want) {
+				return true
+			}
+			if ok, _ := path.Match(strings.ToLower(want), strings.ToLower(got)); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// queryScorer returns the score function of a FilteredList that filters
+// and ranks its items with the query language. The parsed filter is cached,
+// since FilteredList calls the function with the same filter for every item.
+func queryScorer[T any](fields *queryFields[T]) func(T, string) (int, bool) {
+	var (
+		last string
+		q    query[T]
+	)
+	return func(item T, filter string) (int, bool) {
+		if q.fields == nil || filter != last {
+			last = filter
+			q, _ = parseQuery(filter, fields)
+		}
+		return q.score(item)
+	}
+}
+
+// filterWords returns the words of a filter that are not field terms,
+// which are highlighted in the items they match
+func filterWords(filter string) []string {
+	var words []string
+	for _, word := range strings.Fields(filter) {
+		if name, _, ok := strings.Cut(word, ":"); ok && name != "" {
+			continue
+		}
+		words = append(words, word)
+	}
+	return words
+}
@@ -0,0 +1,181 @@
+package app
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nnnkkk7/lazyactions/github"
+)
+
+func TestQuery_MatchRuns(t *testing.T) {
+	now := time.Now()
+	run := github.Run{
+		Name:       "CI",
+		Status:     "completed",
+		Conclusion: "failure",
+		Branch:     "release/1.2",
+		Actor:      "Octocat",
+		Event:      "pull_request",
+		HeadSHA:    "0123456789abcdef0123456789abcdef01234567",
+		CreatedAt:  now.Add(-time.Hour),
+	}
+	tests := []struct {
+		filter string
+		want   bool
+	}{
+		{"", true},
+		{"octo", true},
+		{"release octo", true},
+		{"main", false},
+		{"status:failure", true},
+		{"status:completed", true},
+		{"conclusion:completed", false},
+		{"conclusion:cancelled,failure", true},
+		{"conclusion:cancelled,success", false},
+		{"branch:release/*", true},
+		{"branch:release", false},
+		{"actor:octocat", true},
+		{"-actor:octocat", false},
+		{"-actor:dependabot", true},
+		{"event:pull_request status:failure", true},
+		{"event:push status:failure", false},
+		{"sha:0123456", true},
+		{"created:>2d", true},
+		{"created:<2d", false},
+		{"created:>30m", false},
+		{"created:" + now.Format(time.DateOnly), now.Add(-time.Hour).Day() == now.Day()},
+		{"created:2000-01-01..2000-12-31", false},
+	}
+	bot := run
+	bot.Actor = "dependabot[bot]"
+	for _, tt := range []struct {
+		filter string
+		want   bool
+	}{
+		{"actor:dependabot", true},
+		{"actor:dependabot[bot]", true},
+		{"-actor:dependabot", false},
+		{"actor:depend*", true},
+	} {
+		q, _ := parseQuery(tt.filter, runFields)
+		if got := q.match(bot); got != tt.want {
+			t.Errorf("match(%q) of dependabot[bot] = %v, want %v", tt.filter, got, tt.want)
+		}
+	}
+
+	for _, tt := range tests {
+		q, err := parseQuery(tt.filter, runFields)
+		if err != nil {
+			t.Errorf("parseQuery(%q) error = %v", tt.filter, err)
+			continue
+		}
+		if got := q.match(run); got != tt.want {
+			t.Errorf("match(%q) = %v, want %v", tt.filter, got, tt.want)
+		}
+	}
+}
+
+func TestParseQuery_Errors(t *testing.T) {
+	tests := []struct {
+		filter string
+		want   string
+	}{
+		{"foo:bar", `unknown field "foo"`},
+		{"status:", `missing value after "status:"`},
+		{"status:,", `missing value after "status:"`},
+		{"created:yesterday", `invalid date "yesterday"`},
+		{"created:2d", `needs a comparison`},
+	}
+	for _, tt := range tests {
+		_, err := parseQuery(tt.filter, runFields)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("parseQuery(%q) error = %v, want %q", tt.filter, err, tt.want)
+		}
+	}
+
+	// Not terms
+	for _, filter := range []string{"fix", "https://github.com", "-", ":x"} {
+		if _, err := parseQuery(filter, runFields); err != nil {
+			t.Errorf("parseQuery(%q) error = %v", filter, err)
+		}
+	}
+
+	// Fields are typed per pane
+	if _, err := parseQuery("branch:main", jobFields); err == nil {
+		t.Error("jobs have no branch field")
+	}
+}
+
+func TestParseDateRange(t *testing.T) {
+	now := time.Date(2024, 6, 15, 12, 0, 0, 0, time.Local)
+	day := func(d int) time.Time { return time.Date(2024, 6, d, 0, 0, 0, 0, time.Local) }
+	tests := []struct {
+		value       string
+		from, until time.Time
+	}{
+		{"2024-06-10", day(10), day(11)},
+		{">2024-06-10", day(11), time.Time{}},
+		{">=2024-06-10", day(10), time.Time{}},
+		{"<2024-06-10", time.Time{}, day(10)},
+		{"<=2024-06-10", time.Time{}, day(11)},
+		{"2024-06-01..2024-06-10", day(1), day(11)},
+		{">2d", now.Add(-48 * time.Hour), time.Time{}},
+		{"<1w", time.Time{}, now.Add(-7 * 24 * time.Hour)},
+	}
+	for _, tt := range tests {
+		from, until, err := parseDateRange(tt.value, now)
+		if err != nil {
+			t.Errorf("parseDateRange(%q) error = %v", tt.value, err)
+			continue
+		}
+		if !from.Equal(tt.from) || !until.Equal(tt.until) {
+			t.Errorf("parseDateRange(%q) = [%v, %v), want [%v, %v)", tt.value, from, until, tt.from, tt.until)
+		}
+	}
+}
+
+func TestQuery_Panes(t *testing.T) {
+	app := New()
+	app.workflows.SetItems([]github.Workflow{
+		{ID: 1, Name: "CI", Path: ".github/workflows/ci.yml", State: "active"},
+		{ID: 2, Name: "Deploy", Path: ".github/workflows/deploy.yml", State: "disabled_manually"},
+	})
+	app.workflows.SetFilter("file:ci.yml")
+	if wf, _ := app.workflows.Selected(); app.workflows.Len() != 1 || wf.ID != 1 {
+		t.Errorf("file:ci.yml = %+v, want CI", app.workflows.Items())
+	}
+	app.workflows.SetFilter("-state:active")
+	if wf, _ := app.workflows.Selected(); app.workflows.Len() != 1 || wf.ID != 2 {
+		t.Errorf("-state:active = %+v, want Deploy", app.workflows.Items())
+	}
+
+	app.jobs.SetItems([]github.Job{
+		{ID: 1, Name: "build", Status: "completed", Conclusion: "success"},
+		{ID: 2, Name: "test", Status: "completed", Conclusion: "failure"},
+		{ID: 3, Name: "lint", Status: "in_progress"},
+	})
+	app.jobs.SetFilter("status:failure,in_progress")
+	if app.jobs.Len() != 2 {
+		t.Errorf("status:failure,in_progress = %+v, want test and lint", app.jobs.Items())
+	}
+}
+
+func TestApp_FilterErrorHint(t *testing.T) {
+	app := New()
+	app.width, app.height = 120, 40
+	app.focusedPane = JobsPane
+	app.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+	app.filterInput.SetValue("branch:main")
+
+	view := app.View()
+	if !strings.Contains(view, `unknown field "branch"`) {
+		t.Errorf("view should show the syntax error:\n%s", view)
+	}
+
+	app.filterInput.SetValue("status:failure")
+	if strings.Contains(app.View(), "unknown field") {
+		t.Error("a valid filter should show no hint")
+	}
+}
@@ -0,0 +1,225 @@
+package app
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/nnnkkk7/lazyactions/github"
+)
+
+// Ref picker constants
+const (
+	// RefPickerMaxWidth is the maximum width of the ref picker dialog
+	RefPickerMaxWidth = 60
+	// RefPickerHeight is the number of refs visible at once in the ref picker
+	RefPickerHeight = 10
+	// RefInputCharLimit is the maximum characters for the ref input
+	RefInputCharLimit = 255
+)
+
+// refKind describes where a ref offered by the picker comes from
+type refKind int
+
+const (
+	refLastUsed refKind = iota
+	refDefault
+	refLocal
+	refBranch
+	refTag
+	refCustom
+)
+
+// label returns the annotation shown next to a ref of this kind
+func (k refKind) label() string {
+	switch k {
+	case refLastUsed:
+		return "last used"
+	case refDefault:
+		return "default"
+	case refLocal:
+		return "local"
+	case refTag:
+		return "tag"
+	case refCustom:
+		return "use as ref"
+	default:
+		return ""
+	}
+}
+
+// refItem is a single entry of the ref picker
+type refItem struct {
+	name string
+	kind refKind
+}
+
+// refPicker is the modal used to choose the ref a workflow is dispatched on.
+// Typing filters the known refs; text that matches no ref exactly is offered
+// as a free-form ref so any branch, tag or SHA can be used.
+type refPicker struct {
+	workflow github.Workflow
+	input    textinput.Model
+	list     *FilteredList[refItem]
+
+	lastUsed    string
+	localBranch string
+	refs        []refItem // known refs, without the free-form entry
+
+	loading bool
+	moved   bool // whether the user changed the selection
+	err     string
+}
+
+// newRefPicker creates a picker for wf. The last used ref and the local branch
+// are offered right away; remote refs are added by setRefs once loaded.
+func newRefPicker(wf github.Workflow, lastUsed, localBranch string) *refPicker {
+	ti := textinput.New()
+	ti.Placeholder = "branch, tag or SHA"
+	ti.CharLimit = RefInputCharLimit
+	ti.Prompt = "> "
+	ti.Focus()
+
+	p := &refPicker{
+		workflow:    wf,
+		input:       ti,
+		lastUsed:    lastUsed,
+		localBranch: localBranch,
+		loading:     true,
+		list: NewFilteredList(func(r refItem, filter string) bool {
+			return strings.Contains(strings.ToLower(r.name), strings.ToLower(filter))
+		}),
+	}
+	p.list.SetVisibleHeight(RefPickerHeight)
+	p.setRefs("", nil, nil)
+	return p
+}
+
+// setRefs replaces the known refs. Refs are ordered last used, default branch,
+// local branch, remote branches, then tags; a name is listed only once.
+func (p *refPicker) setRefs(defaultBranch string, branches, tags []string) {
+	selected, hadSelection := p.list.Selected()
+
+	seen := make(map[string]bool)
+	p.refs = make([]refItem, 0, len(branches)+len(tags)+3)
+	add := func(name string, kind refKind) {
+		if name == "" || seen[name] {
+			return
+		}
+		seen[name] = true
+		p.refs = append(p.refs, refItem{name: name, kind: kind})
+	}
+
+	add(p.lastUsed, refLastUsed)
+	add(defaultBranch, refDefault)
+	add(p.localBranch, refLocal)
+	for _, b := range branches {
+		add(b, refBranch)
+	}
+	for _, t := range tags {
+		add(t, refTag)
+	}
+
+	p.applyFilter()
+	if p.moved && hadSelection {
+		p.list.SelectMatching(func(r refItem) bool { return r.name == selected.name })
+	} else {
+		p.list.Select(0)
+	}
+}
+
+// applyFilter filters the refs by the typed text and appends the text itself
+// as a free-form ref unless it names a known ref exactly
+func (p *refPicker) applyFilter() {
+	filter := strings.TrimSpace(p.input.Value())
+
+	items := p.refs
+	if filter != "" && !p.hasRef(filter) {
+		items = append(append([]refItem{}, p.refs...), refItem{name: filter, kind: refCustom})
+	}
+	p.list.SetItems(items)
+	p.list.SetFilter(filter)
+}
+
+// hasRef returns true if name is one of the known refs
+func (p *refPicker) hasRef(name string) bool {
+	for _, r := range p.refs {
+		if r.name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// selected returns the ref to dispatch on
+func (p *refPicker) selected() (string, bool) {
+	r, ok := p.list.Selected()
+	if !ok {
+		return "", false
+	}
+	return r.name, true
+}
+
+// update handles a key press that is not a picker-level command
+func (p *refPicker) update(msg tea.KeyMsg) tea.Cmd {
+	switch msg.String() {
+	case "up", "ctrl+p":
+		p.list.SelectPrev()
+		p.moved = true
+		return nil
+	case "down", "ctrl+n":
+		p.list.SelectNext()
+		p.moved = true
+		return nil
+	}
+
+	var cmd tea.Cmd
+	p.input, cmd = p.input.Update(msg)
+	p.applyFilter()
+	return cmd
+}
+
+// view renders the picker body
+func (p *refPicker) view(width int) string {
+	var b strings.Builder
+	b.WriteString(lipgloss.NewStyle().Bold(true).Render("Run workflow: " + p.workflow.Name))
+	b.WriteString("\n")
+	b.WriteString(theme.Queued.Render("Select a ref to run on"))
+	b.WriteString("\n\n")
+	b.WriteString(p.input.View())
+	b.WriteString("\n\n")
+
+	items := p.list.VisibleItems()
+	offset := p.list.ScrollOffset()
+	selectedIdx := p.list.SelectedIndex()
+	for i, r := range items {
+		label := r.kind.label()
+		name := truncateString(r.name, width-lipgloss.Width(label)-3)
+		gap := width - lipgloss.Width(name) - lipgloss.Width(label) - 2
+		if gap < 1 {
+			gap = 1
+		}
+		if offset+i == selectedIdx {
+			b.WriteString(theme.Cursor.Render(">") + theme.SelectedItemFocused.Render(" "+name))
+		} else {
+			b.WriteString(theme.NormalItem.Render("  " + name))
+		}
+		b.WriteString(strings.Repeat(" ", gap) + theme.Queued.Render(label) + "\n")
+	}
+	if len(items) == 0 {
+		b.WriteString(theme.Queued.Render("  No matching refs") + "\n")
+	}
+	for i := max(len(items), 1); i < RefPickerHeight; i++ {
+		b.WriteString("\n")
+	}
+
+	if p.loading {
+		b.WriteString("\n" + theme.Queued.Render("Loading branches and tags...") + "\n")
+	}
+	if p.err != "" {
+		b.WriteString("\n" + theme.Failure.Render(truncateString(p.err, width)) + "\n")
+	}
+	b.WriteString("\n[↑/↓] select  [Enter] run on ref  [Esc] cancel")
+	return b.String()
+}
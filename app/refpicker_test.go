@@ -0,0 +1,191 @@
+package app
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nnnkkk7/lazyactions/github"
+	"github.com/nnnkkk7/lazyactions/state"
+)
+
+func refNames(p *refPicker) []string {
+	items := p.list.Items()
+	names := make([]string, 0, len(items))
+	for _, r := range items {
+		names = append(names, r.name)
+	}
+	return names
+}
+
+func typeRef(p *refPicker, s string) {
+	for _, r := range s {
+		p.update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+}
+
+func TestRefPicker_SetRefs_OrderAndDedupe(t *testing.T) {
+	p := newRefPicker(github.Workflow{Name: "CI"}, "release", "feature/x")
+	p.setRefs("master", []string{"master", "feature/x", "release", "develop"}, []string{"v1.0.0"})
+
+	got := strings.Join(refNames(p), ",")
+	want := "release,master,feature/x,develop,v1.0.0"
+	if got != want {
+		t.Errorf("refs = %s, want %s", got, want)
+	}
+	if p.list.Items()[1].kind != refDefault || p.list.Items()[4].kind != refTag {
+		t.Errorf("unexpected kinds: %+v", p.list.Items())
+	}
+	if ref, _ := p.selected(); ref != "release" {
+		t.Errorf("selected = %q, want last used ref", ref)
+	}
+}
+
+func TestRefPicker_SelectsDefaultBranchWithoutLastUsed(t *testing.T) {
+	p := newRefPicker(github.Workflow{}, "", "feature/x")
+	if ref, _ := p.selected(); ref != "feature/x" {
+		t.Errorf("selected while loading = %q, want local branch", ref)
+	}
+
+	p.setRefs("develop", []string{"develop", "feature/x"}, nil)
+	if ref, _ := p.selected(); ref != "develop" {
+		t.Errorf("selected = %q, want default branch", ref)
+	}
+}
+
+func TestRefPicker_KeepsMovedSelectionOnLoad(t *testing.T) {
+	p := newRefPicker(github.Workflow{}, "release", "feature/x")
+	p.update(tea.KeyMsg{Type: tea.KeyDown})
+
+	p.setRefs("main", []string{"main", "feature/x"}, nil)
+	if ref, _ := p.selected(); ref != "feature/x" {
+		t.Errorf("selected = %q, want feature/x to stay selected", ref)
+	}
+}
+
+func TestRefPicker_Filter(t *testing.T) {
+	p := newRefPicker(github.Workflow{}, "", "")
+	p.setRefs("main", []string{"main", "feature/login", "feature/logout"}, []string{"v1.0.0"})
+
+	typeRef(p, "LOG")
+	if got := strings.Join(refNames(p), ","); got != "feature/login,feature/logout,LOG" {
+		t.Errorf("filtered refs = %s", got)
+	}
+	if ref, _ := p.selected(); ref != "feature/login" {
+		t.Errorf("selected = %q, want first match", ref)
+	}
+}
+
+func TestRefPicker_FreeTextRef(t *testing.T) {
+	p := newRefPicker(github.Workflow{}, "", "")
+	p.setRefs("main", []string{"main"}, nil)
+
+	typeRef(p, "0a1b2c3")
+	items := p.list.Items()
+	if len(items) != 1 || items[0].kind != refCustom {
+		t.Fatalf("items = %+v, want single free-form ref", items)
+	}
+	if ref, _ := p.selected(); ref != "0a1b2c3" {
+		t.Errorf("selected = %q, want typed SHA", ref)
+	}
+
+	// An exact match is not offered twice
+	p.input.SetValue("main")
+	p.applyFilter()
+	if got := refNames(p); len(got) != 1 || got[0] != "main" {
+		t.Errorf("refs = %v, want only the known ref", got)
+	}
+}
+
+func TestApp_TriggerWorkflow_RefPicker(t *testing.T) {
+	mock := newMockClient(&mockClientState{
+		fileContent:   "on: workflow_dispatch\n",
+		defaultBranch: "master",
+		branches:      []string{"master", "develop"},
+		tags:          []string{"v1.0.0"},
+	})
+	st := state.New()
+	app := New(WithClient(mock), WithState(st), WithRepository(github.Repository{Owner: "o", Name: "r"}))
+	app.width = 100
+	app.height = 40
+	wf := github.Workflow{ID: 1, Name: "CI", Path: ".github/workflows/ci.yml"}
+	app.workflows.SetItems([]github.Workflow{wf})
+
+	cmd := app.triggerWorkflow()
+	if app.refPicker == nil {
+		t.Fatal("triggerWorkflow() should open the ref picker")
+	}
+	app.Update(cmd())
+	if view := app.View(); !strings.Contains(view, "master") || !strings.Contains(view, "default") {
+		t.Error("View() should render the default branch in the ref picker")
+	}
+
+	app.handleKeyPress(tea.KeyMsg{Type: tea.KeyDown})
+	cmd = app.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	if app.refPicker != nil {
+		t.Error("picker should close after selecting a ref")
+	}
+	_, cmd = app.Update(cmd())
+	cmd()
+
+	if calls := mock.TriggerWorkflowCalls(); len(calls) != 1 || calls[0].Ref != "develop" {
+		t.Fatalf("TriggerWorkflow calls = %+v, want ref develop", calls)
+	}
+	if got := st.LastRef("o/r:.github/workflows/ci.yml"); got != "develop" {
+		t.Errorf("last ref = %q, want develop", got)
+	}
+
+	// The next trigger starts on the remembered ref
+	app.triggerWorkflow()
+	if ref, _ := app.refPicker.selected(); ref != "develop" {
+		t.Errorf("selected = %q, want remembered ref", ref)
+	}
+}
+
+func TestApp_RefsLoaded_Error(t *testing.T) {
+	app := New(WithClient(newMockClient(&mockClientState{err: errAPI})), WithLocalBranch("feature/x"))
+	app.workflows.SetItems([]github.Workflow{{ID: 1, Name: "CI"}})
+
+	app.Update(app.triggerWorkflow()())
+	if app.refPicker == nil {
+		t.Fatal("picker should stay open when refs fail to load")
+	}
+	if app.refPicker.loading || app.refPicker.err == "" {
+		t.Error("picker should show the load error")
+	}
+	if ref, _ := app.refPicker.selected(); ref != "feature/x" {
+		t.Errorf("selected = %q, want local branch", ref)
+	}
+	if app.err != nil {
+		t.Errorf("err = %v, want nil", app.err)
+	}
+}
+
+func TestApp_RefsLoaded_TagsError(t *testing.T) {
+	mock := newMockClient(&mockClientState{defaultBranch: "main", branches: []string{"main", "develop"}})
+	mock.ListTagsFunc = func(ctx context.Context, repo github.Repository) ([]string, error) {
+		return nil, errAPI
+	}
+	app := New(WithClient(mock))
+	app.workflows.SetItems([]github.Workflow{{ID: 1, Name: "CI"}})
+
+	app.Update(app.triggerWorkflow()())
+	if !strings.HasPrefix(app.refPicker.err, "Failed to load tags") {
+		t.Errorf("err = %q, want the tag error", app.refPicker.err)
+	}
+	if names, want := refNames(app.refPicker), []string{"main", "develop"}; !slices.Equal(names, want) {
+		t.Errorf("refs = %v, want the branches %v", names, want)
+	}
+}
+
+func TestApp_RefPicker_Escape(t *testing.T) {
+	app := New()
+	app.refPicker = newRefPicker(github.Workflow{}, "", "")
+
+	app.handleKeyPress(tea.KeyMsg{Type: tea.KeyEsc})
+	if app.refPicker != nil {
+		t.Error("Esc should close the ref picker")
+	}
+}
@@ -0,0 +1,108 @@
+package app
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/nnnkkk7/lazyactions/github"
+	"github.com/nnnkkk7/lazyactions/repo"
+)
+
+// Remote switcher constants
+const (
+	// RemoteSwitcherMaxWidth is the maximum width of the remote switcher dialog
+	RemoteSwitcherMaxWidth = 60
+	// RemoteSwitcherHeight is the number of remotes visible at once in the remote switcher
+	RemoteSwitcherHeight = 8
+)
+
+// remoteSwitcher is the modal used to point the app at another git remote
+type remoteSwitcher struct {
+	list    *FilteredList[repo.Remote]
+	current string // name of the remote in use
+}
+
+// newRemoteSwitcher creates a switcher with the remote in use selected
+func newRemoteSwitcher(remotes []repo.Remote, current string) *remoteSwitcher {
+	s := &remoteSwitcher{
+		list: NewFilteredList(func(repo.Remote, string) bool {
+			return true
+		}),
+		current: current,
+	}
+	s.list.SetVisibleHeight(RemoteSwitcherHeight)
+	s.list.SetItems(remotes)
+	s.list.SelectMatching(func(r repo.Remote) bool { return r.Name == current })
+	return s
+}
+
+// view renders the switcher body
+func (s *remoteSwitcher) view(width int) string {
+	var b strings.Builder
+	b.WriteString(lipgloss.NewStyle().Bold(true).Render("Switch remote"))
+	b.WriteString("\n\n")
+
+	items := s.list.VisibleItems()
+	offset := s.list.ScrollOffset()
+	selectedIdx := s.list.SelectedIndex()
+	for i, r := range items {
+		label := r.Repo.FullName()
+		if r.Name == s.current {
+			label += " (current)"
+		}
+		name := truncateString(r.Name, width-lipgloss.Width(label)-3)
+		gap := max(width-lipgloss.Width(name)-lipgloss.Width(label)-2, 1)
+		if offset+i == selectedIdx {
+			b.WriteString(theme.Cursor.Render(">") + theme.SelectedItemFocused.Render(" "+name))
+		} else {
+			b.WriteString(theme.NormalItem.Render("  " + name))
+		}
+		b.WriteString(strings.Repeat(" ", gap) + theme.Queued.Render(label) + "\n")
+	}
+
+	b.WriteString("\n[↑/↓] select  [Enter] switch  [Esc] cancel")
+	return b.String()
+}
+
+// openRemoteSwitcher shows the remote switcher if there is another remote to switch to
+func (a *App) openRemoteSwitcher() tea.Cmd {
+	if len(a.remotes) < 2 {
+		return flashMessage("No other GitHub remotes", FlashDurationInfo)
+	}
+	a.remoteSwitcher = newRemoteSwitcher(a.remotes, a.remote)
+	return nil
+}
+
+// switchRemote re-points the app at the repository of r and reloads everything
+func (a *App) switchRemote(r repo.Remote) tea.Cmd {
+	a.remoteSwitcher = nil
+	if r.Name == a.remote && r.Repo == a.repo {
+		return nil
+	}
+
+	a.remote = r.Name
+
+	return tea.Batch(
+		flashMessage("Switched to "+r.Name+" ("+r.Repo.FullName()+")", FlashDurationSuccess),
+		a.switchRepository(r.Repo),
+	)
+}
+
+// repoMsg tags the result of a data load with the repository it was loaded
+// from, and whether it was loaded for the dashboard
+type repoMsg struct {
+	repo      github.Repository
+	dashboard bool
+	msg       tea.Msg
+}
+
+// forView wraps cmd so that its result is dropped if the app switched to
+// another repository, or opened or closed the dashboard, while the request
+// was in flight
+func (a *App) forView(cmd tea.Cmd) tea.Cmd {
+	repo, dashboard := a.repo, a.dashboard != nil
+	return func() tea.Msg {
+		return repoMsg{repo: repo, dashboard: dashboard, msg: cmd()}
+	}
+}
@@ -0,0 +1,120 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nnnkkk7/lazyactions/github"
+	"github.com/nnnkkk7/lazyactions/repo"
+)
+
+var (
+	forkRemote     = repo.Remote{Name: "origin", Repo: github.Repository{Host: "github.com", Owner: "me", Name: "project"}}
+	upstreamRemote = repo.Remote{Name: "upstream", Repo: github.Repository{Host: "github.com", Owner: "org", Name: "project"}}
+)
+
+func newRemoteTestApp(remotes []repo.Remote) (*App, *github.MockClient) {
+	mock := newMockClient(&mockClientState{
+		workflows: []github.Workflow{{ID: 1, Name: "CI"}},
+	})
+	app := New(
+		WithClient(mock),
+		WithRepository(upstreamRemote.Repo),
+		WithRemotes(remotes, upstreamRemote.Name),
+	)
+	app.width, app.height = 120, 40
+	return app, mock
+}
+
+func TestApp_OpenRemoteSwitcher(t *testing.T) {
+	t.Run("selects the current remote", func(t *testing.T) {
+		app, _ := newRemoteTestApp([]repo.Remote{forkRemote, upstreamRemote})
+		app.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'o'}})
+
+		if app.remoteSwitcher == nil {
+			t.Fatal("o should open the remote switcher")
+		}
+		if r, _ := app.remoteSwitcher.list.Selected(); r.Name != "upstream" {
+			t.Errorf("selected = %q, want the current remote", r.Name)
+		}
+		view := app.View()
+		if !strings.Contains(view, "org/project (current)") || !strings.Contains(view, "me/project") {
+			t.Errorf("switcher should list the remotes:\n%s", view)
+		}
+	})
+
+	t.Run("single remote", func(t *testing.T) {
+		app, _ := newRemoteTestApp([]repo.Remote{upstreamRemote})
+		cmd := app.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'o'}})
+
+		if app.remoteSwitcher != nil {
+			t.Error("switcher should not open without another remote")
+		}
+		if cmd == nil {
+			t.Error("should flash a message")
+		}
+	})
+}
+
+func TestApp_RemoteSwitcher_Esc(t *testing.T) {
+	app, _ := newRemoteTestApp([]repo.Remote{forkRemote, upstreamRemote})
+	app.openRemoteSwitcher()
+	app.handleKeyPress(tea.KeyMsg{Type: tea.KeyEsc})
+
+	if app.remoteSwitcher != nil {
+		t.Error("esc should close the switcher")
+	}
+	if app.repo != upstreamRemote.Repo {
+		t.Errorf("repo = %+v, should be unchanged", app.repo)
+	}
+}
+
+func TestApp_SwitchRemote(t *testing.T) {
+	app, mock := newRemoteTestApp([]repo.Remote{forkRemote, upstreamRemote})
+	app.workflows.SetItems([]github.Workflow{{ID: 9, Name: "Old"}})
+	app.runs.SetItems([]github.Run{{ID: 99}})
+	app.jobs.SetItems([]github.Job{{ID: 999}})
+	app.err = errAPI
+
+	app.openRemoteSwitcher()
+	app.handleKeyPress(tea.KeyMsg{Type: tea.KeyUp})
+	cmd := app.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+
+	if app.remoteSwitcher != nil {
+		t.Error("enter should close the switcher")
+	}
+	if app.repo != forkRemote.Repo || app.remote != "origin" {
+		t.Fatalf("repo = %+v (%s), want the fork", app.repo, app.remote)
+	}
+	if app.workflows.Len() != 0 || app.runs.Len() != 0 || app.jobs.Len() != 0 {
+		t.Error("data of the previous repository should be cleared")
+	}
+	if app.err != nil {
+		t.Errorf("err = %v, should be cleared", app.err)
+	}
+	if cmd == nil {
+		t.Fatal("switching should reload the workflows")
+	}
+
+	app.Update(app.fetchWorkflowsCmd()())
+	calls := mock.ListWorkflowsCalls()
+	if len(calls) == 0 || calls[len(calls)-1].Repo != forkRemote.Repo {
+		t.Errorf("workflows should be loaded from the fork, calls = %+v", calls)
+	}
+	if app.workflows.Len() != 1 {
+		t.Errorf("workflows = %d, want 1", app.workflows.Len())
+	}
+}
+
+func TestApp_DropsResultsOfPreviousRepository(t *testing.T) {
+	app, _ := newRemoteTestApp([]repo.Remote{forkRemote, upstreamRemote})
+	stale := app.fetchWorkflowsCmd()
+
+	app.switchRemote(forkRemote)
+	app.Update(stale())
+
+	if app.workflows.Len() != 0 {
+		t.Error("workflows loaded for the previous repository should be dropped")
+	}
+}
@@ -1,23 +1,75 @@
 package app
 
 import (
+	"slices"
 	"strconv"
 	"strings"
+	"unicode/utf8"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/nnnkkk7/lazyactions/github"
 )
 
 // Rendering helpers - build panels for lazygit-style layout
 
+// buildLeftPanel builds a panel of the left sidebar
+func (a *App) buildLeftPanel(pane Pane, width, height int) []string {
+	switch pane {
+	case ReposPane:
+		return a.buildReposPanel(width, height)
+	case WorkflowsPane:
+		return a.buildWorkflowsPanel(width, height)
+	case RunsPane:
+		return a.buildRunsPanel(width, height)
+	default:
+		return a.buildJobsPanel(width, height)
+	}
+}
+
+// buildReposPanel builds the repositories panel of a workspace,
+// with the status of the latest run of each repository
+func (a *App) buildReposPanel(width, height int) []string {
+	focused := a.focusedPane == ReposPane
+	borderStyle := getPanelBorderStyle(focused)
+	title := renderPanelTitle("Repositories", focused)
+
+	// Set visible height so scroll offset is maintained
+	contentHeight := height - BorderWidth
+	a.repos.SetVisibleHeight(contentHeight)
+
+	scrollOffset := a.repos.ScrollOffset()
+
+	// Build content
+	var content []string
+	items := a.repos.VisibleItems()
+	words := filterWords(a.repos.Filter())
+	if a.repos.Len() == 0 {
+		content = append(content, "  No matching repositories")
+	} else {
+		for i, r := range items {
+			realIdx := scrollOffset + i
+			selected := realIdx == a.repos.SelectedIndex()
+			hovered := a.itemHovered(ReposPane, i)
+			run := a.repoRuns[r]
+			icon := StatusIcon(run.Status, run.Conclusion)
+			line := icon + " " + truncateString(r.FullName(), width-ItemPaddingMedium)
+			content = append(content, a.renderListItem(line, matchPositions(line, words), selected, focused, hovered))
+		}
+	}
+
+	return renderPanelFrame(width, height, title, content, borderStyle)
+}
+
 // buildWorkflowsPanel builds the workflows panel for the left sidebar
 func (a *App) buildWorkflowsPanel(width, height int) []string {
 	focused := a.focusedPane == WorkflowsPane
 	borderStyle := getPanelBorderStyle(focused)
 
 	// Title with spinner when loading
-	titleText := "Workflows"
+	titleText := "Workflows" + truncationText(a.workflows)
 	if a.loading {
-		titleText = "Workflows " + a.spinner.View()
+		titleText += " " + a.spinner.View()
 	}
 	title := renderPanelTitle(titleText, focused)
 
@@ -26,10 +78,10 @@ func (a *App) buildWorkflowsPanel(width, height int) []string {
 	a.workflows.SetVisibleHeight(contentHeight)
 
 	// Build content
-	leftWidth := a.leftPanelWidth()
 	scrollOffset := a.workflows.ScrollOffset()
 	var content []string
 	items := a.workflows.VisibleItems()
+	words := filterWords(a.workflows.Filter())
 	if a.workflows.Len() == 0 {
 		if a.loading {
 			content = append(content, "  Loading...")
@@ -40,9 +92,9 @@ func (a *App) buildWorkflowsPanel(width, height int) []string {
 		for i, wf := range items {
 			realIdx := scrollOffset + i
 			selected := realIdx == a.workflows.SelectedIndex()
-			hovered := a.mouseX < leftWidth && a.mouseY == i+BorderOffset
+			hovered := a.itemHovered(WorkflowsPane, i)
 			name := truncateString(wf.Name, width-ItemPaddingSmall)
-			content = append(content, a.renderListItem(name, selected, focused, hovered))
+			content = append(content, a.renderListItem(name, matchPositions(name, words), selected, focused, hovered))
 		}
 	}
 
@@ -53,31 +105,53 @@ func (a *App) buildWorkflowsPanel(width, height int) []string {
 func (a *App) buildRunsPanel(width, height int) []string {
 	focused := a.focusedPane == RunsPane
 	borderStyle := getPanelBorderStyle(focused)
-	title := renderPanelTitle("Runs", focused)
+	titleText, emptyText := "Runs"+truncationText(a.runs), "  Select workflow"
+	repoWidth := 0
+	if a.dashboard != nil {
+		titleText, emptyText = a.dashboardTitle(), "  No runs in progress or failed"
+		repoWidth = a.dashboardRepoColumnWidth()
+	}
+	// Spinner while the next page of runs loads
+	if a.runHistory.loadingMore {
+		titleText += " " + a.spinner.View()
+	}
+	title := renderPanelTitle(titleText, focused)
 
 	// Set visible height so scroll offset is maintained
 	contentHeight := height - BorderWidth
 	a.runs.SetVisibleHeight(contentHeight)
 
-	// Calculate panel position for hover detection
-	leftWidth := a.leftPanelWidth()
-	panelStartY := a.panelStartY(RunsPane)
 	scrollOffset := a.runs.ScrollOffset()
 
 	// Build content
 	var content []string
+	narrow := width < NarrowPanelWidth
 	items := a.runs.VisibleItems()
+	words := filterWords(a.runs.Filter())
 	if a.runs.Len() == 0 {
-		content = append(content, "  Select workflow")
+		content = append(content, emptyText)
 	} else {
 		for i, run := range items {
 			realIdx := scrollOffset + i
 			selected := realIdx == a.runs.SelectedIndex()
-			hovered := a.mouseX < leftWidth && a.mouseY == panelStartY+i+BorderOffset
+			hovered := a.itemHovered(RunsPane, i)
 			icon := StatusIcon(run.Status, run.Conclusion)
-			line := icon + " #" + strconv.Itoa(run.RunNumber) + " " + run.Event + " " + run.Branch
+			number := "#" + strconv.Itoa(run.RunNumber)
+			// Narrow panels leave out the event, or the workflow and branch
+			// of the dashboard
+			var line string
+			switch {
+			case a.dashboard != nil && narrow:
+				line = icon + " " + a.runRepo(run.ID).Name + " " + number
+			case a.dashboard != nil:
+				line = icon + " " + padRight(a.runRepo(run.ID).Name, repoWidth) + " " + run.Name + " " + number + " " + run.Branch
+			case narrow:
+				line = icon + " " + number + " " + run.Branch
+			default:
+				line = icon + " " + number + " " + run.Event + " " + run.Branch
+			}
 			line = truncateString(line, width-ItemPaddingSmall)
-			content = append(content, a.renderListItem(line, selected, focused, hovered))
+			content = append(content, a.renderListItem(line, matchPositions(line, words), selected, focused, hovered))
 		}
 	}
 
@@ -88,30 +162,28 @@ func (a *App) buildRunsPanel(width, height int) []string {
 func (a *App) buildJobsPanel(width, height int) []string {
 	focused := a.focusedPane == JobsPane
 	borderStyle := getPanelBorderStyle(focused)
-	title := renderPanelTitle("Jobs", focused)
+	title := renderPanelTitle("Jobs"+truncationText(a.jobs), focused)
 
 	// Set visible height so scroll offset is maintained
 	contentHeight := height - BorderWidth
 	a.jobs.SetVisibleHeight(contentHeight)
 
-	// Calculate panel position for hover detection
-	leftWidth := a.leftPanelWidth()
-	panelStartY := a.panelStartY(JobsPane)
 	scrollOffset := a.jobs.ScrollOffset()
 
 	// Build content
 	var content []string
 	items := a.jobs.VisibleItems()
+	words := filterWords(a.jobs.Filter())
 	if a.jobs.Len() == 0 {
 		content = append(content, "  Select a run")
 	} else {
 		for i, job := range items {
 			realIdx := scrollOffset + i
 			selected := realIdx == a.jobs.SelectedIndex()
-			hovered := a.mouseX < leftWidth && a.mouseY == panelStartY+i+BorderOffset
+			hovered := a.itemHovered(JobsPane, i)
 			icon := StatusIcon(job.Status, job.Conclusion)
 			line := icon + " " + truncateString(job.Name, width-ItemPaddingMedium)
-			content = append(content, a.renderListItem(line, selected, focused, hovered))
+			content = append(content, a.renderListItem(line, matchPositions(line, words), selected, focused, hovered))
 		}
 	}
 
@@ -126,9 +198,9 @@ func (a *App) buildDetailPanel(width, height int) []string {
 	infoTab := " Info "
 	logsTab := " Logs "
 	if a.detailTab == InfoTab {
-		infoTab = FocusedTitle.Render(" Info ")
+		infoTab = theme.FocusedTitle.Render(" Info ")
 	} else {
-		logsTab = FocusedTitle.Render(" Logs ")
+		logsTab = theme.FocusedTitle.Render(" Logs ")
 	}
 	tabHeader := " [1]" + infoTab + " [2]" + logsTab + " "
 
@@ -163,6 +235,10 @@ func (a *App) buildInfoContent(maxWidth int) []string {
 		if run, ok := a.runs.Selected(); ok {
 			content = append(content, "  Run Information")
 			content = append(content, "  "+strings.Repeat("─", 30))
+			if a.dashboard != nil {
+				content = append(content, "  Repo:   "+a.runRepo(run.ID).FullName())
+				content = append(content, "  Name:   "+run.Name)
+			}
 			content = append(content, "  Run:    #"+strconv.Itoa(run.RunNumber))
 			content = append(content, "  Status: "+StatusIcon(run.Status, run.Conclusion)+" "+run.Status)
 			if run.Conclusion != "" {
@@ -207,8 +283,18 @@ func (a *App) buildInfoContent(maxWidth int) []string {
 	return content
 }
 
-// buildLogsContent builds the content for the Logs tab
+// buildLogsContent builds the content for the Logs tab: the steps, and the
+// log view below them
 func (a *App) buildLogsContent(maxWidth int) []string {
+	content := a.buildLogsHeader(maxWidth)
+	for _, l := range strings.Split(a.logView.View(), "\n") {
+		content = append(content, "  "+l)
+	}
+	return content
+}
+
+// buildLogsHeader builds the lines of the Logs tab above the log view
+func (a *App) buildLogsHeader(maxWidth int) []string {
 	var content []string
 
 	job, jobOk := a.jobs.Selected()
@@ -232,12 +318,12 @@ func (a *App) buildLogsContent(maxWidth int) []string {
 		allLogsText := "All logs"
 		if allLogsSelected {
 			if a.stepListFocused {
-				content = append(content, "  "+CursorStyle.Render(">")+" "+SelectedItemFocused.Render(allLogsText))
+				content = append(content, "  "+theme.Cursor.Render(">")+" "+theme.SelectedItemFocused.Render(allLogsText))
 			} else {
-				content = append(content, "  "+SelectedItemUnfocused.Render("> "+allLogsText))
+				content = append(content, "  "+theme.SelectedItemUnfocused.Render("> "+allLogsText))
 			}
 		} else {
-			content = append(content, "    "+NormalItem.Render(allLogsText))
+			content = append(content, "    "+theme.NormalItem.Render(allLogsText))
 		}
 
 		// Step list with status icons
@@ -246,8 +332,8 @@ func (a *App) buildLogsContent(maxWidth int) []string {
 
 			// Get step status from job.Steps if available
 			icon := " "
-			if jobOk && i < len(job.Steps) {
-				icon = StatusIcon(job.Steps[i].Status, job.Steps[i].Conclusion)
+			if jobStep, ok := MatchJobStep(job.Steps, i, step.Name); jobOk && ok {
+				icon = StatusIcon(jobStep.Status, jobStep.Conclusion)
 			}
 
 			stepName := truncateString(step.Name, maxWidth-10)
@@ -255,12 +341,12 @@ func (a *App) buildLogsContent(maxWidth int) []string {
 
 			if stepSelected {
 				if a.stepListFocused {
-					content = append(content, "  "+CursorStyle.Render(">")+" "+SelectedItemFocused.Render(stepText))
+					content = append(content, "  "+theme.Cursor.Render(">")+" "+theme.SelectedItemFocused.Render(stepText))
 				} else {
-					content = append(content, "  "+SelectedItemUnfocused.Render("> "+stepText))
+					content = append(content, "  "+theme.SelectedItemUnfocused.Render("> "+stepText))
 				}
 			} else {
-				content = append(content, "    "+NormalItem.Render(stepText))
+				content = append(content, "    "+theme.NormalItem.Render(stepText))
 			}
 		}
 
@@ -268,15 +354,8 @@ func (a *App) buildLogsContent(maxWidth int) []string {
 		content = append(content, "  "+strings.Repeat("─", 30))
 	}
 
-	// Log content
-	logContent := a.logView.View()
-	logLines := strings.Split(logContent, "\n")
-	for _, l := range logLines {
-		content = append(content, "  "+truncateString(l, maxWidth-4))
-	}
-
-	if len(content) == 0 {
-		content = append(content, "  No logs available")
+	if header := a.logViewHeader(); header != "" {
+		content = append(content, "  "+header)
 	}
 
 	return content
@@ -294,17 +373,27 @@ func padRight(s string, width int) string {
 
 // getPanelBorderStyle returns the border style based on focus state
 func getPanelBorderStyle(focused bool) lipgloss.Style {
-	borderColor := UnfocusedColor
+	borderColor := theme.UnfocusedColor
 	if focused {
-		borderColor = FocusedColor
+		borderColor = theme.FocusedColor
 	}
 	return lipgloss.NewStyle().Foreground(borderColor)
 }
 
+// truncationText returns the " (showing 100 of 240)" suffix of the title of a
+// list that could not be loaded completely, or an empty string
+func truncationText[T any](l *FilteredList[T]) string {
+	loaded, total, ok := l.Truncated()
+	if !ok {
+		return ""
+	}
+	return " (showing " + strconv.Itoa(loaded) + " of " + strconv.Itoa(total) + ")"
+}
+
 // renderPanelTitle renders the title with lazydocker-style inverted colors when focused
 func renderPanelTitle(titleText string, focused bool) string {
 	if focused {
-		return " " + FocusedTitle.Render(" "+titleText+" ") + " "
+		return " " + theme.FocusedTitle.Render(" "+titleText+" ") + " "
 	}
 	return " " + titleText + " "
 }
@@ -351,129 +440,151 @@ func truncateToWidth(s string, maxWidth int) string {
 	if maxWidth <= 0 {
 		return ""
 	}
+	if lipgloss.Width(s) <= maxWidth {
+		return s
+	}
+	// Leave room for the ellipsis
+	ellipsis := ""
+	if maxWidth >= 3 {
+		ellipsis = "..."
+	}
 	currentWidth := 0
 	for i, r := range s {
 		charWidth := lipgloss.Width(string(r))
-		if currentWidth+charWidth > maxWidth {
-			if maxWidth >= 3 && currentWidth >= 0 {
-				return s[:i] + "..."
-			}
-			return s[:i]
+		if currentWidth+charWidth > maxWidth-len(ellipsis) {
+			return s[:i] + ellipsis
 		}
 		currentWidth += charWidth
 	}
 	return s
 }
 
+// keyHint renders a status bar hint: the keys of bindings, then label.
+// A single key that starts the label is folded into it, as in "[t]rigger".
+func keyHint(label string, bindings ...key.Binding) string {
+	keys := bindingKeys(bindings...)
+	if first, size := utf8.DecodeRuneInString(label); strings.EqualFold(keys, string(first)) {
+		return "[" + keys + "]" + label[size:]
+	}
+	return "[" + keys + "]" + label
+}
+
 // renderStatusBar renders the status bar at the bottom
 func (a *App) renderStatusBar() string {
+	k := a.keys
+
 	// Navigation hints
-	navHints := "[j/k]panel [↑/↓]list [w/s]job"
+	navHints := keyHint("panel", k.PanelDown, k.PanelUp) + " " + keyHint("list", k.Up, k.Down) + " " + keyHint("job", k.JobUp, k.JobDown)
 
 	// Pane-specific action hints
 	var actionHints string
 	switch a.focusedPane {
+	case ReposPane:
+		actionHints = keyHint("switch repo", k.Up, k.Down) + " " + keyHint("filter", k.Filter)
 	case WorkflowsPane:
-		actionHints = "[t]rigger [/]filter"
+		actionHints = keyHint("trigger", k.Trigger) + " " + keyHint("filter", k.Filter)
 	case RunsPane:
-		actionHints = "[c]ancel [r]erun [R]erun-failed [y]ank"
+		actionHints = keyHint("cancel", k.Cancel) + " " + keyHint("rerun", k.Rerun) + " " + keyHint("rerun-failed", k.RerunFailed) + " " + keyHint("yank", k.Yank)
 	case JobsPane:
 		if a.detailTab == LogsTab && a.parsedLogs != nil && len(a.parsedLogs.Steps) > 0 {
 			if a.stepListFocused {
-				actionHints = "[↑/↓]step [Enter]logs [L]fullscreen"
+				actionHints = keyHint("step", k.Up, k.Down) + " " + keyHint("logs", k.Enter) + " " + keyHint("fullscreen", k.FullLog)
 			} else {
-				actionHints = "[↑/↓]scroll [Esc]steps [L]fullscreen"
+				actionHints = keyHint("scroll", k.Up, k.Down) + " " + keyHint("steps", k.Escape) + " " + keyHint("search", k.Filter) + " " + keyHint("wrap", k.Wrap) + " " + keyHint("fullscreen", k.FullLog)
+				if len(a.parsedLogs.groups) > 0 {
+					actionHints = keyHint("fold", k.Enter) + " " + actionHints
+				}
+				if len(a.logSearch.matches) > 0 {
+					actionHints = keyHint("match", k.NextMatch, k.PrevMatch) + " " + actionHints
+				}
 			}
 		} else {
-			actionHints = "[L]fullscreen [y]ank"
+			actionHints = keyHint("fullscreen", k.FullLog) + " " + keyHint("yank", k.Yank)
 		}
 	}
 
 	// Tab hints
-	tabHints := "[1]info [2]logs"
+	tabHints := keyHint("info", k.InfoTab) + " " + keyHint("logs", k.LogsTab)
 
 	// Common hints
-	commonHints := "[?]help [q]uit"
+	commonHints := keyHint("commands", k.Palette) + " " + keyHint("help", k.Help) + " " + keyHint("quit", k.Quit)
 
 	hints := navHints + " " + actionHints + " " + tabHints + " " + commonHints
+	if a.layout.compact {
+		// Leave room for the actions of the pane
+		hints = actionHints + " " + commonHints
+		if a.detailOnDemand() && a.layout.detailShown {
+			hints = keyHint("list", k.Escape) + " " + hints
+		} else if a.detailOnDemand() {
+			hints = keyHint("details", k.Enter) + " " + hints
+		}
+	}
 
 	if a.filtering {
-		return StatusBar.Width(a.width).Render("Filter: " + a.filterInput.View())
+		line := "Filter: " + a.filterInput.View()
+		// Syntax error hint next to the input
+		if err := a.filterError(); err != nil {
+			hintWidth := a.width - StatusBarPadding - lipgloss.Width(line) - 2
+			if hintWidth > 0 {
+				line += "  " + theme.Failure.Render(truncateString(err.Error(), hintWidth))
+			}
+		}
+		return theme.StatusBar.Width(a.width).Render(line)
 	}
 
 	if a.flashMsg != "" {
-		return StatusBar.Width(a.width).Render(a.flashMsg)
+		return theme.StatusBar.Width(a.width).Render(a.flashMsg)
 	}
 
 	if a.err != nil {
-		return StatusBar.
-			Foreground(lipgloss.Color("#FF0000")).
+		return theme.StatusBarError.
 			Width(a.width).
 			Render("Error: " + a.err.Error() + " [Esc]retry")
 	}
 
-	return StatusBar.Width(a.width).Render(hints)
+	// Right-align the "last updated" indicator, truncating hints to make room.
+	// The bar keeps to one line.
+	if indicator := a.pollStatusText(); indicator != "" {
+		hintsWidth := a.width - StatusBarPadding - lipgloss.Width(indicator) - 1
+		hints = padRight(truncateString(hints, hintsWidth), hintsWidth) + " " + indicator
+	} else {
+		hints = truncateString(hints, a.width-StatusBarPadding)
+	}
+
+	return theme.StatusBar.Width(a.width).Render(hints)
 }
 
 // renderFullscreenLog renders the fullscreen log view
 func (a *App) renderFullscreenLog() string {
-	title := FocusedTitle.Render("Logs (fullscreen)")
+	title := theme.FocusedTitle.Render("Logs (fullscreen)")
+	if header := a.logViewHeader(); header != "" {
+		title += "  " + header
+	}
 
 	content := lipgloss.JoinVertical(lipgloss.Left,
 		title,
 		a.logView.View(),
 	)
 
-	return FocusedPane.
-		Width(a.width).
-		Height(a.height - StatusBarHeight).
+	return theme.FocusedPane.
+		Width(a.width - BorderWidth).
+		Height(a.height - BorderWidth).
 		Render(content)
 }
 
-// renderHelp renders the help popup
-func (a *App) renderHelp() string {
-	help := `
-Panel Navigation
-──────────────────────────────────
-j           Next panel (down)
-k           Previous panel (up)
-↓/↑         Move in list (down/up)
-w/s         Previous/next job
-h/←         Previous pane
-l/→         Next pane
-Tab         Next pane
-Shift+Tab   Previous pane
-
-Actions
-──────────────────────────────────
-t           Trigger workflow
-c           Cancel run
-r           Rerun workflow
-R           Rerun failed jobs only
-y           Copy URL to clipboard
-
-Detail View
-──────────────────────────────────
-1           Info tab
-2           Logs tab
-
-Step Navigation (Logs tab)
-──────────────────────────────────
-↓/↑         Select step
-Enter       Focus log content
-Esc         Back to step list
-
-View
-──────────────────────────────────
-/           Filter
-L           Full-screen log
-Esc         Close/Back
-?           Toggle help
-q           Quit
-`
-	return lipgloss.Place(a.width, a.height,
-		lipgloss.Center, lipgloss.Center,
-		HelpPopup.Render(help))
+// bindingKeys returns how the keys of bindings are shown: all the keys of a
+// single binding, e.g. "h/←", or the first key of each of several, e.g. "↓/↑"
+func bindingKeys(bindings ...key.Binding) string {
+	if len(bindings) == 1 {
+		return bindings[0].Help().Key
+	}
+	keys := make([]string, 0, len(bindings))
+	for _, b := range bindings {
+		if ks := b.Keys(); len(ks) > 0 {
+			keys = append(keys, ks[0])
+		}
+	}
+	return keyHelp(keys)
 }
 
 // renderConfirmDialog renders the confirmation dialog
@@ -483,23 +594,110 @@ func (a *App) renderConfirmDialog() string {
 		"",
 		"[y] Yes  [n] No",
 	)
-	dialog := ConfirmDialog.Width(40).Render(content)
+	dialog := theme.ConfirmDialog.Width(40).Render(content)
+	return lipgloss.Place(a.width, a.height,
+		lipgloss.Center, lipgloss.Center, dialog)
+}
+
+// renderRefPicker renders the ref picker used before dispatching a workflow
+func (a *App) renderRefPicker() string {
+	width := a.width - ContentPadding
+	if width > RefPickerMaxWidth {
+		width = RefPickerMaxWidth
+	}
+	dialog := theme.FormDialog.Width(width).Render(a.refPicker.view(width - ContentPadding))
+	return lipgloss.Place(a.width, a.height,
+		lipgloss.Center, lipgloss.Center, dialog)
+}
+
+// renderDispatchForm renders the workflow_dispatch input form
+func (a *App) renderDispatchForm() string {
+	width := a.width - ContentPadding
+	if width > DispatchFormMaxWidth {
+		width = DispatchFormMaxWidth
+	}
+	dialog := theme.FormDialog.Width(width).Render(a.dispatchForm.view(width - ContentPadding))
+	return lipgloss.Place(a.width, a.height,
+		lipgloss.Center, lipgloss.Center, dialog)
+}
+
+// renderRemoteSwitcher renders the git remote switcher
+func (a *App) renderRemoteSwitcher() string {
+	width := a.width - ContentPadding
+	if width > RemoteSwitcherMaxWidth {
+		width = RemoteSwitcherMaxWidth
+	}
+	dialog := theme.FormDialog.Width(width).Render(a.remoteSwitcher.view(width - ContentPadding))
+	return lipgloss.Place(a.width, a.height,
+		lipgloss.Center, lipgloss.Center, dialog)
+}
+
+// renderPalette renders the command palette
+func (a *App) renderPalette() string {
+	width := min(a.width-ContentPadding, PaletteMaxWidth)
+	dialog := theme.FormDialog.Width(width).Render(a.palette.view(width - ContentPadding))
 	return lipgloss.Place(a.width, a.height,
 		lipgloss.Center, lipgloss.Center, dialog)
 }
 
-// renderListItem renders a list item with appropriate styling based on selection and focus state
-func (a *App) renderListItem(text string, selected, focused, _ bool) string {
+// renderListItem renders a list item with appropriate styling based on selection and focus state.
+// The characters of text at the rune indexes in matches are highlighted.
+func (a *App) renderListItem(text string, matches []int, selected, focused, _ bool) string {
 	if selected {
 		if focused {
 			// Focused + selected: green cursor + bright selection
-			return CursorStyle.Render(">") + SelectedItemFocused.Render(" "+text)
+			return theme.Cursor.Render(">") + renderHighlighted(" ", text, matches, theme.SelectedItemFocused)
 		}
 		// Unfocused + selected: dim selection without cursor
-		return SelectedItemUnfocused.Render("  " + text)
+		return renderHighlighted("  ", text, matches, theme.SelectedItemUnfocused)
 	}
 	// Not selected: normal text
-	return NormalItem.Render("  " + text)
+	return renderHighlighted("  ", text, matches, theme.NormalItem)
+}
+
+// renderHighlighted renders prefix and text with style, highlighting the
+// characters of text at the rune indexes in matches
+func renderHighlighted(prefix, text string, matches []int, style lipgloss.Style) string {
+	if len(matches) == 0 {
+		return style.Render(prefix + text)
+	}
+
+	highlight := theme.MatchHighlight.Inherit(style)
+	var b strings.Builder
+	segment := []rune(prefix)
+	highlighted := false
+	flush := func() {
+		if len(segment) == 0 {
+			return
+		}
+		if highlighted {
+			b.WriteString(highlight.Render(string(segment)))
+		} else {
+			b.WriteString(style.Render(string(segment)))
+		}
+		segment = segment[:0]
+	}
+	for i, r := range []rune(text) {
+		if matched := slices.Contains(matches, i); matched != highlighted {
+			flush()
+			highlighted = matched
+		}
+		segment = append(segment, r)
+	}
+	flush()
+	return b.String()
+}
+
+// matchPositions returns the rune indexes of the characters of text matched
+// by the filter words
+func matchPositions(text string, words []string) []int {
+	var positions []int
+	for _, w := range words {
+		if _, pos, ok := fuzzyMatch(w, text); ok {
+			positions = append(positions, pos...)
+		}
+	}
+	return positions
 }
 
 // truncateString truncates a string to maxLen display width, adding "..." if truncated
@@ -513,7 +711,9 @@ func truncateString(s string, maxLen int) string {
 	return truncateToWidth(s, maxLen)
 }
 
-// wrapLines wraps long lines to fit within maxWidth (display width)
+// wrapLines wraps long lines to fit within maxWidth (display width).
+// Escape sequences take no room and are never split; the styles in effect
+// where a line breaks resume on the next line.
 func wrapLines(content string, maxWidth int) string {
 	if maxWidth <= 0 {
 		maxWidth = DefaultWrapWidth
@@ -525,23 +725,58 @@ func wrapLines(content string, maxWidth int) string {
 			result = append(result, line)
 		} else {
 			// Split long lines by display width
-			currentLine := ""
+			var currentLine, active strings.Builder
 			currentWidth := 0
-			for _, r := range line {
+			for i := 0; i < len(line); {
+				if n := ansiSeqLen(line, i); n > 0 {
+					seq := line[i : i+n]
+					trackSGR(&active, seq)
+					currentLine.WriteString(seq)
+					i += n
+					continue
+				}
+				r, size := utf8.DecodeRuneInString(line[i:])
 				charWidth := lipgloss.Width(string(r))
 				if currentWidth+charWidth > maxWidth {
-					result = append(result, currentLine)
-					currentLine = string(r)
-					currentWidth = charWidth
-				} else {
-					currentLine += string(r)
-					currentWidth += charWidth
+					if active.Len() > 0 {
+						currentLine.WriteString("\x1b[0m")
+					}
+					result = append(result, currentLine.String())
+					currentLine.Reset()
+					currentLine.WriteString(active.String())
+					currentWidth = 0
 				}
+				currentLine.WriteString(line[i : i+size])
+				currentWidth += charWidth
+				i += size
 			}
-			if currentLine != "" {
-				result = append(result, currentLine)
+			if currentLine.Len() > 0 {
+				result = append(result, currentLine.String())
 			}
 		}
 	}
 	return strings.Join(result, "\n")
 }
+
+// MatchJobStep returns the step of a job that the step of its logs at index
+// i shows: the only one of the same name, or else the one at the same position
+// among the steps that were not skipped, as skipped steps write no logs
+func MatchJobStep(steps []github.Step, i int, name string) (github.Step, bool) {
+	var named []github.Step
+	var ran []github.Step
+	for _, step := range steps {
+		if step.Name == name {
+			named = append(named, step)
+		}
+		if step.Conclusion != "skipped" {
+			ran = append(ran, step)
+		}
+	}
+	if len(named) == 1 {
+		return named[0], true
+	}
+	if i < len(ran) {
+		return ran[i], true
+	}
+	return github.Step{}, false
+}
@@ -2,7 +2,12 @@ package app
 
 import (
 	"errors"
+	"slices"
+	"strings"
 	"testing"
+
+	"github.com/nnnkkk7/lazyactions/config"
+	"github.com/nnnkkk7/lazyactions/github"
 )
 
 func TestApp_RenderPanes(t *testing.T) {
@@ -58,3 +63,65 @@ func TestApp_RenderStatusBar_States(t *testing.T) {
 		t.Error("renderStatusBar with error returned empty string")
 	}
 }
+
+func TestMatchPositions(t *testing.T) {
+	text := "✓ Deploy to production"
+	got := matchPositions(text, filterWords("dply status:failure prod"))
+	want := []int{2, 4, 5, 7, 12, 13, 14, 15}
+	if !slices.Equal(got, want) {
+		t.Errorf("matchPositions() = %v, want %v", got, want)
+	}
+
+	// Highlighting keeps the text; tests render without colors
+	if rendered := renderHighlighted("  ", text, got, theme.NormalItem); rendered != "  "+text {
+		t.Errorf("renderHighlighted() = %q, want the text unchanged", rendered)
+	}
+}
+
+func TestApp_RenderHelp_ActiveBindings(t *testing.T) {
+	app := New(WithConfig(&config.Config{Keymap: PresetVim, Keybindings: map[string]config.Keys{"rerun": {"e"}}}))
+	app.width, app.height = 100, 60
+
+	help := app.renderHelp()
+	for _, want := range []string{"j/k         Move in list", "e           Rerun workflow", "[/]         Previous/next job"} {
+		if !strings.Contains(help, want) {
+			t.Errorf("help should contain %q:\n%s", want, help)
+		}
+	}
+
+	app.focusedPane = RunsPane
+	if bar := app.renderStatusBar(); !strings.Contains(bar, "[e]rerun") {
+		t.Errorf("status bar should show the rerun key:\n%s", bar)
+	}
+}
+
+func TestFindJobStep(t *testing.T) {
+	steps := []github.Step{
+		{Name: "Set up job", Conclusion: "success", Number: 1},
+		{Name: "Checkout", Conclusion: "success", Number: 2},
+		{Name: "Lint", Conclusion: "skipped", Number: 3},
+		{Name: "Run go test ./...", Conclusion: "failure", Number: 4},
+		{Name: "Upload coverage", Conclusion: "skipped", Number: 5},
+		{Name: "Post Checkout", Conclusion: "success", Number: 6},
+		{Name: "Complete job", Conclusion: "success", Number: 7},
+	}
+
+	tests := []struct {
+		i          int
+		name       string
+		wantNumber int
+	}{
+		{0, "Set up job", 1},
+		{1, "Run actions/checkout@v4", 2}, // named in the workflow
+		{2, "Run go test ./...", 4},       // by name
+		{3, "Post job cleanup", 6},        // after skipped steps
+		{4, "Complete job", 7},            // by name
+		{9, "Run actions/setup-go@v5", 0}, // none
+	}
+	for _, tt := range tests {
+		step, ok := MatchJobStep(steps, tt.i, tt.name)
+		if step.Number != tt.wantNumber || ok != (tt.wantNumber != 0) {
+			t.Errorf("MatchJobStep(%d, %q) = step %d, %v, want step %d", tt.i, tt.name, step.Number, ok, tt.wantNumber)
+		}
+	}
+}
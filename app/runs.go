@@ -0,0 +1,294 @@
+package app
+
+import (
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nnnkkk7/lazyactions/github"
+)
+
+// Run history constants
+const (
+	// RunsPerPage is the number of runs loaded per page in the Runs pane
+	RunsPerPage = 30
+	// RunsLoadAhead is how close the cursor gets to the end of the Runs pane
+	// before the next page is loaded
+	RunsLoadAhead = 5
+	// RunsFilterDebounce is how long typing in the Runs filter pauses before
+	// its server-side terms reload the runs
+	RunsFilterDebounce = 400 * time.Millisecond
+)
+
+// runListOpts returns the criteria of a Runs pane filter that the API
+// supports: dates, and terms with a single value that is not a pattern.
+// The runs returned still go through the whole filter.
+func runListOpts(q query[github.Run]) github.ListRunsOpts {
+	var opts github.ListRunsOpts
+	set := func(field *string, value string) {
+		if *field == "" {
+			*field = value
+		}
+	}
+	for _, term := range q.terms {
+		if term.negate {
+			continue
+		}
+		if term.field == "created" {
+			set(&opts.Created, createdRange(term.from, term.until))
+			continue
+		}
+		if len(term.values) > 1 || term.hasPattern() || term.values[0] == viewerAlias {
+			continue
+		}
+		switch term.field {
+		case "status", "conclusion":
+			set(&opts.Status, term.values[0])
+		case "branch":
+			set(&opts.Branch, term.values[0])
+		case "event":
+			set(&opts.Event, term.values[0])
+		case "actor":
+			set(&opts.Actor, term.values[0])
+		case "sha":
+			// The API only matches full SHAs
+			if len(term.values[0]) == 40 {
+				set(&opts.HeadSHA, term.values[0])
+			}
+		}
+	}
+	return opts
+}
+
+// createdRange formats the bounds [from, until) of a date term in the
+// search syntax of the created parameter
+func createdRange(from, until time.Time) string {
+	format := func(t time.Time) string { return t.UTC().Format(time.RFC3339) }
+	switch {
+	case from.IsZero():
+		return "<" + format(until)
+	case until.IsZero():
+		return ">=" + format(from)
+	}
+	return format(from) + ".." + format(until.Add(-time.Second))
+}
+
+// viewerAlias is the actor: value standing for the authenticated user
+const viewerAlias = "@me"
+
+// runHistory tracks the pages of runs loaded for the selected workflow
+type runHistory struct {
+	filter      string              // filter of the Runs pane as typed, before resolving @me
+	filterSeq   int                 // keystrokes typed in the filter, to debounce them
+	opts        github.ListRunsOpts // server-side criteria of the Runs pane filter
+	page        int                 // last page loaded; 0 until the first page arrives
+	loadingMore bool                // a page after the first is being loaded
+}
+
+// runCriteria returns the filter criteria of opts, without the workflow and page
+func runCriteria(opts github.ListRunsOpts) github.ListRunsOpts {
+	opts.WorkflowID, opts.Page, opts.PerPage = 0, 0, 0
+	return opts
+}
+
+// runsOpts returns the options listing a page of runs of a workflow
+func (a *App) runsOpts(workflowID int64, page int) github.ListRunsOpts {
+	opts := a.runHistory.opts
+	opts.WorkflowID = workflowID
+	opts.Page = page
+	opts.PerPage = RunsPerPage
+	return opts
+}
+
+// resetRuns starts the run history over, so that the next first page
+// replaces the loaded runs instead of refreshing them
+func (a *App) resetRuns() {
+	a.runHistory.page = 0
+	a.runHistory.loadingMore = false
+}
+
+// applyRunsFilter filters the Runs pane by the filter of the run history,
+// loading the authenticated user first if it refers to them
+func (a *App) applyRunsFilter() tea.Cmd {
+	cmd := a.loadViewer()
+	filter := a.resolveViewer(a.runHistory.filter)
+	a.runs.SetFilter(filter)
+	q, _ := parseQuery(filter, runFields)
+	return tea.Batch(cmd, a.applyRunQuery(q))
+}
+
+// previewRunsFilter filters the loaded runs while the filter is typed, and
+// applies its server-side terms once typing pauses
+func (a *App) previewRunsFilter(filter string) tea.Cmd {
+	h := &a.runHistory
+	h.filter = filter
+	h.filterSeq++
+	a.runs.SetFilter(a.resolveViewer(filter))
+	seq := h.filterSeq
+	return tea.Tick(RunsFilterDebounce, func(time.Time) tea.Msg {
+		return RunsFilterDebounceMsg{Seq: seq}
+	})
+}
+
+// onRunsFilterDebounce applies the filter typed last
+func (a *App) onRunsFilterDebounce(msg RunsFilterDebounceMsg) tea.Cmd {
+	if msg.Seq != a.runHistory.filterSeq {
+		// More was typed since
+		return nil
+	}
+	return a.applyRunsFilter()
+}
+
+// loadViewer loads the authenticated user if the filter of the run history
+// refers to them and they are not loaded yet
+func (a *App) loadViewer() tea.Cmd {
+	if !strings.Contains(a.runHistory.filter, viewerAlias) || a.viewer != "" || a.client == nil {
+		return nil
+	}
+	return fetchViewer(a.client)
+}
+
+// resolveViewer replaces @me in filter by the login of the authenticated
+// user, once loaded
+func (a *App) resolveViewer(filter string) string {
+	if a.viewer == "" {
+		return filter
+	}
+	return strings.ReplaceAll(filter, viewerAlias, a.viewer)
+}
+
+// onViewerLoaded resolves @me in the filter of the Runs pane
+func (a *App) onViewerLoaded(msg ViewerLoadedMsg) tea.Cmd {
+	if msg.Err != nil {
+		a.err = msg.Err
+		return nil
+	}
+	a.viewer = msg.Login
+	if !strings.Contains(a.runHistory.filter, viewerAlias) {
+		return nil
+	}
+	return a.applyRunsFilter()
+}
+
+// applyRunQuery applies the server-side criteria of a Runs pane filter,
+// reloading the runs if they changed
+func (a *App) applyRunQuery(q query[github.Run]) tea.Cmd {
+	if a.dashboard != nil {
+		return nil
+	}
+	opts := runListOpts(q)
+	if opts == a.runHistory.opts {
+		// The filtered list may have become short enough to need more runs
+		return a.loadMoreRuns()
+	}
+	a.runHistory.opts = opts
+	a.resetRuns()
+	wf, ok := a.workflows.Selected()
+	if !ok {
+		return nil
+	}
+	a.loading = true
+	return a.fetchRunsCmd(wf.ID)
+}
+
+// loadMoreRuns loads the next page of runs when the cursor is within
+// RunsLoadAhead runs of the end of the Runs pane and more runs exist
+func (a *App) loadMoreRuns() tea.Cmd {
+	h := &a.runHistory
+	if a.client == nil || a.dashboard != nil || h.loadingMore || h.page == 0 {
+		return nil
+	}
+	if _, _, more := a.runs.Truncated(); !more {
+		return nil
+	}
+	if a.runs.SelectedIndex() < a.runs.Len()-RunsLoadAhead {
+		return nil
+	}
+	wf, ok := a.workflows.Selected()
+	if !ok {
+		return nil
+	}
+	h.loadingMore = true
+	return a.forView(fetchRuns(a.client, a.repo, a.runsOpts(wf.ID, h.page+1)))
+}
+
+// onRunsLoaded shows a page of runs. The first page replaces the runs, or
+// refreshes them once more pages were loaded; later pages are appended.
+func (a *App) onRunsLoaded(msg RunsLoadedMsg) tea.Cmd {
+	if msg.Opts.Page > 1 {
+		return a.onMoreRunsLoaded(msg)
+	}
+	if runCriteria(msg.Opts) != a.runHistory.opts {
+		// Loaded for a filter that is no longer applied
+		return nil
+	}
+
+	a.loading = false
+	if msg.Err != nil {
+		a.err = msg.Err
+		return nil
+	}
+	a.markUpdated()
+
+	runs := msg.Runs
+	if a.runHistory.page > 1 {
+		runs = mergeRuns(msg.Runs, a.runs.AllItems())
+	} else {
+		a.runHistory.page = 1
+	}
+
+	// Keep the selection on the same run when a refresh reorders the list
+	prev, hadPrev := a.runs.Selected()
+	a.runs.SetItems(runs)
+	a.runs.SetTotal(msg.Total)
+	if hadPrev {
+		a.runs.SelectMatching(func(r github.Run) bool { return r.ID == prev.ID })
+	}
+	var cmds []tea.Cmd
+	if run, ok := a.runs.Selected(); ok {
+		cmds = append(cmds, a.fetchJobsCmd(run.ID))
+	}
+	return tea.Batch(append(cmds, a.loadMoreRuns())...)
+}
+
+// onMoreRunsLoaded appends a page of runs after the first
+func (a *App) onMoreRunsLoaded(msg RunsLoadedMsg) tea.Cmd {
+	h := &a.runHistory
+	wf, ok := a.workflows.Selected()
+	if !h.loadingMore || msg.Opts.Page != h.page+1 || !ok || wf.ID != msg.Opts.WorkflowID ||
+		runCriteria(msg.Opts) != h.opts {
+		// Loaded for a workflow or filter that is no longer shown
+		return nil
+	}
+	h.loadingMore = false
+	if msg.Err != nil {
+		a.err = msg.Err
+		return nil
+	}
+
+	h.page = msg.Opts.Page
+	a.runs.SetItems(mergeRuns(a.runs.AllItems(), msg.Runs))
+	if len(msg.Runs) == 0 {
+		// The API stops listing runs before the total it reports
+		a.runs.SetTotal(0)
+		return nil
+	}
+	a.runs.SetTotal(msg.Total)
+	return a.loadMoreRuns()
+}
+
+// mergeRuns returns the runs of first followed by those of rest that are not
+// in first. Runs shift between pages when new runs are created.
+func mergeRuns(first, rest []github.Run) []github.Run {
+	merged := make([]github.Run, 0, len(first)+len(rest))
+	seen := make(map[int64]bool, len(first)+len(rest))
+	for _, runs := range [][]github.Run{first, rest} {
+		for _, r := range runs {
+			if !seen[r.ID] {
+				seen[r.ID] = true
+				merged = append(merged, r)
+			}
+		}
+	}
+	return merged
+}
@@ -0,0 +1,177 @@
+package app
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nnnkkk7/lazyactions/github"
+)
+
+func TestRunListOpts(t *testing.T) {
+	sha := "0123456789abcdef0123456789abcdef01234567"
+	q, err := parseQuery("status:failure branch:main actor:octocat event:push,schedule -event:push sha:"+sha+" created:2024-01-01..2024-01-31 fix", runFields)
+	if err != nil {
+		t.Fatalf("parseQuery() error = %v", err)
+	}
+	got := runListOpts(q)
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local).UTC().Format(time.RFC3339)
+	until := time.Date(2024, 1, 31, 23, 59, 59, 0, time.Local).UTC().Format(time.RFC3339)
+	want := github.ListRunsOpts{
+		Status:  "failure",
+		Branch:  "main",
+		Actor:   "octocat",
+		HeadSHA: sha,
+		Created: from + ".." + until,
+	}
+	if got != want {
+		t.Errorf("runListOpts() = %+v, want %+v", got, want)
+	}
+
+	// Patterns, abbreviated SHAs and @me are only matched client-side
+	q, _ = parseQuery("branch:release/* sha:0123456 actor:@me", runFields)
+	if got := runListOpts(q); got != (github.ListRunsOpts{}) {
+		t.Errorf("runListOpts() = %+v, want no server-side criteria", got)
+	}
+}
+
+// newRunHistoryTestApp returns an app whose mock client lists total runs of
+// workflow 1, RunsPerPage per page, newest (highest ID) first
+func newRunHistoryTestApp(total int) (*App, *github.MockClient) {
+	mock := newMockClient(nil)
+	mock.ListRunsFunc = func(ctx context.Context, repo github.Repository, opts *github.ListRunsOpts) (github.List[github.Run], error) {
+		page := max(opts.Page, 1)
+		var runs []github.Run
+		for i := (page - 1) * RunsPerPage; i < min(page*RunsPerPage, total); i++ {
+			runs = append(runs, github.Run{ID: int64(total - i), Actor: opts.Actor, Conclusion: opts.Status})
+		}
+		return github.List[github.Run]{Items: runs, Total: total}, nil
+	}
+	app := New(WithClient(mock), WithRepository(github.Repository{Owner: "owner", Name: "repo"}))
+	app.width, app.height = 120, 40
+	app.workflows.SetItems([]github.Workflow{{ID: 1, Name: "CI"}})
+	app.focusedPane = RunsPane
+	return app, mock
+}
+
+func TestApp_Runs_LoadMore(t *testing.T) {
+	app, mock := newRunHistoryTestApp(70)
+	app.Update(app.fetchRunsCmd(1)())
+	if app.runs.Len() != RunsPerPage || app.runHistory.page != 1 {
+		t.Fatalf("runs = %d, page = %d, want the first page", app.runs.Len(), app.runHistory.page)
+	}
+	if got := truncationText(app.runs); got != " (showing 30 of 70)" {
+		t.Errorf("truncationText() = %q", got)
+	}
+
+	// Far from the end, no page is loaded
+	if cmd := app.loadMoreRuns(); cmd != nil {
+		t.Error("the next page should not load before the cursor nears the end")
+	}
+
+	for app.runs.SelectedIndex() < app.runs.Len()-RunsLoadAhead {
+		app.runs.SelectNext()
+	}
+	cmd := app.loadMoreRuns()
+	if cmd == nil {
+		t.Fatal("the next page should load near the end")
+	}
+	if app.loadMoreRuns() != nil {
+		t.Error("a page should not be requested twice")
+	}
+	app.Update(cmd())
+
+	calls := mock.ListRunsCalls()
+	if opts := calls[len(calls)-1].Opts; opts.Page != 2 || opts.WorkflowID != 1 {
+		t.Errorf("opts = %+v, want page 2 of workflow 1", opts)
+	}
+	if app.runs.Len() != 2*RunsPerPage || app.runHistory.page != 2 {
+		t.Errorf("runs = %d, page = %d, want two pages", app.runs.Len(), app.runHistory.page)
+	}
+
+	t.Run("duplicates", func(t *testing.T) {
+		// A new run shifted the last run of page 2 onto page 3
+		app.runHistory.loadingMore = true
+		app.Update(repoMsg{repo: app.repo, msg: RunsLoadedMsg{
+			Opts:  github.ListRunsOpts{WorkflowID: 1, Page: 3},
+			Total: 71,
+			Runs:  []github.Run{{ID: 11}, {ID: 10}},
+		}})
+		if app.runs.Len() != 2*RunsPerPage+1 {
+			t.Errorf("runs = %d, want the duplicate dropped", app.runs.Len())
+		}
+	})
+
+	t.Run("stale page", func(t *testing.T) {
+		app.Update(repoMsg{repo: app.repo, msg: RunsLoadedMsg{Opts: github.ListRunsOpts{WorkflowID: 1, Page: 3}, Runs: []github.Run{{ID: 999}}}})
+		for _, r := range app.runs.AllItems() {
+			if r.ID == 999 {
+				t.Fatal("a page not requested should be dropped")
+			}
+		}
+	})
+}
+
+func TestApp_Runs_RefreshKeepsPages(t *testing.T) {
+	app, _ := newRunHistoryTestApp(70)
+	app.Update(app.fetchRunsCmd(1)())
+	app.runHistory.loadingMore = true
+	app.Update(fetchRuns(app.client, app.repo, app.runsOpts(1, 2))())
+
+	app.Update(repoMsg{repo: app.repo, msg: RunsLoadedMsg{
+		Opts:  github.ListRunsOpts{WorkflowID: 1, Page: 1},
+		Total: 71,
+		Runs:  []github.Run{{ID: 71}, {ID: 70}},
+	}})
+	items := app.runs.AllItems()
+	if len(items) != 2*RunsPerPage+1 || items[0].ID != 71 {
+		t.Errorf("runs = %d starting with %d, want the new run ahead of both pages", len(items), items[0].ID)
+	}
+
+	// Selecting another workflow starts over
+	app.workflows.SetItems([]github.Workflow{{ID: 1}, {ID: 2}})
+	app.workflows.SelectNext()
+	app.onWorkflowSelectionChange()
+	app.Update(app.fetchRunsCmd(2)())
+	if app.runs.Len() != RunsPerPage {
+		t.Errorf("runs = %d, want only the first page", app.runs.Len())
+	}
+}
+
+func TestApp_Runs_FilterQualifiers(t *testing.T) {
+	app, mock := newRunHistoryTestApp(70)
+	app.Update(app.fetchRunsCmd(1)())
+
+	app.filterInput.SetValue("status:failure actor:@me")
+	app.filtering = true
+	cmd := app.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatal("terms should reload the runs")
+	}
+	// The authenticated user for @me, which reloads the runs again,
+	// then the runs loaded before @me was resolved
+	msgs := cmd().(tea.BatchMsg)
+	_, reload := app.Update(msgs[0]())
+	if app.viewer != "octocat" || reload == nil {
+		t.Fatalf("viewer = %q, want octocat and the runs reloaded", app.viewer)
+	}
+	app.Update(reload())
+	calls := mock.ListRunsCalls()
+	opts := calls[len(calls)-1].Opts
+	if opts.Status != "failure" || opts.Actor != "octocat" || opts.Page != 1 {
+		t.Errorf("opts = %+v, want the terms on page 1", opts)
+	}
+	app.Update(msgs[1]())
+	if app.runs.Len() != RunsPerPage {
+		t.Errorf("runs = %d, want the runs of octocat, not those of the stale filter", app.runs.Len())
+	}
+
+	// The same terms do not reload the runs
+	app.filterInput.SetValue("actor:@me status:failure")
+	app.filtering = true
+	app.handleKeyPress(tea.KeyMsg{Type: tea.KeyEnter})
+	if app.loading || app.runHistory.page != 1 {
+		t.Error("unchanged terms should not reload the runs")
+	}
+}
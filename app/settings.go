@@ -0,0 +1,195 @@
+package app
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/nnnkkk7/lazyactions/config"
+)
+
+// hasDarkBackground reports whether the terminal background is dark, which
+// picks the theme by default
+var hasDarkBackground = lipgloss.HasDarkBackground
+
+// settings are the behaviors of the App that the config file changes;
+// see WithConfig
+type settings struct {
+	leftPanelWidthRatio float64
+
+	pollIntervalActive time.Duration
+	pollIntervalIdle   time.Duration
+	pollIntervalMax    time.Duration
+	repoStatusInterval time.Duration
+
+	// Actions that ask for confirmation
+	confirmCancel      bool
+	confirmRerun       bool
+	confirmRerunFailed bool
+
+	// Filters applied to the panes of every repository shown
+	filters config.Filters
+
+	theme *Theme
+}
+
+// defaultSettings returns the settings without a config file
+func defaultSettings() settings {
+	return settings{
+		leftPanelWidthRatio: LeftPanelWidthRatio,
+		pollIntervalActive:  PollIntervalActive,
+		pollIntervalIdle:    PollIntervalIdle,
+		pollIntervalMax:     PollIntervalMax,
+		repoStatusInterval:  RepoStatusInterval,
+		confirmCancel:       true,
+		theme:               defaultTheme(),
+	}
+}
+
+// WithConfig applies the user configuration. Settings that ValidateConfig
+// reports are ignored.
+func WithConfig(cfg *config.Config) Option {
+	return func(a *App) {
+		if cfg == nil {
+			return
+		}
+		a.keys, _ = newKeyMap(cfg.Keymap, cfg.Keybindings)
+
+		s := &a.settings
+		if cfg.Layout.LeftWidth != 0 {
+			s.leftPanelWidthRatio = cfg.Layout.LeftWidth
+		}
+
+		r := cfg.Refresh
+		for _, d := range []struct {
+			value   time.Duration
+			setting *time.Duration
+		}{
+			{r.Active, &s.pollIntervalActive},
+			{r.Idle, &s.pollIntervalIdle},
+			{r.Max, &s.pollIntervalMax},
+			{r.RepoStatus, &s.repoStatusInterval},
+		} {
+			if d.value != 0 {
+				*d.setting = d.value
+			}
+		}
+		// Settings from different files may overlap: the backoff never
+		// goes below the active interval
+		s.pollIntervalIdle = max(s.pollIntervalIdle, s.pollIntervalActive)
+		s.pollIntervalMax = max(s.pollIntervalMax, s.pollIntervalIdle)
+
+		c := cfg.Confirm
+		for _, b := range []struct {
+			value   *bool
+			setting *bool
+		}{
+			{c.Cancel, &s.confirmCancel},
+			{c.Rerun, &s.confirmRerun},
+			{c.RerunFailed, &s.confirmRerunFailed},
+		} {
+			if b.value != nil {
+				*b.setting = *b.value
+			}
+		}
+
+		s.filters = cfg.Filters
+		s.theme = resolveTheme(cfg)
+	}
+}
+
+// resolveTheme returns the theme named in cfg. Without one, it picks the dark
+// or light theme after the terminal background, or the no-color theme if
+// NO_COLOR is set. An unknown theme falls back to the default theme.
+func resolveTheme(cfg *config.Config) *Theme {
+	name := cmp.Or(cfg.Theme, ThemeAuto)
+	if custom, ok := cfg.Themes[name]; ok {
+		p, ok := palettes[autoTheme(cmp.Or(custom.Base, ThemeAuto))]
+		if !ok {
+			p = palettes[autoTheme(ThemeAuto)]
+		}
+		colors := p.colors()
+		for role, c := range custom.Colors {
+			if ptr, ok := colors[role]; ok {
+				*ptr = c
+			}
+		}
+		return NewTheme(name, p)
+	}
+	if t, ok := BuiltinTheme(autoTheme(name)); ok {
+		return t
+	}
+	return defaultTheme()
+}
+
+// autoTheme returns the built-in theme that ThemeAuto stands for, or name
+func autoTheme(name string) string {
+	switch {
+	case name != ThemeAuto:
+		return name
+	case noColor():
+		return ThemeNoColor
+	case hasDarkBackground():
+		return ThemeDark
+	}
+	return ThemeLight
+}
+
+// themeNames returns the names of the built-in themes and those of cfg
+func themeNames(cfg *config.Config) []string {
+	names := []string{ThemeAuto}
+	for name := range palettes {
+		names = append(names, name)
+	}
+	for name := range cfg.Themes {
+		if _, builtin := palettes[name]; !builtin {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+// validateThemes reports the unknown themes and colors of cfg
+func validateThemes(cfg *config.Config) []error {
+	var errs []error
+	names := themeNames(cfg)
+	if cfg.Theme != "" && !slices.Contains(names, cfg.Theme) {
+		errs = append(errs, fmt.Errorf("theme: unknown theme %q, want one of %s", cfg.Theme, strings.Join(names, ", ")))
+	}
+
+	var p Palette
+	roles := slices.Sorted(maps.Keys(p.colors()))
+	for _, name := range slices.Sorted(maps.Keys(cfg.Themes)) {
+		custom := cfg.Themes[name]
+		if custom.Base != "" && custom.Base != ThemeAuto {
+			if _, ok := palettes[custom.Base]; !ok {
+				errs = append(errs, fmt.Errorf("themes.%s.base: unknown theme %q, want a built-in theme", name, custom.Base))
+			}
+		}
+		for _, role := range slices.Sorted(maps.Keys(custom.Colors)) {
+			if !slices.Contains(roles, role) {
+				errs = append(errs, fmt.Errorf("themes.%s.colors: unknown color %q, want one of %s", name, role, strings.Join(roles, ", ")))
+			}
+		}
+	}
+	return errs
+}
+
+// ValidateConfig reports the settings of cfg that the app does not know or
+// that conflict: unknown keymaps, keybinding actions, themes and colors, and
+// keys bound twice in a pane. The config package validates the rest.
+func ValidateConfig(cfg *config.Config) error {
+	var errs []error
+	if _, err := newKeyMap(cfg.Keymap, cfg.Keybindings); err != nil {
+		errs = append(errs, err)
+	}
+
+	errs = append(errs, validateThemes(cfg)...)
+	return errors.Join(errs...)
+}
@@ -0,0 +1,225 @@
+package app
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/nnnkkk7/lazyactions/config"
+	"github.com/nnnkkk7/lazyactions/github"
+)
+
+func TestApp_WithConfig(t *testing.T) {
+	no := false
+	app := New(WithConfig(&config.Config{
+		Keybindings: map[string]config.Keys{"rerun": {"x", "ctrl+x"}, "unknown": {"z"}},
+		Layout:      config.Layout{LeftWidth: 0.5},
+		Refresh:     config.Refresh{Active: 10 * time.Second, Max: 8 * time.Second},
+		Confirm:     config.Confirm{Cancel: &no},
+	}))
+
+	if keys := app.keys.Rerun.Keys(); len(keys) != 2 || keys[0] != "x" || keys[1] != "ctrl+x" {
+		t.Errorf("Rerun keys = %v, want [x ctrl+x]", keys)
+	}
+	if help := app.keys.Rerun.Help(); help.Key != "x/ctrl+x" || help.Desc != "rerun workflow" {
+		t.Errorf("Rerun help = %+v", help)
+	}
+	if keys := app.keys.Quit.Keys(); len(keys) != 1 || keys[0] != "q" {
+		t.Errorf("Quit keys = %v, want the default", keys)
+	}
+
+	app.width = 100
+	if w := app.leftPanelWidth(); w != 50 {
+		t.Errorf("leftPanelWidth() = %d, want 50", w)
+	}
+
+	// The backoff never goes below the active interval
+	s := app.settings
+	if s.pollIntervalActive != 10*time.Second || s.pollIntervalIdle != 15*time.Second || s.pollIntervalMax != 15*time.Second {
+		t.Errorf("poll intervals = %v, %v, %v, want 10s, 15s, 15s", s.pollIntervalActive, s.pollIntervalIdle, s.pollIntervalMax)
+	}
+	if s.repoStatusInterval != RepoStatusInterval {
+		t.Errorf("repoStatusInterval = %v, want the default", s.repoStatusInterval)
+	}
+	if s.confirmCancel || s.confirmRerun {
+		t.Error("confirm settings should follow the config")
+	}
+}
+
+func TestApp_WithConfig_Confirm(t *testing.T) {
+	yes, no := true, false
+	app := New(
+		WithClient(newMockClient(nil)),
+		WithConfig(&config.Config{Confirm: config.Confirm{Cancel: &no, Rerun: &yes}}),
+	)
+	app.runs.SetItems([]github.Run{{ID: 1, Status: "in_progress"}})
+
+	if cmd := app.confirmCancelRun(); cmd == nil || app.showConfirm {
+		t.Error("cancel should run without confirmation")
+	}
+	if cmd := app.rerunWorkflow(); cmd != nil || !app.showConfirm || app.confirmMsg != "Rerun this workflow?" {
+		t.Errorf("rerun should ask for confirmation, got %q", app.confirmMsg)
+	}
+}
+
+func TestApp_WithConfig_Filters(t *testing.T) {
+	mock := newMockClient(&mockClientState{
+		workflows: []github.Workflow{
+			{ID: 1, Name: "CI", State: "active"},
+			{ID: 2, Name: "Old", State: "disabled_manually"},
+		},
+	})
+	app := New(
+		WithClient(mock),
+		WithRepository(apiRepo),
+		WithWorkspace([]github.Repository{apiRepo, webRepo}),
+		WithConfig(&config.Config{Filters: config.Filters{
+			Repositories: "web",
+			Workflows:    "state:active",
+			Runs:         "branch:main",
+		}}),
+	)
+
+	if app.repos.Len() != 1 {
+		t.Errorf("repos = %+v, want the default filter applied", app.repos.Items())
+	}
+	app.Update(app.fetchWorkflowsCmd()())
+	if app.workflows.Len() != 1 {
+		t.Errorf("workflows = %+v, want the default filter applied", app.workflows.Items())
+	}
+	if opts := app.runsOpts(1, 1); opts.Branch != "main" {
+		t.Errorf("runsOpts() = %+v, want the branch of the default filter", opts)
+	}
+
+	// Repositories shown later start with the default filters too
+	app.switchRepository(webRepo)
+	if app.workflows.Filter() != "state:active" || app.runs.Filter() != "branch:main" {
+		t.Errorf("filters = %q, %q, want the defaults", app.workflows.Filter(), app.runs.Filter())
+	}
+
+	// Filtering starts from the filter of the pane
+	app.focusedPane = RunsPane
+	app.handleKeyPress(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'/'}})
+	if got := app.filterInput.Value(); got != "branch:main" {
+		t.Errorf("filter input = %q, want the filter of the pane", got)
+	}
+}
+
+func TestValidateConfig(t *testing.T) {
+	if err := ValidateConfig(&config.Config{
+		Keybindings: map[string]config.Keys{"rerun": {"x"}},
+		Theme:       "mine",
+		Themes:      map[string]config.Theme{"mine": {Base: ThemeLight, Colors: map[string]string{"focused": "33"}}},
+	}); err != nil {
+		t.Errorf("ValidateConfig() error = %v", err)
+	}
+
+	err := ValidateConfig(&config.Config{
+		Keybindings: map[string]config.Keys{"rerun": {"x"}, "deploy": {"d"}},
+		Theme:       "neon",
+		Themes:      map[string]config.Theme{"mine": {Base: "sepia", Colors: map[string]string{"border": "33"}}},
+	})
+	for _, want := range []string{
+		`unknown action "deploy"`,
+		`theme: unknown theme "neon", want one of auto, dark, high-contrast, light, mine, no-color`,
+		`themes.mine.base: unknown theme "sepia"`,
+		`themes.mine.colors: unknown color "border"`,
+	} {
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("ValidateConfig() error = %v, want %q", err, want)
+		}
+	}
+}
+
+func TestApp_WithConfig_Theme(t *testing.T) {
+	// Cleanups run last first: restore the default theme once NO_COLOR is
+	t.Cleanup(func() { New() })
+	t.Setenv("NO_COLOR", "")
+	defer func(f func() bool) { hasDarkBackground = f }(hasDarkBackground)
+	dark := true
+	hasDarkBackground = func() bool { return dark }
+
+	tests := []struct {
+		name string
+		cfg  *config.Config
+		want string
+	}{
+		{"auto on a dark background", &config.Config{}, ThemeDark},
+		{"explicit", &config.Config{Theme: ThemeHighContrast}, ThemeHighContrast},
+		{"custom", &config.Config{Theme: "mine", Themes: map[string]config.Theme{"mine": {}}}, "mine"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			New(WithConfig(tt.cfg))
+			if CurrentTheme().Name != tt.want {
+				t.Errorf("theme = %q, want %q", CurrentTheme().Name, tt.want)
+			}
+		})
+	}
+
+	dark = false
+	New(WithConfig(&config.Config{}))
+	if CurrentTheme().Name != ThemeLight {
+		t.Errorf("theme on a light background = %q, want %q", CurrentTheme().Name, ThemeLight)
+	}
+}
+
+func TestApp_WithConfig_CustomTheme(t *testing.T) {
+	t.Cleanup(func() { New() })
+	New(WithConfig(&config.Config{
+		Theme: "mine",
+		Themes: map[string]config.Theme{"mine": {
+			Base:   ThemeLight,
+			Colors: map[string]string{"focused": "#ff8800", "unknown": "1"},
+		}},
+	}))
+
+	if got := CurrentTheme().FocusedColor; got != lipgloss.Color("#ff8800") {
+		t.Errorf("FocusedColor = %v, want the custom color", got)
+	}
+	light, _ := BuiltinTheme(ThemeLight)
+	if got := CurrentTheme().UnfocusedColor; got != light.UnfocusedColor {
+		t.Errorf("UnfocusedColor = %v, want the color of the base theme", got)
+	}
+}
+
+func TestApp_WithConfig_CustomNoColorTheme(t *testing.T) {
+	t.Cleanup(func() { New() })
+	New(WithConfig(&config.Config{
+		Theme: "mono",
+		Themes: map[string]config.Theme{"mono": {
+			Base:   ThemeNoColor,
+			Colors: map[string]string{"failure": "1"},
+		}},
+	}))
+
+	// The roles left without a color show as text attributes, as in the base theme
+	th := CurrentTheme()
+	if !th.FocusedTitle.GetReverse() || !th.SelectedItemFocused.GetReverse() || !th.SelectedItemUnfocused.GetUnderline() || !th.Dimmed.GetFaint() {
+		t.Error("a theme based on no-color should show selections and focus as text attributes")
+	}
+	if th.StatusBarError.GetBold() {
+		t.Error("the colored error status should not be bold")
+	}
+	if dark, _ := BuiltinTheme(ThemeDark); dark.FocusedTitle.GetReverse() || dark.Dimmed.GetFaint() {
+		t.Error("colored themes should not use the attributes of the no-color theme")
+	}
+}
+
+func TestApp_WithConfig_NoColor(t *testing.T) {
+	t.Cleanup(func() { New() })
+	t.Setenv("NO_COLOR", "1")
+
+	New(WithConfig(&config.Config{}))
+	if CurrentTheme().Name != ThemeNoColor {
+		t.Errorf("theme = %q, want %q with NO_COLOR set", CurrentTheme().Name, ThemeNoColor)
+	}
+
+	// A theme set in the config overrides NO_COLOR
+	New(WithConfig(&config.Config{Theme: ThemeDark}))
+	if CurrentTheme().Name != ThemeDark {
+		t.Errorf("theme = %q, want %q", CurrentTheme().Name, ThemeDark)
+	}
+}
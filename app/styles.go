@@ -2,138 +2,365 @@ package app
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/charmbracelet/lipgloss"
 )
 
-// Color palette - semantic color names for consistent theming
-var (
-	// Primary colors
-	ColorGreen      = lipgloss.Color("#00FF00") // Success, focused, running
-	ColorRed        = lipgloss.Color("#FF0000") // Error, failure
-	ColorYellow     = lipgloss.Color("#FFFF00") // Running, in-progress
-	ColorCyan       = lipgloss.Color("#00FFFF") // Info, timestamp, notice
-	ColorOrange     = lipgloss.Color("#FF8800") // Warning, cancelled
-	ColorBlack      = lipgloss.Color("#000000") // Text on bright backgrounds
-	ColorWhite      = lipgloss.Color("#FFFFFF") // Bright text
-	ColorDarkGray   = lipgloss.Color("#333333") // Status bar background
-	ColorMediumGray = lipgloss.Color("#666666") // Unfocused elements
-	ColorLightGray  = lipgloss.Color("#888888") // Queued, dim text
-	ColorSilver     = lipgloss.Color("#AAAAAA") // Normal text
-	ColorPaleGray   = lipgloss.Color("#CCCCCC") // Unfocused selected text
-
-	// Accent colors
-	ColorBlue         = lipgloss.Color("#0066CC") // Selection background
-	ColorDarkBlue     = lipgloss.Color("#444444") // Unfocused selection background
-	ColorDarkGreen    = lipgloss.Color("#006600") // End group marker
-	ColorLightRed     = lipgloss.Color("#FF6666") // Error keyword highlight
-	ColorLightOrange  = lipgloss.Color("#FFAA00") // Warning keyword highlight
-	ColorLightGreen   = lipgloss.Color("#66FF66") // Success keyword highlight
+// Built-in theme names
+const (
+	// ThemeAuto picks ThemeDark or ThemeLight after the terminal background
+	ThemeAuto         = "auto"
+	ThemeDark         = "dark"
+	ThemeLight        = "light"
+	ThemeHighContrast = "high-contrast"
+	// ThemeNoColor is used when the NO_COLOR environment variable is set
+	ThemeNoColor = "no-color"
 )
 
-// UI state colors - semantic aliases
-var (
-	FocusedColor   = ColorGreen
-	UnfocusedColor = ColorMediumGray
-)
+// Palette holds the colors of a theme by role, as "#RRGGBB" or an ANSI
+// color number. An empty color leaves the terminal default.
+type Palette struct {
+	Focused   string // border and title of the focused pane, cursor
+	Unfocused string // border and title of the other panes
+	TitleText string // text of the focused pane title
 
-// Pane styles - use thin border for compact UI
-var (
-	FocusedPane = lipgloss.NewStyle().
-			Border(lipgloss.NormalBorder()).
-			BorderForeground(FocusedColor)
+	Text                       string // list items
+	SelectedText               string // selected item of the focused pane
+	SelectedBackground         string
+	InactiveSelectedText       string // selected item of the other panes
+	InactiveSelectedBackground string
+	Highlight                  string // characters matched by the filter
+	StatusBarBackground        string
 
-	UnfocusedPane = lipgloss.NewStyle().
-			Border(lipgloss.NormalBorder()).
-			BorderForeground(UnfocusedColor)
-)
+	Success   string
+	Failure   string
+	Running   string
+	Queued    string
+	Cancelled string
 
-// Title styles - lazydocker style inverted title for focused panel
-var (
-	FocusedTitle = lipgloss.NewStyle().
-			Background(FocusedColor).
-			Foreground(ColorBlack).
-			Bold(true)
+	Dialog string // border of the confirmation dialog
+	Popup  string // border of the help popup and forms, banner
 
-	UnfocusedTitle = lipgloss.NewStyle().
-			Foreground(UnfocusedColor)
-)
+	LogTimestamp      string
+	LogGroup          string
+	LogEndGroup       string
+	LogError          string
+	LogWarning        string
+	LogNotice         string
+	LogErrorKeyword   string
+	LogWarningKeyword string
+	LogSuccessKeyword string
+}
 
-// Status icon styles
-var (
-	SuccessStyle   = lipgloss.NewStyle().Foreground(ColorGreen)
-	FailureStyle   = lipgloss.NewStyle().Foreground(ColorRed)
-	RunningStyle   = lipgloss.NewStyle().Foreground(ColorYellow)
-	QueuedStyle    = lipgloss.NewStyle().Foreground(ColorLightGray)
-	CancelledStyle = lipgloss.NewStyle().Foreground(ColorOrange)
-)
+// colors returns the colors of the palette by name, the names custom
+// themes use in the config
+func (p *Palette) colors() map[string]*string {
+	return map[string]*string{
+		"focused":                      &p.Focused,
+		"unfocused":                    &p.Unfocused,
+		"title_text":                   &p.TitleText,
+		"text":                         &p.Text,
+		"selected_text":                &p.SelectedText,
+		"selected_background":          &p.SelectedBackground,
+		"inactive_selected_text":       &p.InactiveSelectedText,
+		"inactive_selected_background": &p.InactiveSelectedBackground,
+		"highlight":                    &p.Highlight,
+		"status_bar_background":        &p.StatusBarBackground,
+		"success":                      &p.Success,
+		"failure":                      &p.Failure,
+		"running":                      &p.Running,
+		"queued":                       &p.Queued,
+		"cancelled":                    &p.Cancelled,
+		"dialog":                       &p.Dialog,
+		"popup":                        &p.Popup,
+		"log_timestamp":                &p.LogTimestamp,
+		"log_group":                    &p.LogGroup,
+		"log_end_group":                &p.LogEndGroup,
+		"log_error":                    &p.LogError,
+		"log_warning":                  &p.LogWarning,
+		"log_notice":                   &p.LogNotice,
+		"log_error_keyword":            &p.LogErrorKeyword,
+		"log_warning_keyword":          &p.LogWarningKeyword,
+		"log_success_keyword":          &p.LogSuccessKeyword,
+	}
+}
 
-// Selection styles - lazydocker style: bright selection for focused, dim for unfocused
-var (
-	SelectedItemFocused = lipgloss.NewStyle().
-				Foreground(ColorWhite).
-				Background(ColorBlue).
-				Bold(true)
+// palettes are the palettes of the built-in themes
+var palettes = map[string]Palette{
+	ThemeDark: {
+		Focused:                    "#00FF00",
+		Unfocused:                  "#666666",
+		TitleText:                  "#000000",
+		Text:                       "#AAAAAA",
+		SelectedText:               "#FFFFFF",
+		SelectedBackground:         "#0066CC",
+		InactiveSelectedText:       "#CCCCCC",
+		InactiveSelectedBackground: "#444444",
+		Highlight:                  "#FFFF00",
+		StatusBarBackground:        "#333333",
+		Success:                    "#00FF00",
+		Failure:                    "#FF0000",
+		Running:                    "#FFFF00",
+		Queued:                     "#888888",
+		Cancelled:                  "#FF8800",
+		Dialog:                     "#FF8800",
+		Popup:                      "#00FFFF",
+		LogTimestamp:               "#00FFFF",
+		LogGroup:                   "#00FF00",
+		LogEndGroup:                "#006600",
+		LogError:                   "#FF0000",
+		LogWarning:                 "#FF8800",
+		LogNotice:                  "#00FFFF",
+		LogErrorKeyword:            "#FF6666",
+		LogWarningKeyword:          "#FFAA00",
+		LogSuccessKeyword:          "#66FF66",
+	},
+	ThemeLight: {
+		Focused:                    "#008700",
+		Unfocused:                  "#8A8A8A",
+		TitleText:                  "#FFFFFF",
+		Text:                       "#303030",
+		SelectedText:               "#FFFFFF",
+		SelectedBackground:         "#005FD7",
+		InactiveSelectedText:       "#303030",
+		InactiveSelectedBackground: "#D0D0D0",
+		Highlight:                  "#AF5F00",
+		StatusBarBackground:        "#E4E4E4",
+		Success:                    "#008700",
+		Failure:                    "#D70000",
+		Running:                    "#AF8700",
+		Queued:                     "#808080",
+		Cancelled:                  "#D75F00",
+		Dialog:                     "#D75F00",
+		Popup:                      "#005F87",
+		LogTimestamp:               "#005F87",
+		LogGroup:                   "#008700",
+		LogEndGroup:                "#5F875F",
+		LogError:                   "#D70000",
+		LogWarning:                 "#D75F00",
+		LogNotice:                  "#005F87",
+		LogErrorKeyword:            "#D70000",
+		LogWarningKeyword:          "#AF5F00",
+		LogSuccessKeyword:          "#008700",
+	},
+	ThemeHighContrast: {
+		Focused:                    "#FFFF00",
+		Unfocused:                  "#FFFFFF",
+		TitleText:                  "#000000",
+		Text:                       "#FFFFFF",
+		SelectedText:               "#000000",
+		SelectedBackground:         "#FFFF00",
+		InactiveSelectedText:       "#000000",
+		InactiveSelectedBackground: "#FFFFFF",
+		Highlight:                  "#00FFFF",
+		StatusBarBackground:        "#000000",
+		Success:                    "#00FF00",
+		Failure:                    "#FF5555",
+		Running:                    "#FFFF00",
+		Queued:                     "#FFFFFF",
+		Cancelled:                  "#FF8800",
+		Dialog:                     "#FFFF00",
+		Popup:                      "#FFFFFF",
+		LogTimestamp:               "#00FFFF",
+		LogGroup:                   "#00FF00",
+		LogEndGroup:                "#00FF00",
+		LogError:                   "#FF5555",
+		LogWarning:                 "#FFFF00",
+		LogNotice:                  "#00FFFF",
+		LogErrorKeyword:            "#FF5555",
+		LogWarningKeyword:          "#FFFF00",
+		LogSuccessKeyword:          "#00FF00",
+	},
+	ThemeNoColor: {},
+}
 
-	SelectedItemUnfocused = lipgloss.NewStyle().
-				Foreground(ColorPaleGray).
-				Background(ColorDarkBlue)
+// Theme holds every style of the UI
+type Theme struct {
+	Name string
 
-	// Cursor style for selected item
-	CursorStyle = lipgloss.NewStyle().
-			Foreground(FocusedColor).
-			Bold(true)
+	// Border colors of the panes
+	FocusedColor   lipgloss.TerminalColor
+	UnfocusedColor lipgloss.TerminalColor
 
-	NormalItem = lipgloss.NewStyle().
-			Foreground(ColorSilver)
+	// Panes - thin border for compact UI
+	FocusedPane   lipgloss.Style
+	UnfocusedPane lipgloss.Style
+	// Titles - lazydocker style inverted title for the focused pane
+	FocusedTitle   lipgloss.Style
+	UnfocusedTitle lipgloss.Style
 
-	// Keep backward compatibility
-	SelectedItem = SelectedItemFocused
-)
+	// Status icons
+	Success   lipgloss.Style
+	Failure   lipgloss.Style
+	Running   lipgloss.Style
+	Queued    lipgloss.Style
+	Cancelled lipgloss.Style
 
-// Dialog styles
-var (
-	ConfirmDialog = lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(ColorOrange).
-			Padding(1, 2)
-
-	HelpPopup = lipgloss.NewStyle().
-			Border(lipgloss.DoubleBorder()).
-			BorderForeground(ColorCyan).
-			Padding(1, 2)
-
-	StatusBar = lipgloss.NewStyle().
-			Background(ColorDarkGray).
-			Padding(0, 1)
-)
+	// Selection - lazydocker style: bright selection for focused, dim for unfocused
+	SelectedItemFocused   lipgloss.Style
+	SelectedItemUnfocused lipgloss.Style
+	Cursor                lipgloss.Style
+	NormalItem            lipgloss.Style
+	// MatchHighlight highlights the characters of list items matched by the filter
+	MatchHighlight lipgloss.Style
+	// Dimmed shows what does not apply in the current context
+	Dimmed lipgloss.Style
 
-// Log syntax highlighting styles
-var (
-	LogTimestampStyle = lipgloss.NewStyle().Foreground(ColorCyan)
-	LogGroupStyle     = lipgloss.NewStyle().Foreground(ColorGreen).Bold(true)
-	LogEndGroupStyle  = lipgloss.NewStyle().Foreground(ColorDarkGreen)
-	LogErrorStyle     = lipgloss.NewStyle().Foreground(ColorRed).Bold(true)
-	LogWarningStyle   = lipgloss.NewStyle().Foreground(ColorOrange)
-	LogNoticeStyle    = lipgloss.NewStyle().Foreground(ColorCyan)
-	LogErrorKeyword   = lipgloss.NewStyle().Foreground(ColorLightRed)
-	LogWarningKeyword = lipgloss.NewStyle().Foreground(ColorLightOrange)
-	LogSuccessKeyword = lipgloss.NewStyle().Foreground(ColorLightGreen)
-)
+	// Dialogs and bars
+	ConfirmDialog  lipgloss.Style
+	FormDialog     lipgloss.Style
+	HelpPopup      lipgloss.Style
+	StatusBar      lipgloss.Style
+	StatusBarError lipgloss.Style
+	Banner         lipgloss.Style
+
+	// Log syntax highlighting, see FormatLogLineWithColor
+	LogTimestamp      lipgloss.Style
+	LogGroup          lipgloss.Style
+	LogEndGroup       lipgloss.Style
+	LogError          lipgloss.Style
+	LogWarning        lipgloss.Style
+	LogNotice         lipgloss.Style
+	LogErrorKeyword   lipgloss.Style
+	LogWarningKeyword lipgloss.Style
+	LogSuccessKeyword lipgloss.Style
+	// Matches of the log search, and the current one
+	LogMatch        lipgloss.Style
+	LogMatchCurrent lipgloss.Style
+	// Numbers of the log lines, and the lines selected to copy
+	LogLineNumber lipgloss.Style
+	LogSelection  lipgloss.Style
+}
+
+// color returns the terminal color of a palette color
+func color(c string) lipgloss.TerminalColor {
+	if c == "" {
+		return lipgloss.NoColor{}
+	}
+	return lipgloss.Color(c)
+}
+
+// NewTheme builds the styles of a palette
+func NewTheme(name string, p Palette) *Theme {
+	fg := func(c string) lipgloss.Style { return lipgloss.NewStyle().Foreground(color(c)) }
+	t := &Theme{
+		Name:           name,
+		FocusedColor:   color(p.Focused),
+		UnfocusedColor: color(p.Unfocused),
+
+		FocusedPane:    lipgloss.NewStyle().Border(lipgloss.NormalBorder()).BorderForeground(color(p.Focused)),
+		UnfocusedPane:  lipgloss.NewStyle().Border(lipgloss.NormalBorder()).BorderForeground(color(p.Unfocused)),
+		FocusedTitle:   lipgloss.NewStyle().Background(color(p.Focused)).Foreground(color(p.TitleText)).Bold(true),
+		UnfocusedTitle: fg(p.Unfocused),
+
+		Success:   fg(p.Success),
+		Failure:   fg(p.Failure),
+		Running:   fg(p.Running),
+		Queued:    fg(p.Queued),
+		Cancelled: fg(p.Cancelled),
+
+		SelectedItemFocused:   lipgloss.NewStyle().Foreground(color(p.SelectedText)).Background(color(p.SelectedBackground)).Bold(true),
+		SelectedItemUnfocused: lipgloss.NewStyle().Foreground(color(p.InactiveSelectedText)).Background(color(p.InactiveSelectedBackground)),
+		Cursor:                fg(p.Focused).Bold(true),
+		NormalItem:            fg(p.Text),
+		MatchHighlight:        fg(p.Highlight).Bold(true).Underline(true),
+		Dimmed:                fg(p.Unfocused),
+
+		ConfirmDialog:  lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(color(p.Dialog)).Padding(1, 2),
+		FormDialog:     lipgloss.NewStyle().Border(lipgloss.RoundedBorder()).BorderForeground(color(p.Popup)).Padding(1, 2),
+		HelpPopup:      lipgloss.NewStyle().Border(lipgloss.DoubleBorder()).BorderForeground(color(p.Popup)).Padding(1, 2),
+		StatusBar:      lipgloss.NewStyle().Background(color(p.StatusBarBackground)).Padding(0, 1),
+		StatusBarError: lipgloss.NewStyle().Background(color(p.StatusBarBackground)).Foreground(color(p.Failure)).Padding(0, 1),
+		Banner:         fg(p.Popup),
+
+		LogTimestamp:      fg(p.LogTimestamp),
+		LogGroup:          fg(p.LogGroup).Bold(true),
+		LogEndGroup:       fg(p.LogEndGroup),
+		LogError:          fg(p.LogError).Bold(true),
+		LogWarning:        fg(p.LogWarning),
+		LogNotice:         fg(p.LogNotice),
+		LogErrorKeyword:   fg(p.LogErrorKeyword),
+		LogWarningKeyword: fg(p.LogWarningKeyword),
+		LogSuccessKeyword: fg(p.LogSuccessKeyword),
+		LogMatch:          lipgloss.NewStyle().Background(color(p.Highlight)).Foreground(color(p.TitleText)),
+		LogMatchCurrent:   lipgloss.NewStyle().Background(color(p.Focused)).Foreground(color(p.TitleText)).Bold(true),
+		LogLineNumber:     fg(p.Unfocused),
+		LogSelection:      lipgloss.NewStyle().Background(color(p.SelectedBackground)).Foreground(color(p.SelectedText)),
+	}
+
+	// Selections and focus left without a color, as in the no-color theme
+	// and the custom themes based on it, show as text attributes
+	if p.Focused == "" {
+		t.FocusedTitle = t.FocusedTitle.Reverse(true)
+		t.LogMatchCurrent = t.LogMatchCurrent.Reverse(true).Underline(true)
+	}
+	if p.SelectedBackground == "" {
+		t.SelectedItemFocused = t.SelectedItemFocused.Reverse(true)
+		t.LogSelection = t.LogSelection.Reverse(true)
+	}
+	if p.InactiveSelectedBackground == "" {
+		t.SelectedItemUnfocused = t.SelectedItemUnfocused.Underline(true)
+	}
+	if p.Failure == "" {
+		t.StatusBarError = t.StatusBarError.Bold(true)
+	}
+	if p.Unfocused == "" {
+		t.Dimmed = t.Dimmed.Faint(true)
+		t.LogLineNumber = t.LogLineNumber.Faint(true)
+	}
+	if p.Highlight == "" {
+		t.LogMatch = t.LogMatch.Reverse(true)
+	}
+	return t
+}
+
+// BuiltinTheme returns a built-in theme, or false if there is none by that name
+func BuiltinTheme(name string) (*Theme, bool) {
+	p, ok := palettes[name]
+	if !ok {
+		return nil, false
+	}
+	return NewTheme(name, p), true
+}
+
+// noColor reports whether colors are disabled by the NO_COLOR environment
+// variable, see https://no-color.org
+func noColor() bool {
+	return os.Getenv("NO_COLOR") != ""
+}
+
+// defaultTheme returns the theme used without a config
+func defaultTheme() *Theme {
+	name := ThemeDark
+	if noColor() {
+		name = ThemeNoColor
+	}
+	t, _ := BuiltinTheme(name)
+	return t
+}
+
+// theme is the theme in use, set by New
+var theme = defaultTheme()
+
+// CurrentTheme returns the theme in use
+func CurrentTheme() *Theme {
+	return theme
+}
 
 // StatusIcon returns icon for status
 func StatusIcon(status, conclusion string) string {
 	switch {
 	case status == "in_progress":
-		return RunningStyle.Render("●")
+		return theme.Running.Render("●")
 	case status == "queued":
-		return QueuedStyle.Render("○")
+		return theme.Queued.Render("○")
 	case conclusion == "success":
-		return SuccessStyle.Render("✓")
+		return theme.Success.Render("✓")
 	case conclusion == "failure":
-		return FailureStyle.Render("✗")
+		return theme.Failure.Render("✗")
 	case conclusion == "cancelled":
-		return CancelledStyle.Render("⊘")
+		return theme.Cancelled.Render("⊘")
 	default:
 		return " "
 	}
@@ -142,9 +369,9 @@ func StatusIcon(status, conclusion string) string {
 // RenderItem renders list item with selection state
 func RenderItem(text string, selected bool) string {
 	if selected {
-		return SelectedItem.Render("> " + text)
+		return theme.SelectedItemFocused.Render("> " + text)
 	}
-	return NormalItem.Render("  " + text)
+	return theme.NormalItem.Render("  " + text)
 }
 
 // ScrollPosition renders scroll position in "1/10" format (1-indexed for display).
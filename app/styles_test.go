@@ -232,30 +232,30 @@ func TestStyleVariables_NotNil(t *testing.T) {
 		name  string
 		style func() string
 	}{
-		{"FocusedPane", func() string { return FocusedPane.Render("test") }},
-		{"UnfocusedPane", func() string { return UnfocusedPane.Render("test") }},
-		{"FocusedTitle", func() string { return FocusedTitle.Render("test") }},
-		{"UnfocusedTitle", func() string { return UnfocusedTitle.Render("test") }},
-		{"SuccessStyle", func() string { return SuccessStyle.Render("test") }},
-		{"FailureStyle", func() string { return FailureStyle.Render("test") }},
-		{"RunningStyle", func() string { return RunningStyle.Render("test") }},
-		{"QueuedStyle", func() string { return QueuedStyle.Render("test") }},
-		{"CancelledStyle", func() string { return CancelledStyle.Render("test") }},
-		{"SelectedItem", func() string { return SelectedItem.Render("test") }},
-		{"NormalItem", func() string { return NormalItem.Render("test") }},
-		{"ConfirmDialog", func() string { return ConfirmDialog.Render("test") }},
-		{"HelpPopup", func() string { return HelpPopup.Render("test") }},
-		{"StatusBar", func() string { return StatusBar.Render("test") }},
+		{"theme.FocusedPane", func() string { return theme.FocusedPane.Render("test") }},
+		{"theme.UnfocusedPane", func() string { return theme.UnfocusedPane.Render("test") }},
+		{"theme.FocusedTitle", func() string { return theme.FocusedTitle.Render("test") }},
+		{"theme.UnfocusedTitle", func() string { return theme.UnfocusedTitle.Render("test") }},
+		{"theme.Success", func() string { return theme.Success.Render("test") }},
+		{"theme.Failure", func() string { return theme.Failure.Render("test") }},
+		{"theme.Running", func() string { return theme.Running.Render("test") }},
+		{"theme.Queued", func() string { return theme.Queued.Render("test") }},
+		{"theme.Cancelled", func() string { return theme.Cancelled.Render("test") }},
+		{"theme.SelectedItemFocused", func() string { return theme.SelectedItemFocused.Render("test") }},
+		{"theme.NormalItem", func() string { return theme.NormalItem.Render("test") }},
+		{"theme.ConfirmDialog", func() string { return theme.ConfirmDialog.Render("test") }},
+		{"theme.HelpPopup", func() string { return theme.HelpPopup.Render("test") }},
+		{"theme.StatusBar", func() string { return theme.StatusBar.Render("test") }},
 		// Log syntax highlighting styles
-		{"LogTimestampStyle", func() string { return LogTimestampStyle.Render("test") }},
-		{"LogGroupStyle", func() string { return LogGroupStyle.Render("test") }},
-		{"LogEndGroupStyle", func() string { return LogEndGroupStyle.Render("test") }},
-		{"LogErrorStyle", func() string { return LogErrorStyle.Render("test") }},
-		{"LogWarningStyle", func() string { return LogWarningStyle.Render("test") }},
-		{"LogNoticeStyle", func() string { return LogNoticeStyle.Render("test") }},
-		{"LogErrorKeyword", func() string { return LogErrorKeyword.Render("test") }},
-		{"LogWarningKeyword", func() string { return LogWarningKeyword.Render("test") }},
-		{"LogSuccessKeyword", func() string { return LogSuccessKeyword.Render("test") }},
+		{"theme.LogTimestamp", func() string { return theme.LogTimestamp.Render("test") }},
+		{"theme.LogGroup", func() string { return theme.LogGroup.Render("test") }},
+		{"theme.LogEndGroup", func() string { return theme.LogEndGroup.Render("test") }},
+		{"theme.LogError", func() string { return theme.LogError.Render("test") }},
+		{"theme.LogWarning", func() string { return theme.LogWarning.Render("test") }},
+		{"theme.LogNotice", func() string { return theme.LogNotice.Render("test") }},
+		{"theme.LogErrorKeyword", func() string { return theme.LogErrorKeyword.Render("test") }},
+		{"theme.LogWarningKeyword", func() string { return theme.LogWarningKeyword.Render("test") }},
+		{"theme.LogSuccessKeyword", func() string { return theme.LogSuccessKeyword.Render("test") }},
 	}
 
 	for _, tt := range tests {
@@ -271,10 +271,33 @@ func TestStyleVariables_NotNil(t *testing.T) {
 
 func TestColorVariables(t *testing.T) {
 	// Verify color values
-	if FocusedColor != lipgloss.Color("#00FF00") {
-		t.Errorf("FocusedColor = %v, want #00FF00", FocusedColor)
+	if theme.FocusedColor != lipgloss.Color("#00FF00") {
+		t.Errorf("theme.FocusedColor = %v, want #00FF00", theme.FocusedColor)
 	}
-	if UnfocusedColor != lipgloss.Color("#666666") {
-		t.Errorf("UnfocusedColor = %v, want #666666", UnfocusedColor)
+	if theme.UnfocusedColor != lipgloss.Color("#666666") {
+		t.Errorf("theme.UnfocusedColor = %v, want #666666", theme.UnfocusedColor)
+	}
+}
+
+func TestBuiltinTheme(t *testing.T) {
+	for _, name := range []string{ThemeDark, ThemeLight, ThemeHighContrast, ThemeNoColor} {
+		th, ok := BuiltinTheme(name)
+		if !ok || th.Name != name {
+			t.Fatalf("BuiltinTheme(%q) = %v, %v", name, th, ok)
+		}
+		// Every role has a color, except in the no-color theme
+		_, noColor := th.FocusedColor.(lipgloss.NoColor)
+		if noColor != (name == ThemeNoColor) {
+			t.Errorf("BuiltinTheme(%q).FocusedColor = %v", name, th.FocusedColor)
+		}
+	}
+	if _, ok := BuiltinTheme(ThemeAuto); ok {
+		t.Error("BuiltinTheme(auto) should resolve to no theme")
+	}
+
+	// Without colors, the selection stays visible
+	th, _ := BuiltinTheme(ThemeNoColor)
+	if !th.SelectedItemFocused.GetReverse() {
+		t.Error("no-color selection should be reversed")
 	}
 }
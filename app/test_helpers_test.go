@@ -17,6 +17,13 @@ type mockClientState struct {
 	logs      string
 	err       error
 	rateLimit int
+
+	fileContent  string
+	environments []string
+
+	defaultBranch string
+	branches      []string
+	tags          []string
 }
 
 func newMockClient(state *mockClientState) *github.MockClient {
@@ -25,14 +32,22 @@ func newMockClient(state *mockClientState) *github.MockClient {
 	}
 
 	return &github.MockClient{
-		ListWorkflowsFunc: func(ctx context.Context, repo github.Repository) ([]github.Workflow, error) {
-			return state.workflows, state.err
+		ListWorkflowsFunc: func(ctx context.Context, repo github.Repository) (github.List[github.Workflow], error) {
+			return github.List[github.Workflow]{Items: state.workflows, Total: len(state.workflows)}, state.err
+		},
+		ListRunsFunc: func(ctx context.Context, repo github.Repository, opts *github.ListRunsOpts) (github.List[github.Run], error) {
+			return github.List[github.Run]{Items: state.runs, Total: len(state.runs)}, state.err
 		},
-		ListRunsFunc: func(ctx context.Context, repo github.Repository, opts *github.ListRunsOpts) ([]github.Run, error) {
-			return state.runs, state.err
+		GetRunFunc: func(ctx context.Context, repo github.Repository, runID int64) (github.Run, error) {
+			for _, r := range state.runs {
+				if r.ID == runID {
+					return r, state.err
+				}
+			}
+			return github.Run{}, state.err
 		},
-		ListJobsFunc: func(ctx context.Context, repo github.Repository, runID int64) ([]github.Job, error) {
-			return state.jobs, state.err
+		ListJobsFunc: func(ctx context.Context, repo github.Repository, runID int64) (github.List[github.Job], error) {
+			return github.List[github.Job]{Items: state.jobs, Total: len(state.jobs)}, state.err
 		},
 		GetJobLogsFunc: func(ctx context.Context, repo github.Repository, jobID int64) (string, error) {
 			return state.logs, state.err
@@ -49,6 +64,27 @@ func newMockClient(state *mockClientState) *github.MockClient {
 		TriggerWorkflowFunc: func(ctx context.Context, repo github.Repository, workflowFile, ref string, inputs map[string]interface{}) error {
 			return state.err
 		},
+		GetFileContentFunc: func(ctx context.Context, repo github.Repository, path, ref string) (string, error) {
+			return state.fileContent, state.err
+		},
+		ListEnvironmentsFunc: func(ctx context.Context, repo github.Repository) ([]string, error) {
+			return state.environments, state.err
+		},
+		CurrentUserFunc: func(ctx context.Context) (string, error) {
+			return "octocat", state.err
+		},
+		GetDefaultBranchFunc: func(ctx context.Context, repo github.Repository) (string, error) {
+			if state.defaultBranch != "" {
+				return state.defaultBranch, state.err
+			}
+			return "main", state.err
+		},
+		ListBranchesFunc: func(ctx context.Context, repo github.Repository) ([]string, error) {
+			return state.branches, state.err
+		},
+		ListTagsFunc: func(ctx context.Context, repo github.Repository) ([]string, error) {
+			return state.tags, state.err
+		},
 		RateLimitRemainingFunc: func() int {
 			if state.rateLimit > 0 {
 				return state.rateLimit
@@ -0,0 +1,135 @@
+package app
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nnnkkk7/lazyactions/github"
+)
+
+// RepoStatusInterval is the default minimum interval between refreshes of
+// the latest run of every workspace repository
+const RepoStatusInterval = time.Minute
+
+// repoView is the per-repository UI state kept while another repository is
+// shown, so switching back restores the selection, filters and logs
+type repoView struct {
+	workflows       *FilteredList[github.Workflow]
+	runs            *FilteredList[github.Run]
+	runHistory      runHistory
+	jobs            *FilteredList[github.Job]
+	parsedLogs      *ParsedLogs
+	selectedStepIdx int
+	stepListFocused bool
+}
+
+// newRepoView creates the state of a repository that has not been shown yet,
+// filtered by the default filters of the config
+func (a *App) newRepoView() *repoView {
+	v := &repoView{
+		workflows:       newWorkflowList(),
+		runs:            newRunList(),
+		jobs:            newJobList(),
+		selectedStepIdx: -1,
+		stepListFocused: true,
+	}
+	f := a.settings.filters
+	v.workflows.SetFilter(f.Workflows)
+	v.jobs.SetFilter(f.Jobs)
+
+	// Like applyRunsFilter, without loading runs
+	v.runHistory.filter = f.Runs
+	runs := a.resolveViewer(f.Runs)
+	v.runs.SetFilter(runs)
+	q, _ := parseQuery(runs, runFields)
+	v.runHistory.opts = runListOpts(q)
+	return v
+}
+
+// saveView captures the state of the repository being shown
+func (a *App) saveView() *repoView {
+	return &repoView{
+		workflows:       a.workflows,
+		runs:            a.runs,
+		runHistory:      a.runHistory,
+		jobs:            a.jobs,
+		parsedLogs:      a.parsedLogs,
+		selectedStepIdx: a.selectedStepIdx,
+		stepListFocused: a.stepListFocused,
+	}
+}
+
+// restoreView shows the state of a repository
+func (a *App) restoreView(v *repoView) {
+	a.workflows = v.workflows
+	a.runs = v.runs
+	a.runHistory = v.runHistory
+	a.runHistory.loadingMore = false
+	a.jobs = v.jobs
+	a.parsedLogs = v.parsedLogs
+	a.selectedStepIdx = v.selectedStepIdx
+	a.stepListFocused = v.stepListFocused
+
+	switch job, ok := a.jobs.Selected(); {
+	case a.parsedLogs != nil:
+		a.updateLogViewContent()
+	case ok && !job.IsCompleted():
+		a.logView.SetContent(jobStatusMessage(job))
+	default:
+		a.logView.SetContent("")
+	}
+}
+
+// switchRepository shows repo, restoring its state if it was shown before,
+// and refreshes its workflows in the background
+func (a *App) switchRepository(repo github.Repository) tea.Cmd {
+	a.exitDashboard()
+	if repo == a.repo {
+		return nil
+	}
+
+	a.views[a.repo] = a.saveView()
+	view, ok := a.views[repo]
+	if !ok {
+		view = a.newRepoView()
+	}
+	a.repo = repo
+	a.restoreView(view)
+	a.err = nil
+	a.repos.SelectMatching(func(r github.Repository) bool { return r == repo })
+	return a.refreshAll()
+}
+
+// showReposPane returns true if the Repositories pane is shown,
+// which is the case for a workspace of several repositories
+func (a *App) showReposPane() bool {
+	return len(a.workspace) > 1
+}
+
+// refreshRepoStatuses loads the latest run of every workspace repository,
+// at most once per repository status interval
+func (a *App) refreshRepoStatuses() tea.Cmd {
+	if a.client == nil || !a.showReposPane() || time.Since(a.repoStatusUpdated) < a.settings.repoStatusInterval {
+		return nil
+	}
+	a.repoStatusUpdated = time.Now()
+
+	cmds := make([]tea.Cmd, 0, len(a.workspace))
+	for _, r := range a.workspace {
+		cmds = append(cmds, fetchRepoStatus(a.client, r))
+	}
+	return tea.Batch(cmds...)
+}
+
+// onRepoStatusLoaded records the latest run of a workspace repository.
+// Errors are not shown: they concern a repository other than the one in use.
+func (a *App) onRepoStatusLoaded(msg RepoStatusLoadedMsg) {
+	if msg.Err != nil {
+		return
+	}
+	if msg.Run == nil {
+		delete(a.repoRuns, msg.Repo)
+		return
+	}
+	a.repoRuns[msg.Repo] = *msg.Run
+}
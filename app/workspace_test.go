@@ -0,0 +1,145 @@
+package app
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/nnnkkk7/lazyactions/github"
+)
+
+var (
+	apiRepo = github.Repository{Host: "github.com", Owner: "org", Name: "api"}
+	webRepo = github.Repository{Host: "github.com", Owner: "org", Name: "web"}
+)
+
+func newWorkspaceTestApp() (*App, *github.MockClient) {
+	mock := newMockClient(&mockClientState{
+		workflows: []github.Workflow{{ID: 1, Name: "CI"}, {ID: 2, Name: "Deploy"}},
+	})
+	app := New(
+		WithClient(mock),
+		WithRepository(apiRepo),
+		WithWorkspace([]github.Repository{apiRepo, webRepo}),
+	)
+	app.width, app.height = 120, 40
+	return app, mock
+}
+
+func TestApp_ReposPane_Shown(t *testing.T) {
+	t.Run("workspace", func(t *testing.T) {
+		app, _ := newWorkspaceTestApp()
+		if got := app.leftPanes(); len(got) != 4 || got[0] != ReposPane {
+			t.Errorf("leftPanes() = %v, want Repositories first", got)
+		}
+		if r, _ := app.repos.Selected(); r != apiRepo {
+			t.Errorf("selected repo = %+v, want the current one", r)
+		}
+		view := app.View()
+		if !strings.Contains(view, "Repositories") || !strings.Contains(view, "org/web") {
+			t.Errorf("view should show the Repositories pane:\n%s", view)
+		}
+	})
+
+	t.Run("single repository", func(t *testing.T) {
+		app := New(WithRepository(apiRepo), WithWorkspace([]github.Repository{apiRepo}))
+		app.width, app.height = 120, 40
+		if got := app.leftPanes(); len(got) != NumLeftPanels {
+			t.Errorf("leftPanes() = %v, want %d panes", got, NumLeftPanels)
+		}
+		if strings.Contains(app.View(), "Repositories") {
+			t.Error("Repositories pane should be hidden for a single repository")
+		}
+	})
+}
+
+func TestApp_SwitchRepository_RestoresView(t *testing.T) {
+	app, mock := newWorkspaceTestApp()
+	app.workflows.SetItems([]github.Workflow{{ID: 1, Name: "CI"}, {ID: 2, Name: "Deploy"}})
+	app.workflows.SetFilter("dep")
+	app.runs.SetItems([]github.Run{{ID: 10}})
+
+	app.focusedPane = ReposPane
+	cmd := app.handleKeyPress(tea.KeyMsg{Type: tea.KeyDown})
+	if app.repo != webRepo {
+		t.Fatalf("repo = %+v, want %+v", app.repo, webRepo)
+	}
+	if app.workflows.Len() != 0 || app.runs.Len() != 0 || app.workflows.filter != "" {
+		t.Error("a repository shown for the first time should start empty")
+	}
+	if cmd == nil {
+		t.Fatal("switching should reload the workflows")
+	}
+	app.Update(app.fetchWorkflowsCmd()())
+	calls := mock.ListWorkflowsCalls()
+	if calls[len(calls)-1].Repo != webRepo {
+		t.Errorf("workflows should be loaded from %s", webRepo.FullName())
+	}
+
+	app.handleKeyPress(tea.KeyMsg{Type: tea.KeyUp})
+	if app.repo != apiRepo {
+		t.Fatalf("repo = %+v, want %+v", app.repo, apiRepo)
+	}
+	if app.workflows.filter != "dep" {
+		t.Errorf("filter = %q, want it restored", app.workflows.filter)
+	}
+	if wf, _ := app.workflows.Selected(); wf.ID != 2 {
+		t.Errorf("selected workflow = %d, want 2", wf.ID)
+	}
+	if app.runs.Len() != 1 {
+		t.Errorf("runs = %d, want them restored", app.runs.Len())
+	}
+}
+
+func TestApp_RepoStatusLoaded(t *testing.T) {
+	app, _ := newWorkspaceTestApp()
+	run := github.Run{ID: 1, Status: "completed", Conclusion: "failure"}
+
+	app.Update(RepoStatusLoadedMsg{Repo: webRepo, Run: &run})
+	if got := app.repoRuns[webRepo]; got.ID != 1 {
+		t.Errorf("repoRuns[web] = %+v, want run 1", got)
+	}
+	if !strings.Contains(app.View(), StatusIcon("completed", "failure")+" org/web") {
+		t.Error("Repositories pane should show the status of the latest run")
+	}
+
+	app.Update(RepoStatusLoadedMsg{Repo: webRepo, Err: errAPI})
+	if _, ok := app.repoRuns[webRepo]; !ok {
+		t.Error("an error should keep the last known status")
+	}
+	if app.err != nil {
+		t.Error("status errors should not be shown")
+	}
+}
+
+func TestApp_RefreshRepoStatuses(t *testing.T) {
+	app, mock := newWorkspaceTestApp()
+	cmd := app.refreshRepoStatuses()
+	if cmd == nil {
+		t.Fatal("should load the status of every repository")
+	}
+	for _, msg := range cmd().(tea.BatchMsg) {
+		app.Update(msg())
+	}
+	if n := len(mock.ListRunsCalls()); n != 2 {
+		t.Errorf("ListRuns calls = %d, want 2", n)
+	}
+
+	if app.refreshRepoStatuses() != nil {
+		t.Error("statuses should not be reloaded within RepoStatusInterval")
+	}
+}
+
+func TestApp_ClickReposPane(t *testing.T) {
+	app, _ := newWorkspaceTestApp()
+	app.View()
+
+	// Second item of the first pane
+	app.handleClick(10, 3)
+	if app.focusedPane != ReposPane {
+		t.Errorf("focusedPane = %v, want ReposPane", app.focusedPane)
+	}
+	if app.repo != webRepo {
+		t.Errorf("repo = %+v, want %+v", app.repo, webRepo)
+	}
+}
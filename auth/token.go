@@ -4,6 +4,7 @@ package auth
 
 import (
 	"errors"
+	"fmt"
 	"os"
 	"os/exec"
 	"regexp"
@@ -14,6 +15,13 @@ import (
 // It can be replaced in tests for mocking.
 var ghCLIFunc = getFromGhCLI
 
+// ghCLIHostFunc is the function used to get a host-specific token from gh CLI.
+// It can be replaced in tests for mocking.
+var ghCLIHostFunc = getFromGhCLIForHost
+
+// defaultHost is the host of github.com repositories.
+const defaultHost = "github.com"
+
 // legacyTokenPattern matches the 40-character hexadecimal legacy token format.
 var legacyTokenPattern = regexp.MustCompile(`^[0-9a-fA-F]{40}$`)
 
@@ -80,6 +88,39 @@ func GetToken() (SecureToken, error) {
 	return SecureToken{}, errors.New("no authentication token found: run 'gh auth login' or set GITHUB_TOKEN")
 }
 
+// GetTokenForHost retrieves a token for the given GitHub host.
+// github.com (or an empty host) is resolved by GetToken. For a GitHub Enterprise
+// Server host the priority is:
+//  1. gh CLI (gh auth token --hostname <host>)
+//  2. GH_ENTERPRISE_TOKEN environment variable
+//  3. GITHUB_ENTERPRISE_TOKEN environment variable
+//
+// Returns an error if no valid token is found.
+func GetTokenForHost(host string) (SecureToken, error) {
+	hostname := host
+	if i := strings.Index(hostname, "://"); i >= 0 {
+		hostname = hostname[i+3:]
+	}
+	hostname = strings.TrimSuffix(hostname, "/")
+	if hostname == "" || strings.EqualFold(hostname, defaultHost) {
+		return GetToken()
+	}
+
+	// 1. Try gh CLI for the host first (highest priority)
+	if token, err := ghCLIHostFunc(hostname); err == nil && token != "" {
+		return NewSecureToken(token)
+	}
+
+	// 2. Fall back to enterprise environment variables
+	for _, env := range []string{"GH_ENTERPRISE_TOKEN", "GITHUB_ENTERPRISE_TOKEN"} {
+		if token := os.Getenv(env); token != "" {
+			return NewSecureToken(token)
+		}
+	}
+
+	return SecureToken{}, fmt.Errorf("no authentication token found for %s: run 'gh auth login --hostname %s' or set GH_ENTERPRISE_TOKEN", hostname, hostname)
+}
+
 // getFromGhCLI retrieves the GitHub token from the gh CLI.
 // It runs "gh auth token" and returns the output.
 func getFromGhCLI() (string, error) {
@@ -91,6 +132,17 @@ func getFromGhCLI() (string, error) {
 	return strings.TrimSpace(string(out)), nil
 }
 
+// getFromGhCLIForHost retrieves the token for a specific host from the gh CLI.
+// It runs "gh auth token --hostname <host>" and returns the output.
+func getFromGhCLIForHost(host string) (string, error) {
+	cmd := exec.Command("gh", "auth", "token", "--hostname", host)
+	out, err := cmd.Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
 // isValidGitHubTokenFormat checks if the token has a valid GitHub token format.
 // Valid formats include:
 //   - ghp_* (classic personal access token)
@@ -0,0 +1,279 @@
+// Package cli implements the non-interactive lazyactions subcommands.
+// Every command runs against the same github.Client as the TUI and prints
+// either an aligned table or JSON (--json) to stdout.
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/nnnkkk7/lazyactions/github"
+)
+
+// Exit codes returned by ExitCode.
+// API failures map to the github.ErrorType of the underlying AppError.
+const (
+	ExitOK        = 0
+	ExitError     = 1
+	ExitNetwork   = 3
+	ExitAuth      = 4
+	ExitRateLimit = 5
+	ExitNotFound  = 6
+	ExitServer    = 7
+	ExitUsage     = 8 // 2 is a cancelled run, see WatchExitCancelled
+)
+
+// UsageError is returned when a command is invoked with invalid arguments.
+type UsageError struct {
+	Message string
+}
+
+// Error implements the error interface.
+func (e *UsageError) Error() string {
+	return e.Message
+}
+
+// usageErrorf creates a UsageError with a formatted message
+func usageErrorf(format string, args ...interface{}) error {
+	return &UsageError{Message: fmt.Sprintf(format, args...)}
+}
+
+// ExitCode returns the process exit code for an error returned by Run.
+func ExitCode(err error) int {
+	if err == nil || errors.Is(err, flag.ErrHelp) {
+		return ExitOK
+	}
+
+	var conclusionErr *ConclusionError
+	if errors.As(err, &conclusionErr) {
+		return conclusionErr.Code
+	}
+
+	var usageErr *UsageError
+	if errors.As(err, &usageErr) {
+		return ExitUsage
+	}
+
+	var appErr *github.AppError
+	if errors.As(err, &appErr) {
+		switch appErr.Type {
+		case github.ErrTypeNetwork:
+			return ExitNetwork
+		case github.ErrTypeAuth:
+			return ExitAuth
+		case github.ErrTypeRateLimit:
+			return ExitRateLimit
+		case github.ErrTypeNotFound:
+			return ExitNotFound
+		case github.ErrTypeServer:
+			return ExitServer
+		}
+	}
+	return ExitError
+}
+
+// Usage lines of the subcommands
+const (
+	workflowsUsage = "workflows [--json]"
+	runsUsage      = "runs [--workflow <id|file|name>] [--branch <branch>] [--status <status>] [--event <event>] [--actor <login>] [--created <range>] [--limit <n>] [--json]"
+	jobsUsage      = "jobs <run-id> [--json]"
+	logsUsage      = "logs <job-id> [--step <n>] [--json]"
+	rerunUsage     = "rerun <run-id> [--failed] [--json]"
+	cancelUsage    = "cancel <run-id> [--json]"
+	triggerUsage   = "trigger <id|file|name> [--ref <ref>] [--input key=value]... [--json]"
+	watchUsage     = "watch [<run-id>|<run-url>] [--interval <duration>] [--fail-fast] [--tail <n>] [--json]"
+)
+
+// command is a single subcommand
+type command struct {
+	summary string
+	usage   string
+	run     func(c *CLI, ctx context.Context, args []string) error
+}
+
+// commands maps subcommand names to their implementation
+var commands = map[string]command{
+	"workflows": {
+		summary: "List workflows",
+		usage:   workflowsUsage,
+		run:     (*CLI).runWorkflows,
+	},
+	"runs": {
+		summary: "List workflow runs",
+		usage:   runsUsage,
+		run:     (*CLI).runRuns,
+	},
+	"jobs": {
+		summary: "List the jobs of a run",
+		usage:   jobsUsage,
+		run:     (*CLI).runJobs,
+	},
+	"logs": {
+		summary: "Print the logs of a job",
+		usage:   logsUsage,
+		run:     (*CLI).runLogs,
+	},
+	"rerun": {
+		summary: "Rerun a workflow run",
+		usage:   rerunUsage,
+		run:     (*CLI).runRerun,
+	},
+	"cancel": {
+		summary: "Cancel a workflow run",
+		usage:   cancelUsage,
+		run:     (*CLI).runCancel,
+	},
+	"trigger": {
+		summary: "Trigger a workflow_dispatch workflow",
+		usage:   triggerUsage,
+		run:     (*CLI).runTrigger,
+	},
+	"watch": {
+		summary: "Wait for a run to finish and exit with its conclusion",
+		usage:   watchUsage,
+		run:     (*CLI).runWatch,
+	},
+}
+
+// IsCommand returns true if name is a subcommand.
+func IsCommand(name string) bool {
+	_, ok := commands[name]
+	return ok
+}
+
+// Usage writes the list of subcommands to w.
+func Usage(w io.Writer) {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintln(w, "Commands:")
+	for _, name := range names {
+		fmt.Fprintf(w, "  %-10s %s\n", name, commands[name].summary)
+	}
+	fmt.Fprintln(w, "\nRun 'lazyactions <command> --help' for the flags of a command.")
+}
+
+// CLI runs subcommands against a single repository.
+type CLI struct {
+	client  github.Client
+	repo    github.Repository
+	headSHA string
+	stdout  io.Writer
+	stderr  io.Writer
+}
+
+// Option is a functional option for CLI
+type Option func(*CLI)
+
+// WithOutput sets the writers for command output and diagnostics
+func WithOutput(stdout, stderr io.Writer) Option {
+	return func(c *CLI) {
+		c.stdout = stdout
+		c.stderr = stderr
+	}
+}
+
+// WithHeadSHA sets the commit checked out locally, watched when no run is given
+func WithHeadSHA(sha string) Option {
+	return func(c *CLI) {
+		c.headSHA = sha
+	}
+}
+
+// New creates a new CLI for repo.
+func New(client github.Client, repo github.Repository, opts ...Option) *CLI {
+	c := &CLI{
+		client: client,
+		repo:   repo,
+		stdout: os.Stdout,
+		stderr: os.Stderr,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Run runs the subcommand named by args[0] with the remaining arguments.
+func (c *CLI) Run(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		Usage(c.stderr)
+		return usageErrorf("no command given")
+	}
+	cmd, ok := commands[args[0]]
+	if !ok {
+		Usage(c.stderr)
+		return usageErrorf("unknown command %q", args[0])
+	}
+	return cmd.run(c, ctx, args[1:])
+}
+
+// newFlagSet creates the flag set of a subcommand, with the shared --json flag
+func (c *CLI) newFlagSet(name, usage string, asJSON *bool) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(c.stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(c.stderr, "Usage: lazyactions %s\n", usage)
+		fs.PrintDefaults()
+	}
+	fs.BoolVar(asJSON, "json", false, "print JSON instead of a table")
+	return fs
+}
+
+// parseArgs parses flags that may appear before or after positional arguments
+// and returns the positional arguments. Unless want is negative, it checks
+// that exactly want were given.
+func parseArgs(fs *flag.FlagSet, args []string, want int) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return nil, err
+			}
+			return nil, &UsageError{Message: err.Error()}
+		}
+		if fs.NArg() == 0 {
+			break
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+
+	if want >= 0 && len(positional) != want {
+		fs.Usage()
+		return nil, usageErrorf("%s: expected %d argument(s), got %d", fs.Name(), want, len(positional))
+	}
+	return positional, nil
+}
+
+// inputsFlag collects repeated --input key=value flags
+type inputsFlag map[string]string
+
+// String implements flag.Value
+func (f inputsFlag) String() string {
+	pairs := make([]string, 0, len(f))
+	for k, v := range f {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// Set implements flag.Value
+func (f inputsFlag) Set(value string) error {
+	k, v, ok := strings.Cut(value, "=")
+	if !ok || k == "" {
+		return fmt.Errorf("input must be key=value, got %q", value)
+	}
+	f[k] = v
+	return nil
+}
@@ -0,0 +1,385 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/nnnkkk7/lazyactions/app"
+	"github.com/nnnkkk7/lazyactions/github"
+)
+
+// maxRetries is the number of retries for transient API errors
+const maxRetries = 3
+
+// retry runs fn with backoff on transient errors (rate limits, server and network errors).
+// Requests that are not idempotent, such as rerunning or cancelling a run and
+// triggering a workflow, are sent once instead, as one that failed may still
+// have been accepted.
+func retry(ctx context.Context, fn func() error) error {
+	return github.RetryWithBackoff(ctx, maxRetries, fn)
+}
+
+// runWorkflows implements "lazyactions workflows"
+func (c *CLI) runWorkflows(ctx context.Context, args []string) error {
+	var asJSON bool
+	fs := c.newFlagSet("workflows", workflowsUsage, &asJSON)
+	if _, err := parseArgs(fs, args, 0); err != nil {
+		return err
+	}
+
+	var workflows github.List[github.Workflow]
+	err := retry(ctx, func() error {
+		var e error
+		workflows, e = c.client.ListWorkflows(ctx, c.repo)
+		return e
+	})
+	if err != nil {
+		return err
+	}
+	c.warnTruncated("workflows", len(workflows.Items), workflows.Total)
+
+	if asJSON {
+		return c.printJSON(workflows.Items)
+	}
+	rows := make([][]string, 0, len(workflows.Items))
+	for _, wf := range workflows.Items {
+		rows = append(rows, []string{strconv.FormatInt(wf.ID, 10), wf.Name, wf.State, wf.Path})
+	}
+	return c.printTable([]string{"ID", "NAME", "STATE", "PATH"}, rows)
+}
+
+// runRuns implements "lazyactions runs"
+func (c *CLI) runRuns(ctx context.Context, args []string) error {
+	var asJSON bool
+	var workflow string
+	opts := &github.ListRunsOpts{}
+	fs := c.newFlagSet("runs", runsUsage, &asJSON)
+	fs.StringVar(&workflow, "workflow", "", "only runs of this workflow (ID, file name or name)")
+	fs.StringVar(&opts.Branch, "branch", "", "only runs on this branch")
+	fs.StringVar(&opts.Status, "status", "", "only runs with this status or conclusion (e.g. in_progress, failure)")
+	fs.StringVar(&opts.Event, "event", "", "only runs triggered by this event (e.g. push, pull_request)")
+	fs.StringVar(&opts.Actor, "actor", "", "only runs triggered by this user")
+	fs.StringVar(&opts.Created, "created", "", "only runs created in this date range (e.g. >=2024-01-01, 2024-01-01..2024-01-31)")
+	fs.IntVar(&opts.PerPage, "limit", 20, "maximum number of runs")
+	if _, err := parseArgs(fs, args, 0); err != nil {
+		return err
+	}
+	if opts.PerPage <= 0 || opts.PerPage > 100 {
+		return usageErrorf("--limit must be between 1 and 100")
+	}
+
+	if workflow != "" {
+		wf, err := c.resolveWorkflow(ctx, workflow)
+		if err != nil {
+			return err
+		}
+		opts.WorkflowID = wf.ID
+	}
+
+	var runs github.List[github.Run]
+	err := retry(ctx, func() error {
+		var e error
+		runs, e = c.client.ListRuns(ctx, c.repo, opts)
+		return e
+	})
+	if err != nil {
+		return err
+	}
+
+	if asJSON {
+		return c.printJSON(runs.Items)
+	}
+	rows := make([][]string, 0, len(runs.Items))
+	for _, r := range runs.Items {
+		rows = append(rows, []string{
+			strconv.FormatInt(r.ID, 10),
+			statusText(r.Status, r.Conclusion),
+			r.Name,
+			"#" + strconv.Itoa(r.RunNumber),
+			r.Branch,
+			r.Event,
+			r.Actor,
+			r.CreatedAt.Local().Format(timeFormat),
+		})
+	}
+	return c.printTable([]string{"ID", "STATUS", "WORKFLOW", "RUN", "BRANCH", "EVENT", "ACTOR", "CREATED"}, rows)
+}
+
+// runJobs implements "lazyactions jobs <run-id>"
+func (c *CLI) runJobs(ctx context.Context, args []string) error {
+	var asJSON bool
+	fs := c.newFlagSet("jobs", jobsUsage, &asJSON)
+	positional, err := parseArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	runID, err := parseID("run", positional[0])
+	if err != nil {
+		return err
+	}
+
+	var jobs github.List[github.Job]
+	err = retry(ctx, func() error {
+		var e error
+		jobs, e = c.client.ListJobs(ctx, c.repo, runID)
+		return e
+	})
+	if err != nil {
+		return err
+	}
+	c.warnTruncated("jobs", len(jobs.Items), jobs.Total)
+
+	if asJSON {
+		return c.printJSON(jobs.Items)
+	}
+	rows := make([][]string, 0, len(jobs.Items))
+	for _, j := range jobs.Items {
+		rows = append(rows, []string{strconv.FormatInt(j.ID, 10), statusText(j.Status, j.Conclusion), j.Name})
+	}
+	return c.printTable([]string{"ID", "STATUS", "NAME"}, rows)
+}
+
+// stepLogJSON is the JSON shape of a step printed by "lazyactions logs"
+type stepLogJSON struct {
+	Number int      `json:"number"`
+	Name   string   `json:"name"`
+	Lines  []string `json:"lines"`
+}
+
+// runLogs implements "lazyactions logs <job-id>".
+// Logs are sanitized and split into steps like in the TUI log view.
+func (c *CLI) runLogs(ctx context.Context, args []string) error {
+	var asJSON bool
+	var step int
+	fs := c.newFlagSet("logs", logsUsage, &asJSON)
+	fs.IntVar(&step, "step", 0, "only print step n (1-based, as listed in the TUI)")
+	positional, err := parseArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	jobID, err := parseID("job", positional[0])
+	if err != nil {
+		return err
+	}
+	if step < 0 {
+		return usageErrorf("--step must be positive")
+	}
+
+	var logs string
+	err = retry(ctx, func() error {
+		var e error
+		logs, e = c.client.GetJobLogs(ctx, c.repo, jobID)
+		return e
+	})
+	if err != nil {
+		return err
+	}
+	parsed := app.ParseLogs(github.SanitizeLogs(logs))
+	if step > len(parsed.Steps) {
+		return fmt.Errorf("step %d not found: job %d has %d step(s)", step, jobID, len(parsed.Steps))
+	}
+
+	if asJSON {
+		steps := make([]stepLogJSON, 0, len(parsed.Steps))
+		for i, s := range parsed.Steps {
+			if step > 0 && i != step-1 {
+				continue
+			}
+			steps = append(steps, stepLogJSON{Number: i + 1, Name: s.Name, Lines: s.Lines})
+		}
+		return c.printJSON(struct {
+			JobID int64         `json:"job_id"`
+			Steps []stepLogJSON `json:"steps"`
+		}{jobID, steps})
+	}
+
+	out := parsed.GetStepLogs(step - 1)
+	if out != "" && !strings.HasSuffix(out, "\n") {
+		out += "\n"
+	}
+	_, err = fmt.Fprint(c.stdout, out)
+	return err
+}
+
+// actionJSON is the JSON result of a run action (rerun, cancel)
+type actionJSON struct {
+	RunID  int64  `json:"run_id"`
+	Action string `json:"action"`
+}
+
+// runRerun implements "lazyactions rerun <run-id>"
+func (c *CLI) runRerun(ctx context.Context, args []string) error {
+	var asJSON, failed bool
+	fs := c.newFlagSet("rerun", rerunUsage, &asJSON)
+	fs.BoolVar(&failed, "failed", false, "only rerun failed jobs")
+	positional, err := parseArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	runID, err := parseID("run", positional[0])
+	if err != nil {
+		return err
+	}
+
+	action := "rerun"
+	rerun := c.client.RerunWorkflow
+	if failed {
+		action = "rerun_failed"
+		rerun = c.client.RerunFailedJobs
+	}
+	if err := rerun(ctx, c.repo, runID); err != nil {
+		return err
+	}
+
+	if asJSON {
+		return c.printJSON(actionJSON{RunID: runID, Action: action})
+	}
+	if failed {
+		return c.printf("Rerun of failed jobs requested for run %d\n", runID)
+	}
+	return c.printf("Rerun requested for run %d\n", runID)
+}
+
+// runCancel implements "lazyactions cancel <run-id>"
+func (c *CLI) runCancel(ctx context.Context, args []string) error {
+	var asJSON bool
+	fs := c.newFlagSet("cancel", cancelUsage, &asJSON)
+	positional, err := parseArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+	runID, err := parseID("run", positional[0])
+	if err != nil {
+		return err
+	}
+
+	if err := c.client.CancelRun(ctx, c.repo, runID); err != nil {
+		return err
+	}
+
+	if asJSON {
+		return c.printJSON(actionJSON{RunID: runID, Action: "cancel"})
+	}
+	return c.printf("Cancellation requested for run %d\n", runID)
+}
+
+// runTrigger implements "lazyactions trigger <workflow>".
+// Inputs are checked against the workflow's workflow_dispatch declaration at ref.
+func (c *CLI) runTrigger(ctx context.Context, args []string) error {
+	var asJSON bool
+	var ref string
+	inputs := inputsFlag{}
+	fs := c.newFlagSet("trigger", triggerUsage, &asJSON)
+	fs.StringVar(&ref, "ref", "", "branch, tag or SHA to run on (default: the repository's default branch)")
+	fs.Var(inputs, "input", "workflow input as key=value (repeatable)")
+	positional, err := parseArgs(fs, args, 1)
+	if err != nil {
+		return err
+	}
+
+	wf, err := c.resolveWorkflow(ctx, positional[0])
+	if err != nil {
+		return err
+	}
+
+	if ref == "" {
+		err = retry(ctx, func() error {
+			var e error
+			ref, e = c.client.GetDefaultBranch(ctx, c.repo)
+			return e
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	var content string
+	err = retry(ctx, func() error {
+		var e error
+		content, e = c.client.GetFileContent(ctx, c.repo, wf.Path, ref)
+		return e
+	})
+	if err != nil {
+		return err
+	}
+	declared, err := github.ParseDispatchInputs([]byte(content))
+	if err != nil {
+		return fmt.Errorf("%s: %w", wf.Name, err)
+	}
+	if err := checkInputs(declared, inputs); err != nil {
+		return err
+	}
+
+	file := path.Base(wf.Path)
+	values := make(map[string]interface{}, len(inputs))
+	for k, v := range inputs {
+		values[k] = v
+	}
+	if err := c.client.TriggerWorkflow(ctx, c.repo, file, ref, values); err != nil {
+		return err
+	}
+
+	if asJSON {
+		return c.printJSON(struct {
+			Workflow string            `json:"workflow"`
+			Ref      string            `json:"ref"`
+			Inputs   map[string]string `json:"inputs"`
+		}{file, ref, inputs})
+	}
+	return c.printf("Triggered %s on %s\n", wf.Name, ref)
+}
+
+// checkInputs validates --input values against the declared workflow_dispatch inputs
+func checkInputs(declared []github.WorkflowInput, inputs map[string]string) error {
+	known := make(map[string]bool, len(declared))
+	for _, in := range declared {
+		known[in.Name] = true
+		if _, ok := inputs[in.Name]; !ok && in.Required && in.Default == "" {
+			return usageErrorf("missing required input %q", in.Name)
+		}
+	}
+	for name := range inputs {
+		if !known[name] {
+			return usageErrorf("unknown input %q", name)
+		}
+	}
+	return nil
+}
+
+// resolveWorkflow finds a workflow by ID, file name, path or name (case-insensitive)
+func (c *CLI) resolveWorkflow(ctx context.Context, ident string) (github.Workflow, error) {
+	var workflows github.List[github.Workflow]
+	err := retry(ctx, func() error {
+		var e error
+		workflows, e = c.client.ListWorkflows(ctx, c.repo)
+		return e
+	})
+	if err != nil {
+		return github.Workflow{}, err
+	}
+
+	id, _ := strconv.ParseInt(ident, 10, 64)
+	for _, wf := range workflows.Items {
+		if wf.ID == id || wf.Path == ident || path.Base(wf.Path) == ident || strings.EqualFold(wf.Name, ident) {
+			return wf, nil
+		}
+	}
+	return github.Workflow{}, &github.AppError{
+		Type:    github.ErrTypeNotFound,
+		Message: fmt.Sprintf("workflow %q not found", ident),
+		Cause:   errors.New("no matching workflow"),
+	}
+}
+
+// parseID parses a numeric run or job ID
+func parseID(kind, s string) (int64, error) {
+	id, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || id <= 0 {
+		return 0, usageErrorf("invalid %s ID %q", kind, s)
+	}
+	return id, nil
+}
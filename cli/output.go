@@ -0,0 +1,70 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/nnnkkk7/lazyactions/app"
+)
+
+// timeFormat is the layout of timestamps in tables
+const timeFormat = "2006-01-02 15:04"
+
+// columnGap is the number of spaces between table columns
+const columnGap = 2
+
+// printJSON writes v as indented JSON
+func (c *CLI) printJSON(v interface{}) error {
+	enc := json.NewEncoder(c.stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+// printf writes formatted text to stdout
+func (c *CLI) printf(format string, args ...interface{}) error {
+	_, err := fmt.Fprintf(c.stdout, format, args...)
+	return err
+}
+
+// warnTruncated notes on stderr that a listing stopped at the page bound
+func (c *CLI) warnTruncated(kind string, loaded, total int) {
+	if total > loaded {
+		fmt.Fprintf(c.stderr, "Showing %d of %d %s\n", loaded, total, kind)
+	}
+}
+
+// printTable writes rows as left-aligned columns under header.
+// Widths are measured with lipgloss so styled cells stay aligned.
+func (c *CLI) printTable(header []string, rows [][]string) error {
+	widths := make([]int, len(header))
+	for _, row := range append([][]string{header}, rows...) {
+		for i, cell := range row {
+			widths[i] = max(widths[i], lipgloss.Width(cell))
+		}
+	}
+
+	var b strings.Builder
+	for _, row := range append([][]string{header}, rows...) {
+		for i, cell := range row {
+			b.WriteString(cell)
+			if i < len(row)-1 {
+				b.WriteString(strings.Repeat(" ", widths[i]-lipgloss.Width(cell)+columnGap))
+			}
+		}
+		b.WriteString("\n")
+	}
+	_, err := fmt.Fprint(c.stdout, b.String())
+	return err
+}
+
+// statusText returns the status icon followed by the conclusion of a completed
+// run or job, or its status otherwise
+func statusText(status, conclusion string) string {
+	text := status
+	if status == "completed" && conclusion != "" {
+		text = conclusion
+	}
+	return strings.TrimSpace(app.StatusIcon(status, conclusion) + " " + text)
+}
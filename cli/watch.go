@@ -0,0 +1,386 @@
+package cli
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nnnkkk7/lazyactions/app"
+	"github.com/nnnkkk7/lazyactions/github"
+)
+
+// Watch constants
+const (
+	// DefaultWatchInterval is the initial delay between polls
+	DefaultWatchInterval = 3 * time.Second
+	// MaxWatchInterval caps the delay between polls while nothing changes
+	MaxWatchInterval = 30 * time.Second
+	// RunWaitTimeout is how long watch waits for a run of HEAD to appear
+	RunWaitTimeout = 2 * time.Minute
+	// DefaultLogTail is the number of log lines printed for a failed step
+	DefaultLogTail = 20
+)
+
+// Exit codes of watch, by run conclusion
+const (
+	WatchExitSuccess   = 0
+	WatchExitFailure   = 1
+	WatchExitCancelled = 2
+)
+
+// ConclusionError ends watch with the exit code of the run conclusion.
+type ConclusionError struct {
+	Code    int
+	Message string
+}
+
+// Error implements the error interface.
+func (e *ConclusionError) Error() string {
+	return e.Message
+}
+
+// runURLPattern matches the owner, repository and ID in a run URL
+// (e.g. https://github.com/owner/repo/actions/runs/123/job/456)
+var runURLPattern = regexp.MustCompile(`/([^/]+)/([^/]+)/actions/runs/(\d+)`)
+
+// runWatch implements "lazyactions watch [<run-id>|<run-url>]".
+// Without an argument it watches the latest run of the HEAD commit.
+func (c *CLI) runWatch(ctx context.Context, args []string) error {
+	var asJSON, failFast bool
+	var interval time.Duration
+	var tail int
+	fs := c.newFlagSet("watch", watchUsage, &asJSON)
+	fs.DurationVar(&interval, "interval", DefaultWatchInterval, "initial delay between polls")
+	fs.BoolVar(&failFast, "fail-fast", false, "stop as soon as any job fails")
+	fs.IntVar(&tail, "tail", DefaultLogTail, "log lines to print for a failed step")
+
+	positional, err := parseArgs(fs, args, -1)
+	if err != nil {
+		return err
+	}
+	if len(positional) > 1 {
+		fs.Usage()
+		return usageErrorf("watch: expected at most 1 argument, got %d", len(positional))
+	}
+	if interval <= 0 {
+		return usageErrorf("--interval must be positive")
+	}
+
+	repo := c.repo
+	var runID int64
+	if len(positional) == 1 {
+		repo, runID, err = c.parseRunRef(positional[0])
+		if err != nil {
+			return err
+		}
+	} else {
+		runID, err = c.waitForHeadRun(ctx, interval)
+		if err != nil {
+			return err
+		}
+	}
+
+	w := &watcher{
+		cli:         c,
+		repo:        repo,
+		runID:       runID,
+		quiet:       asJSON,
+		interactive: isTerminal(c.stdout),
+	}
+	run, jobs, err := w.watch(ctx, interval, failFast)
+	if err != nil {
+		return err
+	}
+
+	failed := failedJobs(jobs)
+	if asJSON {
+		if err := c.printJSON(struct {
+			Run  github.Run   `json:"run"`
+			Jobs []github.Job `json:"jobs"`
+		}{run, jobs}); err != nil {
+			return err
+		}
+	} else if len(failed) > 0 {
+		c.printFailedSteps(ctx, repo, failed, tail)
+	}
+
+	switch {
+	case len(failed) > 0 && run.Status != "completed":
+		return &ConclusionError{Code: WatchExitFailure, Message: fmt.Sprintf("run %d has failed jobs", run.ID)}
+	case run.Conclusion == "cancelled":
+		return &ConclusionError{Code: WatchExitCancelled, Message: fmt.Sprintf("run %d was cancelled", run.ID)}
+	case run.Conclusion == "success" || run.Conclusion == "neutral" || run.Conclusion == "skipped":
+		return nil
+	default:
+		return &ConclusionError{Code: WatchExitFailure, Message: fmt.Sprintf("run %d concluded with %s", run.ID, run.Conclusion)}
+	}
+}
+
+// parseRunRef parses a run ID or a run URL. A URL may point to another
+// repository on the same host.
+func (c *CLI) parseRunRef(ref string) (github.Repository, int64, error) {
+	if m := runURLPattern.FindStringSubmatch(ref); m != nil {
+		host := cmp.Or(c.repo.Host, "github.com")
+		if u, err := url.Parse(ref); err == nil && u.Host != "" && !strings.EqualFold(u.Host, host) {
+			return c.repo, 0, usageErrorf("watch: %s is not a run on %s", ref, host)
+		}
+		id, _ := strconv.ParseInt(m[3], 10, 64)
+		repo := github.Repository{Host: c.repo.Host, Owner: m[1], Name: m[2]}
+		return repo, id, nil
+	}
+	id, err := parseID("run", ref)
+	return c.repo, id, err
+}
+
+// waitForHeadRun returns the latest run of the HEAD commit, waiting up to
+// RunWaitTimeout for one to be created after a push
+func (c *CLI) waitForHeadRun(ctx context.Context, interval time.Duration) (int64, error) {
+	if c.headSHA == "" {
+		return 0, usageErrorf("no run given and the HEAD commit is unknown")
+	}
+
+	deadline := time.Now().Add(RunWaitTimeout)
+	waiting := false
+	for {
+		var runs github.List[github.Run]
+		err := retry(ctx, func() error {
+			var e error
+			runs, e = c.client.ListRuns(ctx, c.repo, &github.ListRunsOpts{HeadSHA: c.headSHA, PerPage: 1})
+			return e
+		})
+		if err != nil {
+			return 0, err
+		}
+		if len(runs.Items) > 0 {
+			return runs.Items[0].ID, nil
+		}
+		if time.Now().After(deadline) {
+			return 0, &github.AppError{
+				Type:    github.ErrTypeNotFound,
+				Message: fmt.Sprintf("no run found for commit %s", shortSHA(c.headSHA)),
+			}
+		}
+		if !waiting {
+			fmt.Fprintf(c.stderr, "Waiting for a run of %s...\n", shortSHA(c.headSHA))
+			waiting = true
+		}
+		if err := sleep(ctx, interval); err != nil {
+			return 0, err
+		}
+	}
+}
+
+// watcher polls a run and renders its jobs until the run completes
+type watcher struct {
+	cli         *CLI
+	repo        github.Repository
+	runID       int64
+	quiet       bool // only the final result is printed (--json)
+	interactive bool // redraw in place instead of printing each change
+
+	lastFrame string
+}
+
+// watch polls until the run completes, or until a job fails with failFast.
+// The delay between polls doubles while nothing changes, up to MaxWatchInterval.
+func (w *watcher) watch(ctx context.Context, interval time.Duration, failFast bool) (github.Run, []github.Job, error) {
+	delay := interval
+	for {
+		run, jobs, err := w.poll(ctx)
+		if err != nil {
+			return run, jobs, err
+		}
+
+		changed := w.draw(renderWatch(run, jobs))
+		if run.Status == "completed" || (failFast && len(failedJobs(jobs)) > 0) {
+			return run, jobs, nil
+		}
+
+		if changed {
+			delay = interval
+		} else {
+			delay = min(delay*2, MaxWatchInterval)
+		}
+		if err := sleep(ctx, delay); err != nil {
+			return run, jobs, err
+		}
+	}
+}
+
+// poll fetches the run and its jobs, retrying transient errors
+func (w *watcher) poll(ctx context.Context) (github.Run, []github.Job, error) {
+	c := w.cli
+	var run github.Run
+	err := retry(ctx, func() error {
+		var e error
+		run, e = c.client.GetRun(ctx, w.repo, w.runID)
+		return e
+	})
+	if err != nil {
+		return run, nil, err
+	}
+
+	var jobs github.List[github.Job]
+	err = retry(ctx, func() error {
+		var e error
+		jobs, e = c.client.ListJobs(ctx, w.repo, w.runID)
+		return e
+	})
+	return run, jobs.Items, err
+}
+
+// draw prints frame if it differs from the previous one and reports whether it changed.
+// On a terminal the previous frame is replaced in place.
+func (w *watcher) draw(frame string) bool {
+	if frame == w.lastFrame {
+		return false
+	}
+	if w.quiet {
+		w.lastFrame = frame
+		return true
+	}
+	if w.interactive && w.lastFrame != "" {
+		// Move to the start of the previous frame and clear it
+		fmt.Fprintf(w.cli.stdout, "\x1b[%dA\x1b[J", strings.Count(w.lastFrame, "\n"))
+	}
+	fmt.Fprint(w.cli.stdout, frame)
+	w.lastFrame = frame
+	return true
+}
+
+// renderWatch renders a compact view of a run: one line per job, with the
+// steps of running and failed jobs underneath
+func renderWatch(run github.Run, jobs []github.Job) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s #%d · %s on %s\n", app.StatusIcon(run.Status, run.Conclusion), run.Name, run.RunNumber, run.Event, run.Branch)
+	for _, j := range jobs {
+		fmt.Fprintf(&b, "  %s %s\n", app.StatusIcon(j.Status, j.Conclusion), j.Name)
+		if !j.IsRunning() && j.Conclusion != "failure" {
+			continue
+		}
+		for _, s := range j.Steps {
+			fmt.Fprintf(&b, "      %s %s\n", app.StatusIcon(s.Status, s.Conclusion), s.Name)
+		}
+	}
+	return b.String()
+}
+
+// printFailedSteps prints the failed step and the log lines leading up to
+// its error for each failed job
+func (c *CLI) printFailedSteps(ctx context.Context, repo github.Repository, jobs []github.Job, tail int) {
+	for _, j := range jobs {
+		var failed github.Step
+		for _, s := range j.Steps {
+			if s.Conclusion == "failure" {
+				failed = s
+				break
+			}
+		}
+		fmt.Fprintf(c.stdout, "\n%s %s › %s\n", app.StatusIcon("completed", "failure"), j.Name, cmp.Or(failed.Name, "unknown step"))
+
+		var logs string
+		err := retry(ctx, func() error {
+			var e error
+			logs, e = c.client.GetJobLogs(ctx, repo, j.ID)
+			return e
+		})
+		if err != nil {
+			fmt.Fprintf(c.stdout, "  (logs unavailable: %v)\n", err)
+			continue
+		}
+		lines := failedStepLines(app.ParseJobLogs(github.SanitizeLogs(logs), j.Steps), j.Steps, failed)
+		for _, line := range logTail(lines, tail) {
+			fmt.Fprintln(c.stdout, "  "+line)
+		}
+	}
+}
+
+// failedStepLines returns the log lines of the failed step of a job: those
+// of the step of the logs showing it, or else of the step of the last
+// ##[error] line, or else all of them
+func failedStepLines(parsed *app.ParsedLogs, steps []github.Step, failed github.Step) []string {
+	if failed.Number != 0 {
+		for i, s := range parsed.Steps {
+			if step, ok := app.MatchJobStep(steps, i, s.Name); ok && step.Number == failed.Number {
+				return s.Lines
+			}
+		}
+	}
+	for i := len(parsed.AllLines) - 1; i >= 0; i-- {
+		if !strings.Contains(parsed.AllLines[i], "##[error]") {
+			continue
+		}
+		for _, s := range parsed.Steps {
+			if i >= s.StartLine && i <= s.EndLine {
+				return s.Lines
+			}
+		}
+		break
+	}
+	return parsed.AllLines
+}
+
+// logTail returns up to n lines ending at the last ##[error] line,
+// or the last n lines if there is no error marker
+func logTail(lines []string, n int) []string {
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	end := len(lines)
+	for i := len(lines) - 1; i >= 0; i-- {
+		if strings.Contains(lines[i], "##[error]") {
+			end = i + 1
+			break
+		}
+	}
+	start := max(end-n, 0)
+	return lines[start:end]
+}
+
+// failedJobs returns the jobs that concluded with failure
+func failedJobs(jobs []github.Job) []github.Job {
+	var failed []github.Job
+	for _, j := range jobs {
+		if j.Conclusion == "failure" {
+			failed = append(failed, j)
+		}
+	}
+	return failed
+}
+
+// sleep waits for d or until ctx is done
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// shortSHA abbreviates a commit SHA for display
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
+
+// isTerminal returns true if w is a terminal
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	fi, err := f.Stat()
+	return err == nil && fi.Mode()&os.ModeCharDevice != 0
+}
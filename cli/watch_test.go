@@ -0,0 +1,259 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/nnnkkk7/lazyactions/github"
+)
+
+// newWatchMock returns a mock whose run goes through the given states,
+// staying in the last one. Jobs follow the same sequence.
+func newWatchMock(runs []github.Run, jobs [][]github.Job) *github.MockClient {
+	mock := newTestMock()
+	polls := 0
+	mock.GetRunFunc = func(ctx context.Context, repo github.Repository, runID int64) (github.Run, error) {
+		run := runs[min(polls, len(runs)-1)]
+		run.ID = runID
+		return run, nil
+	}
+	mock.ListJobsFunc = func(ctx context.Context, repo github.Repository, runID int64) (github.List[github.Job], error) {
+		j := jobs[min(polls, len(jobs)-1)]
+		polls++
+		return github.List[github.Job]{Items: j, Total: len(j)}, nil
+	}
+	mock.GetJobLogsFunc = func(ctx context.Context, repo github.Repository, jobID int64) (string, error) {
+		return "##[group]Test\nrunning tests\nFAIL TestFoo\n##[error]Process completed with exit code 1.\n##[endgroup]\nPost job cleanup", nil
+	}
+	return mock
+}
+
+func TestWatch_Conclusions(t *testing.T) {
+	running := github.Run{Name: "CI", RunNumber: 3, Status: "in_progress", Event: "push", Branch: "main"}
+	inProgress := []github.Job{{ID: 1, Name: "build", Status: "in_progress", Steps: []github.Step{{Name: "Test", Status: "in_progress"}}}}
+	failed := []github.Job{{ID: 1, Name: "build", Status: "completed", Conclusion: "failure", Steps: []github.Step{{Name: "Test", Status: "completed", Conclusion: "failure"}}}}
+	succeeded := []github.Job{{ID: 1, Name: "build", Status: "completed", Conclusion: "success"}}
+
+	completed := func(conclusion string) github.Run {
+		r := running
+		r.Status = "completed"
+		r.Conclusion = conclusion
+		return r
+	}
+
+	tests := []struct {
+		name     string
+		runs     []github.Run
+		jobs     [][]github.Job
+		wantCode int
+		wantLog  bool
+	}{
+		{name: "success", runs: []github.Run{running, completed("success")}, jobs: [][]github.Job{inProgress, succeeded}, wantCode: WatchExitSuccess},
+		{name: "skipped", runs: []github.Run{completed("skipped")}, jobs: [][]github.Job{nil}, wantCode: WatchExitSuccess},
+		{name: "failure", runs: []github.Run{running, completed("failure")}, jobs: [][]github.Job{inProgress, failed}, wantCode: WatchExitFailure, wantLog: true},
+		{name: "timed out", runs: []github.Run{completed("timed_out")}, jobs: [][]github.Job{succeeded}, wantCode: WatchExitFailure},
+		{name: "cancelled", runs: []github.Run{running, completed("cancelled")}, jobs: [][]github.Job{inProgress, succeeded}, wantCode: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := newWatchMock(tt.runs, tt.jobs)
+			out, _, err := runCLI(t, mock, "watch", "42", "--interval", "1ms")
+			if got := ExitCode(err); got != tt.wantCode {
+				t.Fatalf("ExitCode() = %d, want %d (err = %v)", got, tt.wantCode, err)
+			}
+			if len(mock.GetRunCalls()) != len(tt.runs) {
+				t.Errorf("GetRun called %d times, want %d", len(mock.GetRunCalls()), len(tt.runs))
+			}
+			if !strings.Contains(out, "CI #3") {
+				t.Errorf("output should show the run:\n%s", out)
+			}
+			gotLog := strings.Contains(out, "build › Test") && strings.Contains(out, "FAIL TestFoo")
+			if gotLog != tt.wantLog {
+				t.Errorf("failed step log printed = %v, want %v:\n%s", gotLog, tt.wantLog, out)
+			}
+			if tt.wantLog && strings.Contains(out, "Post job cleanup") {
+				t.Errorf("log tail should end at the error line:\n%s", out)
+			}
+		})
+	}
+}
+
+func TestWatch_FailFast(t *testing.T) {
+	running := github.Run{Name: "CI", Status: "in_progress"}
+	jobs := []github.Job{
+		{ID: 1, Name: "lint", Status: "completed", Conclusion: "failure"},
+		{ID: 2, Name: "test", Status: "in_progress"},
+	}
+
+	t.Run("stops on first failed job", func(t *testing.T) {
+		mock := newWatchMock([]github.Run{running}, [][]github.Job{jobs})
+		_, _, err := runCLI(t, mock, "watch", "42", "--interval", "1ms", "--fail-fast")
+		if got := ExitCode(err); got != WatchExitFailure {
+			t.Fatalf("ExitCode() = %d, want %d", got, WatchExitFailure)
+		}
+		if len(mock.GetRunCalls()) != 1 {
+			t.Errorf("GetRun called %d times, want 1", len(mock.GetRunCalls()))
+		}
+	})
+
+	t.Run("keeps waiting without the flag", func(t *testing.T) {
+		done := running
+		done.Status = "completed"
+		done.Conclusion = "failure"
+		mock := newWatchMock([]github.Run{running, running, done}, [][]github.Job{jobs})
+		_, _, err := runCLI(t, mock, "watch", "42", "--interval", "1ms")
+		if got := ExitCode(err); got != WatchExitFailure {
+			t.Fatalf("ExitCode() = %d, want %d", got, WatchExitFailure)
+		}
+		if len(mock.GetRunCalls()) != 3 {
+			t.Errorf("GetRun called %d times, want 3", len(mock.GetRunCalls()))
+		}
+	})
+}
+
+func TestWatch_RunURL(t *testing.T) {
+	mock := newWatchMock([]github.Run{{Status: "completed", Conclusion: "success"}}, [][]github.Job{nil})
+	_, _, err := runCLI(t, mock, "watch", "https://github.com/other/project/actions/runs/123/job/456")
+	if err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	call := mock.GetRunCalls()[0]
+	if call.RunID != 123 || call.Repo.Owner != "other" || call.Repo.Name != "project" {
+		t.Errorf("GetRun(%+v, %d), want other/project run 123", call.Repo, call.RunID)
+	}
+}
+
+func TestWatch_FailedStepLog(t *testing.T) {
+	done := github.Run{Name: "CI", Status: "completed", Conclusion: "failure"}
+	jobs := []github.Job{{ID: 1, Name: "build", Status: "completed", Conclusion: "failure", Steps: []github.Step{
+		{Name: "Set up job", Status: "completed", Conclusion: "success", Number: 1},
+		{Name: "Vet", Status: "completed", Conclusion: "success", Number: 2},
+		{Name: "Lint", Status: "completed", Conclusion: "skipped", Number: 3},
+		{Name: "Test", Status: "completed", Conclusion: "failure", Number: 4},
+		{Name: "Complete job", Status: "completed", Conclusion: "success", Number: 5},
+	}}}
+	mock := newWatchMock([]github.Run{done}, [][]github.Job{jobs})
+	mock.GetJobLogsFunc = func(ctx context.Context, repo github.Repository, jobID int64) (string, error) {
+		return strings.Join([]string{
+			"Current runner version: '2.317.0'",
+			"##[group]Run go vet ./...",
+			"go vet ./...",
+			"##[endgroup]",
+			"vet passed",
+			"##[group]Run go test ./...",
+			"go test ./...",
+			"##[endgroup]",
+			"--- FAIL: TestFoo (0.01s)",
+			"##[error]Process completed with exit code 1.",
+			"Cleaning up orphan processes",
+		}, "\n"), nil
+	}
+
+	out, _, err := runCLI(t, mock, "watch", "42")
+	if got := ExitCode(err); got != WatchExitFailure {
+		t.Fatalf("ExitCode() = %d, want %d", got, WatchExitFailure)
+	}
+	if !strings.Contains(out, "build › Test") || !strings.Contains(out, "--- FAIL: TestFoo") {
+		t.Errorf("output should show the log of the failed step:\n%s", out)
+	}
+	if strings.Contains(out, "vet passed") || strings.Contains(out, "Cleaning up") {
+		t.Errorf("output should show only the lines of the failed step:\n%s", out)
+	}
+}
+
+func TestWatch_HeadSHA(t *testing.T) {
+	t.Run("latest run of HEAD", func(t *testing.T) {
+		mock := newWatchMock([]github.Run{{Status: "completed", Conclusion: "success"}}, [][]github.Job{nil})
+		var stdout, stderr bytes.Buffer
+		cli := New(mock, testRepo, WithOutput(&stdout, &stderr), WithHeadSHA("abc1234def"))
+		if err := cli.Run(context.Background(), []string{"watch", "--json"}); err != nil {
+			t.Fatalf("Run() error = %v", err)
+		}
+		if opts := mock.ListRunsCalls()[0].Opts; opts.HeadSHA != "abc1234def" {
+			t.Errorf("ListRuns HeadSHA = %q", opts.HeadSHA)
+		}
+		if mock.GetRunCalls()[0].RunID != 100 {
+			t.Errorf("watched run %d, want 100", mock.GetRunCalls()[0].RunID)
+		}
+
+		var got struct {
+			Run  github.Run   `json:"run"`
+			Jobs []github.Job `json:"jobs"`
+		}
+		if err := json.Unmarshal(stdout.Bytes(), &got); err != nil {
+			t.Fatalf("invalid JSON: %v\n%s", err, stdout.String())
+		}
+		if got.Run.ID != 100 || got.Run.Conclusion != "success" {
+			t.Errorf("run = %+v", got.Run)
+		}
+	})
+
+	t.Run("unknown HEAD", func(t *testing.T) {
+		_, _, err := runCLI(t, newTestMock(), "watch")
+		if got := ExitCode(err); got != ExitUsage {
+			t.Errorf("ExitCode() = %d, want %d", got, ExitUsage)
+		}
+	})
+}
+
+func TestWatch_InvalidArgs(t *testing.T) {
+	tests := [][]string{
+		{"watch", "abc"},
+		{"watch", "1", "2"},
+		{"watch", "1", "--interval", "0s"},
+		{"watch", "https://ghe.example.com/other/project/actions/runs/123"},
+	}
+	for _, args := range tests {
+		t.Run(strings.Join(args, " "), func(t *testing.T) {
+			_, _, err := runCLI(t, newTestMock(), args...)
+			if got := ExitCode(err); got != ExitUsage {
+				t.Errorf("ExitCode() = %d, want %d", got, ExitUsage)
+			}
+		})
+	}
+}
+
+func TestLogTail(t *testing.T) {
+	tests := []struct {
+		name string
+		logs string
+		n    int
+		want []string
+	}{
+		{name: "ends at last error", logs: "a\nb\n##[error]one\nc\n##[error]two\nd", n: 2, want: []string{"c", "##[error]two"}},
+		{name: "no error marker", logs: "a\nb\nc\n", n: 2, want: []string{"b", "c"}},
+		{name: "shorter than n", logs: "a\n##[error]x", n: 10, want: []string{"a", "##[error]x"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := logTail(strings.Split(tt.logs, "\n"), tt.n)
+			if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+				t.Errorf("logTail() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderWatch(t *testing.T) {
+	run := github.Run{Name: "CI", RunNumber: 9, Status: "in_progress", Event: "push", Branch: "main"}
+	jobs := []github.Job{
+		{Name: "lint", Status: "completed", Conclusion: "success", Steps: []github.Step{{Name: "Run lint", Status: "completed", Conclusion: "success"}}},
+		{Name: "test", Status: "in_progress", Steps: []github.Step{{Name: "Run tests", Status: "in_progress"}}},
+	}
+
+	got := renderWatch(run, jobs)
+	if !strings.Contains(got, "CI #9 · push on main") {
+		t.Errorf("missing run header:\n%s", got)
+	}
+	if strings.Contains(got, "Run lint") {
+		t.Errorf("steps of finished jobs should be hidden:\n%s", got)
+	}
+	if !strings.Contains(got, "Run tests") {
+		t.Errorf("steps of running jobs should be shown:\n%s", got)
+	}
+}
@@ -2,44 +2,332 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"time"
 
+	"github.com/nnnkkk7/lazyactions"
 	"github.com/nnnkkk7/lazyactions/app"
 	"github.com/nnnkkk7/lazyactions/auth"
+	"github.com/nnnkkk7/lazyactions/cli"
+	"github.com/nnnkkk7/lazyactions/config"
 	"github.com/nnnkkk7/lazyactions/github"
 	"github.com/nnnkkk7/lazyactions/repo"
+	"github.com/nnnkkk7/lazyactions/state"
 )
 
+// forkCheckTimeout bounds the API request that checks whether origin is a fork
+const forkCheckTimeout = 5 * time.Second
+
+// Version is the release version, overridden by goreleaser with -X main.Version
+var Version = lazyactions.Version
+
 func main() {
-	if err := run(); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+	if err := run(os.Args[1:]); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		}
+		os.Exit(cli.ExitCode(err))
+	}
+}
+
+// options are the global command line flags
+type options struct {
+	path    string // repository directory (positional)
+	repo    string // --repo [HOST/]OWNER/NAME, skips git detection
+	remote  string // --remote
+	host    string // --host
+	version bool   // --version
+
+	remoteSet bool // whether --remote was given explicitly
+}
+
+// parseFlags parses the global flags and returns them with the subcommand
+// arguments, if a subcommand was given
+func parseFlags(args []string) (options, []string, error) {
+	var opts options
+	fs := flag.NewFlagSet("lazyactions", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprint(fs.Output(), "Usage: lazyactions [flags] [path]\n       lazyactions [flags] <command> [args]\n\nFlags:\n")
+		fs.PrintDefaults()
+		fmt.Fprintln(fs.Output())
+		cli.Usage(fs.Output())
+	}
+	fs.StringVar(&opts.repo, "repo", "", "repository as [HOST/]OWNER/NAME instead of detecting it from git")
+	fs.StringVar(&opts.remote, "remote", repo.DefaultRemote, "git remote to read the repository from")
+	fs.StringVar(&opts.host, "host", "", "GitHub API host (default: the host of the remote)")
+	fs.BoolVar(&opts.version, "version", false, "print the version and exit")
+
+	// Flags may follow the path, but everything after a subcommand belongs to it
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				return opts, nil, err
+			}
+			return opts, nil, &cli.UsageError{Message: err.Error()}
+		}
+		if fs.NArg() == 0 {
+			break
+		}
+		if len(positional) == 0 && cli.IsCommand(fs.Arg(0)) {
+			return opts, fs.Args(), validateFlags(fs, &opts)
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+
+	if len(positional) > 1 {
+		return opts, nil, &cli.UsageError{Message: fmt.Sprintf("expected at most one path, got %d arguments", len(positional))}
+	}
+	if len(positional) == 1 {
+		opts.path = positional[0]
+	}
+	return opts, nil, validateFlags(fs, &opts)
+}
+
+// validateFlags rejects flag combinations that cannot be honored
+func validateFlags(fs *flag.FlagSet, opts *options) error {
+	fs.Visit(func(f *flag.Flag) {
+		opts.remoteSet = opts.remoteSet || f.Name == "remote"
+	})
+	if opts.repo != "" && (opts.path != "" || opts.remoteSet) {
+		return &cli.UsageError{Message: "--repo cannot be combined with a path or --remote"}
+	}
+	return nil
+}
+
+// localPath returns the directory of the local checkout
+func (o options) localPath() string {
+	if o.path == "" {
+		return "."
+	}
+	return o.path
+}
+
+// detectRepository resolves the repository from --repo or from a git remote at
+// the path. Without --remote, it also returns all GitHub remotes and the name
+// of the one chosen, which is origin or else the first GitHub remote.
+func detectRepository(opts options) (*github.Repository, []repo.Remote, string, error) {
+	if opts.repo != "" {
+		repoInfo, err := repo.ParseRepository(opts.repo)
+		if err != nil {
+			return nil, nil, "", &cli.UsageError{Message: err.Error()}
+		}
+		if opts.host != "" {
+			repoInfo.Host = opts.host
+		}
+		return repoInfo, nil, "", nil
+	}
+
+	var remotes []repo.Remote
+	remote := opts.remote
+	if !opts.remoteSet {
+		var err error
+		if remotes, err = repo.ListRemotes(opts.localPath()); err != nil {
+			return nil, nil, "", fmt.Errorf("failed to detect repository: %w", err)
+		}
+		if opts.host != "" {
+			for i := range remotes {
+				remotes[i].Repo.Host = opts.host
+			}
+		}
+		if r, ok := repo.PreferredRemote(remotes, func(github.Repository) bool { return false }); ok {
+			repoInfo := r.Repo
+			return &repoInfo, remotes, r.Name, nil
+		}
+	}
+
+	// Without a GitHub remote, reading origin reports why it is unusable
+	repoInfo, err := repo.DetectRemote(opts.localPath(), remote)
+	if err != nil {
+		return nil, nil, "", fmt.Errorf("failed to detect repository: %w", err)
+	}
+	if opts.host != "" {
+		repoInfo.Host = opts.host
+	}
+	return repoInfo, remotes, remote, nil
+}
+
+// loadWorkspace returns the repositories of the workspace config: those
+// listed explicitly, then those discovered in directories, without duplicates.
+// Invalid entries are reported as warnings and skipped.
+func loadWorkspace(ws config.Workspace) []github.Repository {
+	var repos []github.Repository
+	seen := make(map[github.Repository]bool)
+	add := func(r github.Repository) {
+		if !seen[r] {
+			seen[r] = true
+			repos = append(repos, r)
+		}
+	}
+
+	for _, name := range ws.Repositories {
+		r, err := repo.ParseRepository(name)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: workspace: %v\n", err)
+			continue
+		}
+		add(*r)
+	}
+	for _, dir := range ws.Discover {
+		found, err := repo.Discover(config.ExpandPath(dir))
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: workspace: %v\n", err)
+			continue
+		}
+		for _, r := range found {
+			add(r)
+		}
+	}
+	return repos
+}
+
+// configPaths returns the paths of the config files: the user config, and
+// the config at the root of the local checkout. Either is empty if unknown.
+func configPaths(opts options) (user, local string) {
+	user, _ = config.DefaultPath()
+	if opts.repo == "" {
+		if root, err := repo.Root(opts.localPath()); err == nil {
+			local = filepath.Join(root, config.LocalFileName)
+		}
+	}
+	return user, local
+}
+
+// warn prints each of the errors joined in err as a warning
+func warn(err error) {
+	for _, line := range strings.Split(err.Error(), "\n") {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", line)
 	}
 }
 
-func run() error {
-	// Detect repository from current directory
-	repoInfo, err := repo.Detect()
+func run(args []string) error {
+	opts, cmdArgs, err := parseFlags(args)
+	if err != nil {
+		return err
+	}
+	if opts.version {
+		fmt.Printf("lazyactions %s\n", Version)
+		return nil
+	}
+
+	// Load the user config, overridden by the config of the repository;
+	// a broken config file should not prevent startup
+	cfg, err := config.Load(configPaths(opts))
+	if err != nil {
+		warn(err)
+	}
+	if err := app.ValidateConfig(cfg); err != nil {
+		warn(err)
+	}
+	workspace := loadWorkspace(cfg.Workspace)
+
+	// The local checkout is unknown with --repo
+	localPath := opts.localPath()
+	hasCheckout := opts.repo == ""
+
+	// Resolve the repository from --repo or the git remotes.
+	// Outside a checkout, the TUI opens the first workspace repository.
+	repoInfo, remotes, remote, err := detectRepository(opts)
 	if err != nil {
-		return fmt.Errorf("failed to detect repository: %w", err)
+		if opts.path != "" || opts.repo != "" || cmdArgs != nil || len(workspace) == 0 {
+			return err
+		}
+		repoInfo, remote, hasCheckout = &workspace[0], "", false
 	}
 
-	// Get authentication token (gh CLI -> GITHUB_TOKEN)
-	token, err := auth.GetToken()
+	// Get authentication token for the repository host
+	// (gh CLI -> GITHUB_TOKEN, or GH_ENTERPRISE_TOKEN for Enterprise Server)
+	token, err := auth.GetTokenForHost(repoInfo.Host)
 	if err != nil {
 		return fmt.Errorf("failed to get authentication: %w", err)
 	}
 
 	// Create GitHub client
-	client := github.NewClient(token.Value(), repoInfo.Owner, repoInfo.Name)
+	client, err := github.NewClient(token.Value(), repoInfo.Owner, repoInfo.Name, github.WithHost(repoInfo.Host))
+	if err != nil {
+		return fmt.Errorf("failed to create GitHub client: %w", err)
+	}
+
+	// In a fork, CI usually runs on upstream rather than on origin
+	if remote == repo.DefaultRemote {
+		r, ok := repo.PreferredRemote(remotes, func(origin github.Repository) bool {
+			ctx, cancel := context.WithTimeout(context.Background(), forkCheckTimeout)
+			defer cancel()
+			fork, err := client.IsFork(ctx, origin)
+			return err == nil && fork
+		})
+		if ok {
+			repoInfo, remote = &r.Repo, r.Name
+		}
+	}
 
 	// Create repository struct
 	repository := github.Repository{
+		Host:  repoInfo.Host,
 		Owner: repoInfo.Owner,
 		Name:  repoInfo.Name,
 	}
 
+	// Run a non-interactive subcommand instead of the TUI
+	if cmdArgs != nil {
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+		var cliOpts []cli.Option
+		if hasCheckout {
+			// HEAD is only needed by watch without a run; an unborn branch leaves it empty
+			sha, _ := repo.HeadSHA(localPath)
+			cliOpts = append(cliOpts, cli.WithHeadSHA(sha))
+		}
+		return cli.New(client, repository, cliOpts...).Run(ctx, cmdArgs)
+	}
+
+	// Load persisted UI state; a broken state file should not prevent startup
+	st := state.New()
+	if path, err := state.DefaultPath(); err == nil {
+		if st, err = state.Load(path); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+	}
+
+	// The switcher offers the remotes the client can authenticate against
+	var switchable []repo.Remote
+	for _, r := range remotes {
+		if r.Repo.Host == repository.Host {
+			switchable = append(switchable, r)
+		}
+	}
+
+	// The workspace holds the repositories the client can authenticate against
+	// and always includes the repository in use
+	var repos []github.Repository
+	inWorkspace := false
+	for _, r := range workspace {
+		if r.Host != repository.Host {
+			fmt.Fprintf(os.Stderr, "Warning: workspace: skipping %s/%s: not on %s\n", r.Host, r.FullName(), repository.Host)
+			continue
+		}
+		inWorkspace = inWorkspace || r == repository
+		repos = append(repos, r)
+	}
+	if !inWorkspace {
+		repos = append([]github.Repository{repository}, repos...)
+	}
+
+	appOpts := []app.Option{app.WithConfig(cfg), app.WithState(st), app.WithRemotes(switchable, remote), app.WithWorkspace(repos)}
+	if hasCheckout {
+		// Local branch is offered by the ref picker; empty on a detached HEAD
+		branch, _ := repo.CurrentBranch(localPath)
+		appOpts = append(appOpts, app.WithLocalBranch(branch))
+	}
+
 	// Run TUI
-	return app.Run(client, repository)
+	return app.Run(client, repository, appOpts...)
 }
@@ -0,0 +1,327 @@
+// Package config loads the user configuration.
+// The configuration is read from $XDG_CONFIG_HOME/lazyactions/config.yml
+// (~/.config/lazyactions/config.yml by default), then from .lazyactions.yml
+// at the root of the repository, whose settings take precedence; every
+// setting is optional. As a checkout may come from anyone, its config cannot
+// set the workspace, keymap, keybindings or confirmations.
+package config
+
+import (
+	"bytes"
+	"cmp"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// fileName is the name of the config file inside the config directory.
+const fileName = "config.yml"
+
+// LocalFileName is the name of the config file at the root of a repository.
+const LocalFileName = ".lazyactions.yml"
+
+// Bounds of the settings
+const (
+	// MinLeftWidth and MaxLeftWidth bound the width ratio of the left sidebar
+	MinLeftWidth = 0.1
+	MaxLeftWidth = 0.9
+	// MinRefreshInterval is the shortest refresh interval, the default one
+	// while a run is running. Polling may still need longer intervals to stay
+	// within the API rate limit, which it drops to refresh.max when low.
+	MinRefreshInterval = 5 * time.Second
+)
+
+// Config is the user configuration.
+type Config struct {
+	Workspace Workspace `yaml:"workspace"`
+	// Keymap is the name of the keybinding preset
+	Keymap string `yaml:"keymap"`
+	// Keybindings maps action names to the keys that trigger them, replacing
+	// those of the preset
+	Keybindings map[string]Keys `yaml:"keybindings"`
+	// Theme is the name of the color theme
+	Theme string `yaml:"theme"`
+	// Themes are custom color themes by name
+	Themes  map[string]Theme `yaml:"themes"`
+	Layout  Layout           `yaml:"layout"`
+	Filters Filters          `yaml:"filters"`
+	Refresh Refresh          `yaml:"refresh"`
+	Confirm Confirm          `yaml:"confirm"`
+}
+
+// Workspace lists the repositories shown in the Repositories pane.
+type Workspace struct {
+	// Repositories are given as [HOST/]OWNER/NAME
+	Repositories []string `yaml:"repositories"`
+	// Discover lists directories whose immediate subdirectories are git
+	// checkouts to add to the workspace
+	Discover []string `yaml:"discover"`
+}
+
+// Keys are the keys bound to an action, given as a single key or a list.
+type Keys []string
+
+// UnmarshalYAML accepts a single key as well as a list of keys.
+func (k *Keys) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*k = Keys{node.Value}
+		return nil
+	}
+	var keys []string
+	if err := node.Decode(&keys); err != nil {
+		return err
+	}
+	*k = keys
+	return nil
+}
+
+// Theme is a custom color theme.
+type Theme struct {
+	// Base is the theme the colors not given are taken from
+	Base string `yaml:"base"`
+	// Colors maps color roles to colors, as "#RRGGBB", "#RGB" or an ANSI
+	// color number from 0 to 255
+	Colors map[string]string `yaml:"colors"`
+}
+
+// colorPattern matches the colors of themes
+var colorPattern = regexp.MustCompile(`^(#[0-9a-fA-F]{6}|#[0-9a-fA-F]{3}|[0-9]{1,3})$`)
+
+// validColor reports whether c is a color of a theme
+func validColor(c string) bool {
+	if !colorPattern.MatchString(c) {
+		return false
+	}
+	if c[0] != '#' {
+		n, _ := strconv.Atoi(c)
+		return n <= 255
+	}
+	return true
+}
+
+// Layout sizes the panes. Zero values keep the defaults.
+type Layout struct {
+	// LeftWidth is the ratio of the screen width taken by the left sidebar
+	LeftWidth float64 `yaml:"left_width"`
+}
+
+// Filters are the filters applied to the panes on startup, in the filter
+// query language of the TUI.
+type Filters struct {
+	Repositories string `yaml:"repositories"`
+	Workflows    string `yaml:"workflows"`
+	Runs         string `yaml:"runs"`
+	Jobs         string `yaml:"jobs"`
+}
+
+// Refresh sets how often data is polled. Zero values keep the defaults.
+type Refresh struct {
+	// Active is the interval while runs are in progress
+	Active time.Duration `yaml:"active"`
+	// Idle is the first interval once all runs completed; it doubles on
+	// each refresh that finds nothing running, up to Max
+	Idle time.Duration `yaml:"idle"`
+	Max  time.Duration `yaml:"max"`
+	// RepoStatus is the interval between refreshes of the repository statuses
+	// of a workspace
+	RepoStatus time.Duration `yaml:"repo_status"`
+}
+
+// Confirm sets which actions ask for confirmation. Unset values keep the
+// defaults.
+type Confirm struct {
+	Cancel      *bool `yaml:"cancel"`
+	Rerun       *bool `yaml:"rerun"`
+	RerunFailed *bool `yaml:"rerun_failed"`
+}
+
+// DefaultPath returns the default config file path.
+// It honors $XDG_CONFIG_HOME and falls back to ~/.config.
+func DefaultPath() (string, error) {
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to get home directory: %w", err)
+		}
+		dir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(dir, "lazyactions", fileName), nil
+}
+
+// Load reads the user config file at path, then the config file of the
+// repository at repoPath, whose settings take precedence. Empty paths and
+// missing files are skipped. Unknown keys are rejected so that typos do not
+// go unnoticed.
+//
+// A file that fails to parse is ignored as a whole, and invalid settings, as
+// well as those the repository config may not set, are ignored individually;
+// the returned error reports them all, while the Config holds the remaining
+// settings.
+func Load(path, repoPath string) (*Config, error) {
+	cfg := &Config{}
+	err := errors.Join(cfg.load(path, true), cfg.load(repoPath, false))
+	return cfg, err
+}
+
+// load merges the config file at path into c. An untrusted file cannot set
+// the settings of dropUserOnly.
+func (c *Config) load(path string, trusted bool) error {
+	if path == "" {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	file := &Config{}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(file); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+	var errs []error
+	for _, err := range file.validate() {
+		errs = append(errs, fmt.Errorf("invalid config file %s: %w", path, err))
+	}
+	if !trusted {
+		for _, err := range file.dropUserOnly() {
+			errs = append(errs, fmt.Errorf("config file %s: %w", path, err))
+		}
+	}
+	c.merge(file)
+	return errors.Join(errs...)
+}
+
+// validate checks the values of the settings, resetting the invalid ones
+func (c *Config) validate() []error {
+	var errs []error
+	invalid := func(field, format string, args ...any) {
+		errs = append(errs, fmt.Errorf(field+": "+format, args...))
+	}
+
+	for _, action := range slices.Sorted(maps.Keys(c.Keybindings)) {
+		if keys := c.Keybindings[action]; len(keys) == 0 || slices.Contains(keys, "") {
+			invalid("keybindings."+action, "keys must not be empty")
+			delete(c.Keybindings, action)
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(c.Themes)) {
+		colors := c.Themes[name].Colors
+		for _, role := range slices.Sorted(maps.Keys(colors)) {
+			if !validColor(colors[role]) {
+				invalid("themes."+name+".colors."+role, "invalid color %q, want #RRGGBB, #RGB or 0 to 255", colors[role])
+				delete(colors, role)
+			}
+		}
+	}
+
+	if w := c.Layout.LeftWidth; w != 0 && (w < MinLeftWidth || w > MaxLeftWidth) {
+		invalid("layout.left_width", "must be between %v and %v, got %v", MinLeftWidth, MaxLeftWidth, w)
+		c.Layout.LeftWidth = 0
+	}
+
+	intervals := []struct {
+		name string
+		d    *time.Duration
+	}{
+		{"active", &c.Refresh.Active},
+		{"idle", &c.Refresh.Idle},
+		{"max", &c.Refresh.Max},
+		{"repo_status", &c.Refresh.RepoStatus},
+	}
+	for _, i := range intervals {
+		if *i.d != 0 && *i.d < MinRefreshInterval {
+			invalid("refresh."+i.name, "must be at least %v, got %v", MinRefreshInterval, *i.d)
+			*i.d = 0
+		}
+	}
+	// Each interval is at least the previous one
+	for i := 1; i < 3; i++ {
+		prev, cur := intervals[i-1], intervals[i]
+		if *prev.d != 0 && *cur.d != 0 && *cur.d < *prev.d {
+			invalid("refresh."+cur.name, "must not be shorter than refresh.%s (%v), got %v", prev.name, *prev.d, *cur.d)
+			*cur.d = 0
+		}
+	}
+
+	return errs
+}
+
+// dropUserOnly resets the settings only the user config may set: those
+// choosing the repositories accessed and what keys do, which a repository
+// could otherwise turn against its user
+func (c *Config) dropUserOnly() []error {
+	var errs []error
+	drop := func(field string, set bool) {
+		if set {
+			errs = append(errs, fmt.Errorf("%s: only allowed in the user config, ignored", field))
+		}
+	}
+	drop("workspace", c.Workspace.Repositories != nil || c.Workspace.Discover != nil)
+	drop("keymap", c.Keymap != "")
+	drop("keybindings", c.Keybindings != nil)
+	drop("confirm", c.Confirm != Confirm{})
+	c.Workspace, c.Keymap, c.Keybindings, c.Confirm = Workspace{}, "", nil, Confirm{}
+	return errs
+}
+
+// merge overrides the settings of c with those set in o
+func (c *Config) merge(o *Config) {
+	if o.Workspace.Repositories != nil {
+		c.Workspace.Repositories = o.Workspace.Repositories
+	}
+	if o.Workspace.Discover != nil {
+		c.Workspace.Discover = o.Workspace.Discover
+	}
+	if len(o.Keybindings) > 0 && c.Keybindings == nil {
+		c.Keybindings = make(map[string]Keys, len(o.Keybindings))
+	}
+	maps.Copy(c.Keybindings, o.Keybindings)
+	c.Keymap = cmp.Or(o.Keymap, c.Keymap)
+	c.Theme = cmp.Or(o.Theme, c.Theme)
+	if len(o.Themes) > 0 && c.Themes == nil {
+		c.Themes = make(map[string]Theme, len(o.Themes))
+	}
+	maps.Copy(c.Themes, o.Themes)
+	c.Layout.LeftWidth = cmp.Or(o.Layout.LeftWidth, c.Layout.LeftWidth)
+	c.Filters.Repositories = cmp.Or(o.Filters.Repositories, c.Filters.Repositories)
+	c.Filters.Workflows = cmp.Or(o.Filters.Workflows, c.Filters.Workflows)
+	c.Filters.Runs = cmp.Or(o.Filters.Runs, c.Filters.Runs)
+	c.Filters.Jobs = cmp.Or(o.Filters.Jobs, c.Filters.Jobs)
+	c.Refresh.Active = cmp.Or(o.Refresh.Active, c.Refresh.Active)
+	c.Refresh.Idle = cmp.Or(o.Refresh.Idle, c.Refresh.Idle)
+	c.Refresh.Max = cmp.Or(o.Refresh.Max, c.Refresh.Max)
+	c.Refresh.RepoStatus = cmp.Or(o.Refresh.RepoStatus, c.Refresh.RepoStatus)
+	c.Confirm.Cancel = cmp.Or(o.Confirm.Cancel, c.Confirm.Cancel)
+	c.Confirm.Rerun = cmp.Or(o.Confirm.Rerun, c.Confirm.Rerun)
+	c.Confirm.RerunFailed = cmp.Or(o.Confirm.RerunFailed, c.Confirm.RerunFailed)
+}
+
+// ExpandPath replaces a leading ~ in path with the home directory.
+func ExpandPath(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, strings.TrimPrefix(path, "~"))
+}
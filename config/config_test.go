@@ -0,0 +1,256 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yml")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	return path
+}
+
+func TestDefaultPath(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", "/tmp/xdg")
+	path, err := DefaultPath()
+	if err != nil {
+		t.Fatalf("DefaultPath() error = %v", err)
+	}
+	if path != "/tmp/xdg/lazyactions/config.yml" {
+		t.Errorf("DefaultPath() = %q", path)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("missing file", func(t *testing.T) {
+		cfg, err := Load(filepath.Join(t.TempDir(), "config.yml"), "")
+		if err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		if len(cfg.Workspace.Repositories) != 0 {
+			t.Errorf("Load() = %+v, want empty config", cfg)
+		}
+	})
+
+	t.Run("empty file", func(t *testing.T) {
+		if _, err := Load(writeConfig(t, ""), ""); err != nil {
+			t.Errorf("Load() error = %v", err)
+		}
+	})
+
+	t.Run("workspace", func(t *testing.T) {
+		cfg, err := Load(writeConfig(t, `
+workspace:
+  repositories:
+    - owner/api
+    - ghe.example.com/owner/web
+  discover:
+    - ~/src/team
+`), "")
+		if err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		want := Workspace{
+			Repositories: []string{"owner/api", "ghe.example.com/owner/web"},
+			Discover:     []string{"~/src/team"},
+		}
+		if !reflect.DeepEqual(cfg.Workspace, want) {
+			t.Errorf("Workspace = %+v, want %+v", cfg.Workspace, want)
+		}
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		_, err := Load(writeConfig(t, "workspace:\n  repos: [owner/api]\n"), "")
+		if err == nil || !strings.Contains(err.Error(), "repos") {
+			t.Errorf("Load() error = %v, want error naming the unknown key", err)
+		}
+	})
+
+	t.Run("settings", func(t *testing.T) {
+		cfg, err := Load(writeConfig(t, `
+keybindings:
+  rerun: ctrl+r
+  quit: [q, ctrl+c]
+theme: mine
+themes:
+  mine:
+    base: light
+    colors:
+      focused: "#ff8800"
+      failure: 160
+layout:
+  left_width: 0.4
+filters:
+  workflows: state:active
+  runs: actor:@me
+refresh:
+  active: 10s
+  idle: 30s
+  max: 5m
+  repo_status: 2m
+confirm:
+  cancel: false
+  rerun: true
+`), "")
+		if err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+		wantKeys := map[string]Keys{"rerun": {"ctrl+r"}, "quit": {"q", "ctrl+c"}}
+		if !reflect.DeepEqual(cfg.Keybindings, wantKeys) {
+			t.Errorf("Keybindings = %v, want %v", cfg.Keybindings, wantKeys)
+		}
+		if cfg.Theme != "mine" || cfg.Layout.LeftWidth != 0.4 {
+			t.Errorf("Theme, Layout = %q, %+v", cfg.Theme, cfg.Layout)
+		}
+		wantThemes := map[string]Theme{"mine": {Base: "light", Colors: map[string]string{"focused": "#ff8800", "failure": "160"}}}
+		if !reflect.DeepEqual(cfg.Themes, wantThemes) {
+			t.Errorf("Themes = %+v, want %+v", cfg.Themes, wantThemes)
+		}
+		if want := (Filters{Workflows: "state:active", Runs: "actor:@me"}); cfg.Filters != want {
+			t.Errorf("Filters = %+v, want %+v", cfg.Filters, want)
+		}
+		if want := (Refresh{Active: 10 * time.Second, Idle: 30 * time.Second, Max: 5 * time.Minute, RepoStatus: 2 * time.Minute}); cfg.Refresh != want {
+			t.Errorf("Refresh = %+v, want %+v", cfg.Refresh, want)
+		}
+		c := cfg.Confirm
+		if c.Cancel == nil || *c.Cancel || c.Rerun == nil || !*c.Rerun || c.RerunFailed != nil {
+			t.Errorf("Confirm = %+v, want cancel false, rerun true, rerun_failed unset", c)
+		}
+	})
+
+	t.Run("invalid values", func(t *testing.T) {
+		cfg, err := Load(writeConfig(t, `
+keybindings:
+  quit: []
+themes:
+  mine:
+    colors:
+      focused: orange
+      failure: 256
+      success: "#0f0"
+layout:
+  left_width: 1.5
+filters:
+  runs: branch:main
+refresh:
+  active: 3s
+  idle: 1m
+  max: 30s
+`), "")
+		for _, want := range []string{
+			"keybindings.quit: keys must not be empty",
+			`themes.mine.colors.focused: invalid color "orange", want #RRGGBB, #RGB or 0 to 255`,
+			`themes.mine.colors.failure: invalid color "256"`,
+			"layout.left_width: must be between 0.1 and 0.9, got 1.5",
+			"refresh.active: must be at least 5s, got 3s",
+			"refresh.max: must not be shorter than refresh.idle (1m0s), got 30s",
+		} {
+			if err == nil || !strings.Contains(err.Error(), want) {
+				t.Errorf("Load() error = %v, want %q", err, want)
+			}
+		}
+		// Invalid settings are dropped, the others kept
+		want := &Config{
+			Themes:  map[string]Theme{"mine": {Colors: map[string]string{"success": "#0f0"}}},
+			Filters: Filters{Runs: "branch:main"},
+			Refresh: Refresh{Idle: time.Minute},
+		}
+		if !reflect.DeepEqual(cfg, want) {
+			t.Errorf("Load() = %+v, want %+v", cfg, want)
+		}
+	})
+
+	t.Run("invalid type", func(t *testing.T) {
+		_, err := Load(writeConfig(t, "refresh:\n  active: often\n"), "")
+		if err == nil || !strings.Contains(err.Error(), "often") {
+			t.Errorf("Load() error = %v, want error naming the invalid value", err)
+		}
+	})
+}
+
+func TestLoad_Override(t *testing.T) {
+	user := writeConfig(t, `
+workspace:
+  repositories: [owner/api]
+keybindings:
+  rerun: ctrl+r
+filters:
+  workflows: state:active
+  runs: actor:@me
+confirm:
+  rerun: true
+`)
+	local := writeConfig(t, `
+workspace:
+  discover: [~/src]
+keymap: vim
+keybindings:
+  quit: Q
+theme: light
+filters:
+  runs: branch:main
+confirm:
+  rerun: false
+`)
+
+	cfg, err := Load(user, local)
+	for _, field := range []string{"workspace", "keymap", "keybindings", "confirm"} {
+		if want := local + ": " + field + ": only allowed in the user config"; err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Load() error = %v, want %q", err, want)
+		}
+	}
+	if want := []string{"owner/api"}; !reflect.DeepEqual(cfg.Workspace.Repositories, want) || cfg.Workspace.Discover != nil {
+		t.Errorf("Workspace = %+v, want the user workspace", cfg.Workspace)
+	}
+	if cfg.Keymap != "" {
+		t.Errorf("Keymap = %q, want it unset", cfg.Keymap)
+	}
+	wantKeys := map[string]Keys{"rerun": {"ctrl+r"}}
+	if !reflect.DeepEqual(cfg.Keybindings, wantKeys) {
+		t.Errorf("Keybindings = %v, want %v", cfg.Keybindings, wantKeys)
+	}
+	if cfg.Confirm.Rerun == nil || !*cfg.Confirm.Rerun {
+		t.Error("the repository config should not turn rerun confirmation off")
+	}
+	if cfg.Theme != "light" {
+		t.Errorf("Theme = %q, want light", cfg.Theme)
+	}
+	if want := (Filters{Workflows: "state:active", Runs: "branch:main"}); cfg.Filters != want {
+		t.Errorf("Filters = %+v, want %+v", cfg.Filters, want)
+	}
+
+	broken := writeConfig(t, "filters: [\n")
+	cfg, err = Load(user, broken)
+	if err == nil || !strings.Contains(err.Error(), broken) {
+		t.Errorf("Load() error = %v, want error naming %s", err, broken)
+	}
+	if cfg.Filters.Runs != "actor:@me" {
+		t.Error("a broken repository config should keep the user config")
+	}
+	if _, err := Load(filepath.Join(t.TempDir(), "missing.yml"), ""); err != nil {
+		t.Errorf("Load() error = %v, want missing files skipped", err)
+	}
+}
+
+func TestExpandPath(t *testing.T) {
+	t.Setenv("HOME", "/home/me")
+	tests := map[string]string{
+		"~":         "/home/me",
+		"~/src":     "/home/me/src",
+		"/abs/path": "/abs/path",
+		"~other":    "~other",
+	}
+	for in, want := range tests {
+		if got := ExpandPath(in); got != want {
+			t.Errorf("ExpandPath(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
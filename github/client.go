@@ -2,23 +2,62 @@ package github
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 
 	"github.com/google/go-github/v68/github"
 )
 
+// Pagination bounds, in pages of 100 items
+const (
+	// MaxListPages bounds how many pages of workflows, jobs or environments are fetched
+	MaxListPages = 10
+	// MaxRefPages bounds how many pages of branches or tags are fetched
+	MaxRefPages = 10
+	// MaxRepoPages bounds how many pages of an owner's repositories are fetched
+	MaxRepoPages = 10
+)
+
+// pageSize is the number of items requested per page, the API maximum
+const pageSize = 100
+
 // realClient implements the Client interface using go-github
 type realClient struct {
 	client    *github.Client
+	host      string
 	owner     string
 	repoName  string
 	rateLimit int
 }
 
-// NewClient creates a new GitHub API client
-func NewClient(token, owner, repoName string) Client {
+// ClientOption configures the client created by NewClient
+type ClientOption func(*clientOptions)
+
+// clientOptions holds the settings applied by ClientOption
+type clientOptions struct {
+	host string
+}
+
+// WithHost targets the GitHub host serving the repository (e.g. "ghe.example.com").
+// Any host other than github.com is treated as GitHub Enterprise Server.
+// The host may include a scheme ("http://ghe.local:8080"); https is assumed otherwise.
+func WithHost(host string) ClientOption {
+	return func(o *clientOptions) {
+		o.host = host
+	}
+}
+
+// NewClient creates a new GitHub API client.
+// It returns an error if the enterprise host does not form a valid URL.
+func NewClient(token, owner, repoName string, opts ...ClientOption) (Client, error) {
+	var o clientOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	httpClient := &http.Client{}
 	if token != "" {
 		httpClient = &http.Client{
@@ -26,12 +65,23 @@ func NewClient(token, owner, repoName string) Client {
 		}
 	}
 
+	client := github.NewClient(httpClient)
+	if isEnterpriseHost(o.host) {
+		baseURL := enterpriseBaseURL(o.host)
+		var err error
+		client, err = client.WithEnterpriseURLs(baseURL+"/api/v3/", baseURL+"/api/uploads/")
+		if err != nil {
+			return nil, fmt.Errorf("invalid GitHub host %q: %w", o.host, err)
+		}
+	}
+
 	return &realClient{
-		client:    github.NewClient(httpClient),
+		client:    client,
+		host:      o.host,
 		owner:     owner,
 		repoName:  repoName,
 		rateLimit: 5000, // Default rate limit
-	}
+	}, nil
 }
 
 // tokenTransport adds authorization header to requests
@@ -52,29 +102,58 @@ func (c *realClient) updateRateLimit(resp *github.Response) {
 	}
 }
 
-// ListWorkflows lists all workflows in the repository.
-func (c *realClient) ListWorkflows(ctx context.Context, repo Repository) ([]Workflow, error) {
-	opts := &github.ListOptions{PerPage: 100}
-	workflows, resp, err := c.client.Actions.ListWorkflows(ctx, repo.Owner, repo.Name, opts)
-	c.updateRateLimit(resp)
-	if err != nil {
-		return nil, WrapAPIError(err)
-	}
-
-	result := make([]Workflow, 0, len(workflows.Workflows))
-	for _, w := range workflows.Workflows {
-		result = append(result, Workflow{
-			ID:    w.GetID(),
-			Name:  w.GetName(),
-			Path:  w.GetPath(),
-			State: w.GetState(),
-		})
+// paginate fetches successive pages until the last one or maxPages, and
+// stops early with ctx's error if ctx is done. fetch returns the items of a
+// page and the total count reported by the API, or -1 if it reports none;
+// without a count, Total is the number of items fetched.
+func paginate[T any](ctx context.Context, maxPages int, fetch func(opts *github.ListOptions) ([]T, int, *github.Response, error)) (List[T], error) {
+	var result List[T]
+	opts := &github.ListOptions{PerPage: pageSize}
+	for page := 0; page < maxPages; page++ {
+		if err := ctx.Err(); err != nil {
+			return List[T]{}, err
+		}
+		items, total, resp, err := fetch(opts)
+		if err != nil {
+			return List[T]{}, WrapAPIError(err)
+		}
+		result.Items = append(result.Items, items...)
+		result.Total = total
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
 	}
+	result.Total = max(result.Total, len(result.Items))
 	return result, nil
 }
 
-// ListRuns lists workflow runs.
-func (c *realClient) ListRuns(ctx context.Context, repo Repository, opts *ListRunsOpts) ([]Run, error) {
+// ListWorkflows lists the workflows in the repository,
+// following pagination up to MaxListPages pages.
+func (c *realClient) ListWorkflows(ctx context.Context, repo Repository) (List[Workflow], error) {
+	return paginate(ctx, MaxListPages, func(opts *github.ListOptions) ([]Workflow, int, *github.Response, error) {
+		workflows, resp, err := c.client.Actions.ListWorkflows(ctx, repo.Owner, repo.Name, opts)
+		c.updateRateLimit(resp)
+		if err != nil {
+			return nil, 0, resp, err
+		}
+
+		result := make([]Workflow, 0, len(workflows.Workflows))
+		for _, w := range workflows.Workflows {
+			result = append(result, Workflow{
+				ID:    w.GetID(),
+				Name:  w.GetName(),
+				Path:  w.GetPath(),
+				State: w.GetState(),
+			})
+		}
+		return result, workflows.GetTotalCount(), resp, nil
+	})
+}
+
+// ListRuns lists a page of workflow runs, newest first.
+// Total is the number of runs matching opts.
+func (c *realClient) ListRuns(ctx context.Context, repo Repository, opts *ListRunsOpts) (List[Run], error) {
 	ghOpts := &github.ListWorkflowRunsOptions{
 		ListOptions: github.ListOptions{PerPage: 30},
 	}
@@ -82,6 +161,9 @@ func (c *realClient) ListRuns(ctx context.Context, repo Repository, opts *ListRu
 		if opts.PerPage > 0 {
 			ghOpts.ListOptions.PerPage = opts.PerPage
 		}
+		if opts.Page > 0 {
+			ghOpts.ListOptions.Page = opts.Page
+		}
 		if opts.Branch != "" {
 			ghOpts.Branch = opts.Branch
 		}
@@ -91,22 +173,41 @@ func (c *realClient) ListRuns(ctx context.Context, repo Repository, opts *ListRu
 		if opts.Event != "" {
 			ghOpts.Event = opts.Event
 		}
+		if opts.Actor != "" {
+			ghOpts.Actor = opts.Actor
+		}
+		if opts.Created != "" {
+			ghOpts.Created = opts.Created
+		}
+		if opts.HeadSHA != "" {
+			ghOpts.HeadSHA = opts.HeadSHA
+		}
 		if opts.WorkflowID > 0 {
 			runs, resp, err := c.client.Actions.ListWorkflowRunsByID(ctx, repo.Owner, repo.Name, opts.WorkflowID, ghOpts)
 			c.updateRateLimit(resp)
 			if err != nil {
-				return nil, WrapAPIError(err)
+				return List[Run]{}, WrapAPIError(err)
 			}
-			return convertRuns(runs.WorkflowRuns), nil
+			return List[Run]{Items: convertRuns(runs.WorkflowRuns), Total: runs.GetTotalCount()}, nil
 		}
 	}
 
 	runs, resp, err := c.client.Actions.ListRepositoryWorkflowRuns(ctx, repo.Owner, repo.Name, ghOpts)
 	c.updateRateLimit(resp)
 	if err != nil {
-		return nil, WrapAPIError(err)
+		return List[Run]{}, WrapAPIError(err)
 	}
-	return convertRuns(runs.WorkflowRuns), nil
+	return List[Run]{Items: convertRuns(runs.WorkflowRuns), Total: runs.GetTotalCount()}, nil
+}
+
+// GetRun gets a single workflow run.
+func (c *realClient) GetRun(ctx context.Context, repo Repository, runID int64) (Run, error) {
+	run, resp, err := c.client.Actions.GetWorkflowRunByID(ctx, repo.Owner, repo.Name, runID)
+	c.updateRateLimit(resp)
+	if err != nil {
+		return Run{}, WrapAPIError(err)
+	}
+	return convertRuns([]*github.WorkflowRun{run})[0], nil
 }
 
 // CancelRun cancels a workflow run.
@@ -153,37 +254,37 @@ func (c *realClient) TriggerWorkflow(ctx context.Context, repo Repository, workf
 	return nil
 }
 
-// ListJobs lists jobs for a workflow run.
-func (c *realClient) ListJobs(ctx context.Context, repo Repository, runID int64) ([]Job, error) {
-	opts := &github.ListWorkflowJobsOptions{
-		ListOptions: github.ListOptions{PerPage: 100},
-	}
-	jobs, resp, err := c.client.Actions.ListWorkflowJobs(ctx, repo.Owner, repo.Name, runID, opts)
-	c.updateRateLimit(resp)
-	if err != nil {
-		return nil, WrapAPIError(err)
-	}
-
-	result := make([]Job, 0, len(jobs.Jobs))
-	for _, j := range jobs.Jobs {
-		steps := make([]Step, 0, len(j.Steps))
-		for _, s := range j.Steps {
-			steps = append(steps, Step{
-				Name:       s.GetName(),
-				Status:     s.GetStatus(),
-				Conclusion: s.GetConclusion(),
-				Number:     int(s.GetNumber()),
+// ListJobs lists the jobs of a workflow run,
+// following pagination up to MaxListPages pages.
+func (c *realClient) ListJobs(ctx context.Context, repo Repository, runID int64) (List[Job], error) {
+	return paginate(ctx, MaxListPages, func(opts *github.ListOptions) ([]Job, int, *github.Response, error) {
+		jobs, resp, err := c.client.Actions.ListWorkflowJobs(ctx, repo.Owner, repo.Name, runID, &github.ListWorkflowJobsOptions{ListOptions: *opts})
+		c.updateRateLimit(resp)
+		if err != nil {
+			return nil, 0, resp, err
+		}
+
+		result := make([]Job, 0, len(jobs.Jobs))
+		for _, j := range jobs.Jobs {
+			steps := make([]Step, 0, len(j.Steps))
+			for _, s := range j.Steps {
+				steps = append(steps, Step{
+					Name:       s.GetName(),
+					Status:     s.GetStatus(),
+					Conclusion: s.GetConclusion(),
+					Number:     int(s.GetNumber()),
+				})
+			}
+			result = append(result, Job{
+				ID:         j.GetID(),
+				Name:       j.GetName(),
+				Status:     j.GetStatus(),
+				Conclusion: j.GetConclusion(),
+				Steps:      steps,
 			})
 		}
-		result = append(result, Job{
-			ID:         j.GetID(),
-			Name:       j.GetName(),
-			Status:     j.GetStatus(),
-			Conclusion: j.GetConclusion(),
-			Steps:      steps,
-		})
-	}
-	return result, nil
+		return result, jobs.GetTotalCount(), resp, nil
+	})
 }
 
 // GetJobLogs gets logs for a job.
@@ -208,6 +309,173 @@ func (c *realClient) GetJobLogs(ctx context.Context, repo Repository, jobID int6
 	return string(body), nil
 }
 
+// GetFileContent gets the decoded content of a file at the given ref.
+func (c *realClient) GetFileContent(ctx context.Context, repo Repository, path, ref string) (string, error) {
+	opts := &github.RepositoryContentGetOptions{Ref: ref}
+	file, _, resp, err := c.client.Repositories.GetContents(ctx, repo.Owner, repo.Name, path, opts)
+	c.updateRateLimit(resp)
+	if err != nil {
+		return "", WrapAPIError(err)
+	}
+	if file == nil {
+		return "", fmt.Errorf("%s is not a file", path)
+	}
+
+	content, err := file.GetContent()
+	if err != nil {
+		return "", fmt.Errorf("failed to decode %s: %w", path, err)
+	}
+	return content, nil
+}
+
+// ListEnvironments lists the deployment environment names of the repository,
+// following pagination up to MaxListPages pages.
+func (c *realClient) ListEnvironments(ctx context.Context, repo Repository) ([]string, error) {
+	envs, err := paginate(ctx, MaxListPages, func(opts *github.ListOptions) ([]string, int, *github.Response, error) {
+		envs, resp, err := c.client.Repositories.ListEnvironments(ctx, repo.Owner, repo.Name, &github.EnvironmentListOptions{ListOptions: *opts})
+		c.updateRateLimit(resp)
+		if err != nil {
+			return nil, 0, resp, err
+		}
+
+		result := make([]string, 0, len(envs.Environments))
+		for _, e := range envs.Environments {
+			result = append(result, e.GetName())
+		}
+		return result, envs.GetTotalCount(), resp, nil
+	})
+	return envs.Items, err
+}
+
+// IsFork reports whether the repository is a fork.
+func (c *realClient) IsFork(ctx context.Context, repo Repository) (bool, error) {
+	r, resp, err := c.client.Repositories.Get(ctx, repo.Owner, repo.Name)
+	c.updateRateLimit(resp)
+	if err != nil {
+		return false, WrapAPIError(err)
+	}
+	return r.GetFork(), nil
+}
+
+// ListOwnerRepositories lists the repositories of an organization or user,
+// following pagination up to MaxRepoPages pages. Archived repositories are
+// skipped. For the authenticated user, private repositories are included.
+func (c *realClient) ListOwnerRepositories(ctx context.Context, owner string) ([]Repository, error) {
+	repos, err := c.listRepositories(ctx, func(opts *github.ListOptions) ([]*github.Repository, *github.Response, error) {
+		return c.client.Repositories.ListByOrg(ctx, owner, &github.RepositoryListByOrgOptions{ListOptions: *opts})
+	})
+
+	// The organization endpoint answers 404 for a user
+	var appErr *AppError
+	if errors.As(err, &appErr) && appErr.Type == ErrTypeNotFound {
+		list, err := c.userRepositoryLister(ctx, owner)
+		if err != nil {
+			return nil, err
+		}
+		return c.listRepositories(ctx, list)
+	}
+	return repos, err
+}
+
+// listRepositories follows the pages of a repository listing, skipping archived repositories
+func (c *realClient) listRepositories(ctx context.Context, list func(*github.ListOptions) ([]*github.Repository, *github.Response, error)) ([]Repository, error) {
+	repos, err := paginate(ctx, MaxRepoPages, func(opts *github.ListOptions) ([]Repository, int, *github.Response, error) {
+		repos, resp, err := list(opts)
+		c.updateRateLimit(resp)
+		if err != nil {
+			return nil, 0, resp, err
+		}
+
+		result := make([]Repository, 0, len(repos))
+		for _, r := range repos {
+			if !r.GetArchived() {
+				result = append(result, Repository{Host: c.host, Owner: r.GetOwner().GetLogin(), Name: r.GetName()})
+			}
+		}
+		return result, -1, resp, nil
+	})
+	return repos.Items, err
+}
+
+// userRepositoryLister returns the function listing a page of the repositories
+// of user. The public listing hides private repositories, so the repositories
+// of the authenticated user are listed through its own endpoint.
+func (c *realClient) userRepositoryLister(ctx context.Context, user string) (func(*github.ListOptions) ([]*github.Repository, *github.Response, error), error) {
+	me, err := c.CurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if strings.EqualFold(me, user) {
+		return func(opts *github.ListOptions) ([]*github.Repository, *github.Response, error) {
+			return c.client.Repositories.ListByAuthenticatedUser(ctx, &github.RepositoryListByAuthenticatedUserOptions{
+				Affiliation: "owner",
+				ListOptions: *opts,
+			})
+		}, nil
+	}
+	return func(opts *github.ListOptions) ([]*github.Repository, *github.Response, error) {
+		return c.client.Repositories.ListByUser(ctx, user, &github.RepositoryListByUserOptions{ListOptions: *opts})
+	}, nil
+}
+
+// CurrentUser returns the login of the authenticated user.
+func (c *realClient) CurrentUser(ctx context.Context) (string, error) {
+	me, resp, err := c.client.Users.Get(ctx, "")
+	c.updateRateLimit(resp)
+	if err != nil {
+		return "", WrapAPIError(err)
+	}
+	return me.GetLogin(), nil
+}
+
+// GetDefaultBranch gets the default branch of the repository.
+func (c *realClient) GetDefaultBranch(ctx context.Context, repo Repository) (string, error) {
+	r, resp, err := c.client.Repositories.Get(ctx, repo.Owner, repo.Name)
+	c.updateRateLimit(resp)
+	if err != nil {
+		return "", WrapAPIError(err)
+	}
+	return r.GetDefaultBranch(), nil
+}
+
+// ListBranches lists the branch names of the repository,
+// following pagination up to MaxRefPages pages.
+func (c *realClient) ListBranches(ctx context.Context, repo Repository) ([]string, error) {
+	branches, err := paginate(ctx, MaxRefPages, func(opts *github.ListOptions) ([]string, int, *github.Response, error) {
+		branches, resp, err := c.client.Repositories.ListBranches(ctx, repo.Owner, repo.Name, &github.BranchListOptions{ListOptions: *opts})
+		c.updateRateLimit(resp)
+		if err != nil {
+			return nil, 0, resp, err
+		}
+
+		result := make([]string, 0, len(branches))
+		for _, b := range branches {
+			result = append(result, b.GetName())
+		}
+		return result, -1, resp, nil
+	})
+	return branches.Items, err
+}
+
+// ListTags lists the tag names of the repository,
+// following pagination up to MaxRefPages pages.
+func (c *realClient) ListTags(ctx context.Context, repo Repository) ([]string, error) {
+	tags, err := paginate(ctx, MaxRefPages, func(opts *github.ListOptions) ([]string, int, *github.Response, error) {
+		tags, resp, err := c.client.Repositories.ListTags(ctx, repo.Owner, repo.Name, opts)
+		c.updateRateLimit(resp)
+		if err != nil {
+			return nil, 0, resp, err
+		}
+
+		result := make([]string, 0, len(tags))
+		for _, t := range tags {
+			result = append(result, t.GetName())
+		}
+		return result, -1, resp, nil
+	})
+	return tags.Items, err
+}
+
 // RateLimitRemaining returns the remaining rate limit.
 func (c *realClient) RateLimitRemaining() int {
 	return c.rateLimit
@@ -224,6 +492,7 @@ func convertRuns(ghRuns []*github.WorkflowRun) []Run {
 			Status:     r.GetStatus(),
 			Conclusion: r.GetConclusion(),
 			Branch:     r.GetHeadBranch(),
+			HeadSHA:    r.GetHeadSHA(),
 			Event:      r.GetEvent(),
 			Actor:      r.GetActor().GetLogin(),
 			URL:        r.GetHTMLURL(),
@@ -21,16 +21,43 @@ var _ Client = &MockClient{}
 //			CancelRunFunc: func(ctx context.Context, repo Repository, runID int64) error {
 //				panic("mock out the CancelRun method")
 //			},
+//			CurrentUserFunc: func(ctx context.Context) (string, error) {
+//				panic("mock out the CurrentUser method")
+//			},
+//			GetDefaultBranchFunc: func(ctx context.Context, repo Repository) (string, error) {
+//				panic("mock out the GetDefaultBranch method")
+//			},
+//			GetFileContentFunc: func(ctx context.Context, repo Repository, path string, ref string) (string, error) {
+//				panic("mock out the GetFileContent method")
+//			},
 //			GetJobLogsFunc: func(ctx context.Context, repo Repository, jobID int64) (string, error) {
 //				panic("mock out the GetJobLogs method")
 //			},
-//			ListJobsFunc: func(ctx context.Context, repo Repository, runID int64) ([]Job, error) {
+//			GetRunFunc: func(ctx context.Context, repo Repository, runID int64) (Run, error) {
+//				panic("mock out the GetRun method")
+//			},
+//			IsForkFunc: func(ctx context.Context, repo Repository) (bool, error) {
+//				panic("mock out the IsFork method")
+//			},
+//			ListBranchesFunc: func(ctx context.Context, repo Repository) ([]string, error) {
+//				panic("mock out the ListBranches method")
+//			},
+//			ListEnvironmentsFunc: func(ctx context.Context, repo Repository) ([]string, error) {
+//				panic("mock out the ListEnvironments method")
+//			},
+//			ListJobsFunc: func(ctx context.Context, repo Repository, runID int64) (List[Job], error) {
 //				panic("mock out the ListJobs method")
 //			},
-//			ListRunsFunc: func(ctx context.Context, repo Repository, opts *ListRunsOpts) ([]Run, error) {
+//			ListOwnerRepositoriesFunc: func(ctx context.Context, owner string) ([]Repository, error) {
+//				panic("mock out the ListOwnerRepositories method")
+//			},
+//			ListRunsFunc: func(ctx context.Context, repo Repository, opts *ListRunsOpts) (List[Run], error) {
 //				panic("mock out the ListRuns method")
 //			},
-//			ListWorkflowsFunc: func(ctx context.Context, repo Repository) ([]Workflow, error) {
+//			ListTagsFunc: func(ctx context.Context, repo Repository) ([]string, error) {
+//				panic("mock out the ListTags method")
+//			},
+//			ListWorkflowsFunc: func(ctx context.Context, repo Repository) (List[Workflow], error) {
 //				panic("mock out the ListWorkflows method")
 //			},
 //			RateLimitRemainingFunc: func() int {
@@ -55,17 +82,44 @@ type MockClient struct {
 	// CancelRunFunc mocks the CancelRun method.
 	CancelRunFunc func(ctx context.Context, repo Repository, runID int64) error
 
+	// CurrentUserFunc mocks the CurrentUser method.
+	CurrentUserFunc func(ctx context.Context) (string, error)
+
+	// GetDefaultBranchFunc mocks the GetDefaultBranch method.
+	GetDefaultBranchFunc func(ctx context.Context, repo Repository) (string, error)
+
+	// GetFileContentFunc mocks the GetFileContent method.
+	GetFileContentFunc func(ctx context.Context, repo Repository, path string, ref string) (string, error)
+
 	// GetJobLogsFunc mocks the GetJobLogs method.
 	GetJobLogsFunc func(ctx context.Context, repo Repository, jobID int64) (string, error)
 
+	// GetRunFunc mocks the GetRun method.
+	GetRunFunc func(ctx context.Context, repo Repository, runID int64) (Run, error)
+
+	// IsForkFunc mocks the IsFork method.
+	IsForkFunc func(ctx context.Context, repo Repository) (bool, error)
+
+	// ListBranchesFunc mocks the ListBranches method.
+	ListBranchesFunc func(ctx context.Context, repo Repository) ([]string, error)
+
+	// ListEnvironmentsFunc mocks the ListEnvironments method.
+	ListEnvironmentsFunc func(ctx context.Context, repo Repository) ([]string, error)
+
 	// ListJobsFunc mocks the ListJobs method.
-	ListJobsFunc func(ctx context.Context, repo Repository, runID int64) ([]Job, error)
+	ListJobsFunc func(ctx context.Context, repo Repository, runID int64) (List[Job], error)
+
+	// ListOwnerRepositoriesFunc mocks the ListOwnerRepositories method.
+	ListOwnerRepositoriesFunc func(ctx context.Context, owner string) ([]Repository, error)
 
 	// ListRunsFunc mocks the ListRuns method.
-	ListRunsFunc func(ctx context.Context, repo Repository, opts *ListRunsOpts) ([]Run, error)
+	ListRunsFunc func(ctx context.Context, repo Repository, opts *ListRunsOpts) (List[Run], error)
+
+	// ListTagsFunc mocks the ListTags method.
+	ListTagsFunc func(ctx context.Context, repo Repository) ([]string, error)
 
 	// ListWorkflowsFunc mocks the ListWorkflows method.
-	ListWorkflowsFunc func(ctx context.Context, repo Repository) ([]Workflow, error)
+	ListWorkflowsFunc func(ctx context.Context, repo Repository) (List[Workflow], error)
 
 	// RateLimitRemainingFunc mocks the RateLimitRemaining method.
 	RateLimitRemainingFunc func() int
@@ -90,6 +144,29 @@ type MockClient struct {
 			// RunID is the runID argument value.
 			RunID int64
 		}
+		// CurrentUser holds details about calls to the CurrentUser method.
+		CurrentUser []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+		}
+		// GetDefaultBranch holds details about calls to the GetDefaultBranch method.
+		GetDefaultBranch []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Repo is the repo argument value.
+			Repo Repository
+		}
+		// GetFileContent holds details about calls to the GetFileContent method.
+		GetFileContent []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Repo is the repo argument value.
+			Repo Repository
+			// Path is the path argument value.
+			Path string
+			// Ref is the ref argument value.
+			Ref string
+		}
 		// GetJobLogs holds details about calls to the GetJobLogs method.
 		GetJobLogs []struct {
 			// Ctx is the ctx argument value.
@@ -99,6 +176,36 @@ type MockClient struct {
 			// JobID is the jobID argument value.
 			JobID int64
 		}
+		// GetRun holds details about calls to the GetRun method.
+		GetRun []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Repo is the repo argument value.
+			Repo Repository
+			// RunID is the runID argument value.
+			RunID int64
+		}
+		// IsFork holds details about calls to the IsFork method.
+		IsFork []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Repo is the repo argument value.
+			Repo Repository
+		}
+		// ListBranches holds details about calls to the ListBranches method.
+		ListBranches []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Repo is the repo argument value.
+			Repo Repository
+		}
+		// ListEnvironments holds details about calls to the ListEnvironments method.
+		ListEnvironments []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Repo is the repo argument value.
+			Repo Repository
+		}
 		// ListJobs holds details about calls to the ListJobs method.
 		ListJobs []struct {
 			// Ctx is the ctx argument value.
@@ -108,6 +215,13 @@ type MockClient struct {
 			// RunID is the runID argument value.
 			RunID int64
 		}
+		// ListOwnerRepositories holds details about calls to the ListOwnerRepositories method.
+		ListOwnerRepositories []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Owner is the owner argument value.
+			Owner string
+		}
 		// ListRuns holds details about calls to the ListRuns method.
 		ListRuns []struct {
 			// Ctx is the ctx argument value.
@@ -117,6 +231,13 @@ type MockClient struct {
 			// Opts is the opts argument value.
 			Opts *ListRunsOpts
 		}
+		// ListTags holds details about calls to the ListTags method.
+		ListTags []struct {
+			// Ctx is the ctx argument value.
+			Ctx context.Context
+			// Repo is the repo argument value.
+			Repo Repository
+		}
 		// ListWorkflows holds details about calls to the ListWorkflows method.
 		ListWorkflows []struct {
 			// Ctx is the ctx argument value.
@@ -159,15 +280,24 @@ type MockClient struct {
 			Inputs map[string]interface{}
 		}
 	}
-	lockCancelRun          sync.RWMutex
-	lockGetJobLogs         sync.RWMutex
-	lockListJobs           sync.RWMutex
-	lockListRuns           sync.RWMutex
-	lockListWorkflows      sync.RWMutex
-	lockRateLimitRemaining sync.RWMutex
-	lockRerunFailedJobs    sync.RWMutex
-	lockRerunWorkflow      sync.RWMutex
-	lockTriggerWorkflow    sync.RWMutex
+	lockCancelRun             sync.RWMutex
+	lockCurrentUser           sync.RWMutex
+	lockGetDefaultBranch      sync.RWMutex
+	lockGetFileContent        sync.RWMutex
+	lockGetJobLogs            sync.RWMutex
+	lockGetRun                sync.RWMutex
+	lockIsFork                sync.RWMutex
+	lockListBranches          sync.RWMutex
+	lockListEnvironments      sync.RWMutex
+	lockListJobs              sync.RWMutex
+	lockListOwnerRepositories sync.RWMutex
+	lockListRuns              sync.RWMutex
+	lockListTags              sync.RWMutex
+	lockListWorkflows         sync.RWMutex
+	lockRateLimitRemaining    sync.RWMutex
+	lockRerunFailedJobs       sync.RWMutex
+	lockRerunWorkflow         sync.RWMutex
+	lockTriggerWorkflow       sync.RWMutex
 }
 
 // CancelRun calls CancelRunFunc.
@@ -210,6 +340,118 @@ func (mock *MockClient) CancelRunCalls() []struct {
 	return calls
 }
 
+// CurrentUser calls CurrentUserFunc.
+func (mock *MockClient) CurrentUser(ctx context.Context) (string, error) {
+	if mock.CurrentUserFunc == nil {
+		panic("MockClient.CurrentUserFunc: method is nil but Client.CurrentUser was just called")
+	}
+	callInfo := struct {
+		Ctx context.Context
+	}{
+		Ctx: ctx,
+	}
+	mock.lockCurrentUser.Lock()
+	mock.calls.CurrentUser = append(mock.calls.CurrentUser, callInfo)
+	mock.lockCurrentUser.Unlock()
+	return mock.CurrentUserFunc(ctx)
+}
+
+// CurrentUserCalls gets all the calls that were made to CurrentUser.
+// Check the length with:
+//
+//	len(mockedClient.CurrentUserCalls())
+func (mock *MockClient) CurrentUserCalls() []struct {
+	Ctx context.Context
+} {
+	var calls []struct {
+		Ctx context.Context
+	}
+	mock.lockCurrentUser.RLock()
+	calls = mock.calls.CurrentUser
+	mock.lockCurrentUser.RUnlock()
+	return calls
+}
+
+// GetDefaultBranch calls GetDefaultBranchFunc.
+func (mock *MockClient) GetDefaultBranch(ctx context.Context, repo Repository) (string, error) {
+	if mock.GetDefaultBranchFunc == nil {
+		panic("MockClient.GetDefaultBranchFunc: method is nil but Client.GetDefaultBranch was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Repo Repository
+	}{
+		Ctx:  ctx,
+		Repo: repo,
+	}
+	mock.lockGetDefaultBranch.Lock()
+	mock.calls.GetDefaultBranch = append(mock.calls.GetDefaultBranch, callInfo)
+	mock.lockGetDefaultBranch.Unlock()
+	return mock.GetDefaultBranchFunc(ctx, repo)
+}
+
+// GetDefaultBranchCalls gets all the calls that were made to GetDefaultBranch.
+// Check the length with:
+//
+//	len(mockedClient.GetDefaultBranchCalls())
+func (mock *MockClient) GetDefaultBranchCalls() []struct {
+	Ctx  context.Context
+	Repo Repository
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Repo Repository
+	}
+	mock.lockGetDefaultBranch.RLock()
+	calls = mock.calls.GetDefaultBranch
+	mock.lockGetDefaultBranch.RUnlock()
+	return calls
+}
+
+// GetFileContent calls GetFileContentFunc.
+func (mock *MockClient) GetFileContent(ctx context.Context, repo Repository, path string, ref string) (string, error) {
+	if mock.GetFileContentFunc == nil {
+		panic("MockClient.GetFileContentFunc: method is nil but Client.GetFileContent was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Repo Repository
+		Path string
+		Ref  string
+	}{
+		Ctx:  ctx,
+		Repo: repo,
+		Path: path,
+		Ref:  ref,
+	}
+	mock.lockGetFileContent.Lock()
+	mock.calls.GetFileContent = append(mock.calls.GetFileContent, callInfo)
+	mock.lockGetFileContent.Unlock()
+	return mock.GetFileContentFunc(ctx, repo, path, ref)
+}
+
+// GetFileContentCalls gets all the calls that were made to GetFileContent.
+// Check the length with:
+//
+//	len(mockedClient.GetFileContentCalls())
+func (mock *MockClient) GetFileContentCalls() []struct {
+	Ctx  context.Context
+	Repo Repository
+	Path string
+	Ref  string
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Repo Repository
+		Path string
+		Ref  string
+	}
+	mock.lockGetFileContent.RLock()
+	calls = mock.calls.GetFileContent
+	mock.lockGetFileContent.RUnlock()
+	return calls
+}
+
 // GetJobLogs calls GetJobLogsFunc.
 func (mock *MockClient) GetJobLogs(ctx context.Context, repo Repository, jobID int64) (string, error) {
 	if mock.GetJobLogsFunc == nil {
@@ -250,8 +492,156 @@ func (mock *MockClient) GetJobLogsCalls() []struct {
 	return calls
 }
 
+// GetRun calls GetRunFunc.
+func (mock *MockClient) GetRun(ctx context.Context, repo Repository, runID int64) (Run, error) {
+	if mock.GetRunFunc == nil {
+		panic("MockClient.GetRunFunc: method is nil but Client.GetRun was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Repo  Repository
+		RunID int64
+	}{
+		Ctx:   ctx,
+		Repo:  repo,
+		RunID: runID,
+	}
+	mock.lockGetRun.Lock()
+	mock.calls.GetRun = append(mock.calls.GetRun, callInfo)
+	mock.lockGetRun.Unlock()
+	return mock.GetRunFunc(ctx, repo, runID)
+}
+
+// GetRunCalls gets all the calls that were made to GetRun.
+// Check the length with:
+//
+//	len(mockedClient.GetRunCalls())
+func (mock *MockClient) GetRunCalls() []struct {
+	Ctx   context.Context
+	Repo  Repository
+	RunID int64
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Repo  Repository
+		RunID int64
+	}
+	mock.lockGetRun.RLock()
+	calls = mock.calls.GetRun
+	mock.lockGetRun.RUnlock()
+	return calls
+}
+
+// IsFork calls IsForkFunc.
+func (mock *MockClient) IsFork(ctx context.Context, repo Repository) (bool, error) {
+	if mock.IsForkFunc == nil {
+		panic("MockClient.IsForkFunc: method is nil but Client.IsFork was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Repo Repository
+	}{
+		Ctx:  ctx,
+		Repo: repo,
+	}
+	mock.lockIsFork.Lock()
+	mock.calls.IsFork = append(mock.calls.IsFork, callInfo)
+	mock.lockIsFork.Unlock()
+	return mock.IsForkFunc(ctx, repo)
+}
+
+// IsForkCalls gets all the calls that were made to IsFork.
+// Check the length with:
+//
+//	len(mockedClient.IsForkCalls())
+func (mock *MockClient) IsForkCalls() []struct {
+	Ctx  context.Context
+	Repo Repository
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Repo Repository
+	}
+	mock.lockIsFork.RLock()
+	calls = mock.calls.IsFork
+	mock.lockIsFork.RUnlock()
+	return calls
+}
+
+// ListBranches calls ListBranchesFunc.
+func (mock *MockClient) ListBranches(ctx context.Context, repo Repository) ([]string, error) {
+	if mock.ListBranchesFunc == nil {
+		panic("MockClient.ListBranchesFunc: method is nil but Client.ListBranches was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Repo Repository
+	}{
+		Ctx:  ctx,
+		Repo: repo,
+	}
+	mock.lockListBranches.Lock()
+	mock.calls.ListBranches = append(mock.calls.ListBranches, callInfo)
+	mock.lockListBranches.Unlock()
+	return mock.ListBranchesFunc(ctx, repo)
+}
+
+// ListBranchesCalls gets all the calls that were made to ListBranches.
+// Check the length with:
+//
+//	len(mockedClient.ListBranchesCalls())
+func (mock *MockClient) ListBranchesCalls() []struct {
+	Ctx  context.Context
+	Repo Repository
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Repo Repository
+	}
+	mock.lockListBranches.RLock()
+	calls = mock.calls.ListBranches
+	mock.lockListBranches.RUnlock()
+	return calls
+}
+
+// ListEnvironments calls ListEnvironmentsFunc.
+func (mock *MockClient) ListEnvironments(ctx context.Context, repo Repository) ([]string, error) {
+	if mock.ListEnvironmentsFunc == nil {
+		panic("MockClient.ListEnvironmentsFunc: method is nil but Client.ListEnvironments was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Repo Repository
+	}{
+		Ctx:  ctx,
+		Repo: repo,
+	}
+	mock.lockListEnvironments.Lock()
+	mock.calls.ListEnvironments = append(mock.calls.ListEnvironments, callInfo)
+	mock.lockListEnvironments.Unlock()
+	return mock.ListEnvironmentsFunc(ctx, repo)
+}
+
+// ListEnvironmentsCalls gets all the calls that were made to ListEnvironments.
+// Check the length with:
+//
+//	len(mockedClient.ListEnvironmentsCalls())
+func (mock *MockClient) ListEnvironmentsCalls() []struct {
+	Ctx  context.Context
+	Repo Repository
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Repo Repository
+	}
+	mock.lockListEnvironments.RLock()
+	calls = mock.calls.ListEnvironments
+	mock.lockListEnvironments.RUnlock()
+	return calls
+}
+
 // ListJobs calls ListJobsFunc.
-func (mock *MockClient) ListJobs(ctx context.Context, repo Repository, runID int64) ([]Job, error) {
+func (mock *MockClient) ListJobs(ctx context.Context, repo Repository, runID int64) (List[Job], error) {
 	if mock.ListJobsFunc == nil {
 		panic("MockClient.ListJobsFunc: method is nil but Client.ListJobs was just called")
 	}
@@ -290,8 +680,44 @@ func (mock *MockClient) ListJobsCalls() []struct {
 	return calls
 }
 
+// ListOwnerRepositories calls ListOwnerRepositoriesFunc.
+func (mock *MockClient) ListOwnerRepositories(ctx context.Context, owner string) ([]Repository, error) {
+	if mock.ListOwnerRepositoriesFunc == nil {
+		panic("MockClient.ListOwnerRepositoriesFunc: method is nil but Client.ListOwnerRepositories was just called")
+	}
+	callInfo := struct {
+		Ctx   context.Context
+		Owner string
+	}{
+		Ctx:   ctx,
+		Owner: owner,
+	}
+	mock.lockListOwnerRepositories.Lock()
+	mock.calls.ListOwnerRepositories = append(mock.calls.ListOwnerRepositories, callInfo)
+	mock.lockListOwnerRepositories.Unlock()
+	return mock.ListOwnerRepositoriesFunc(ctx, owner)
+}
+
+// ListOwnerRepositoriesCalls gets all the calls that were made to ListOwnerRepositories.
+// Check the length with:
+//
+//	len(mockedClient.ListOwnerRepositoriesCalls())
+func (mock *MockClient) ListOwnerRepositoriesCalls() []struct {
+	Ctx   context.Context
+	Owner string
+} {
+	var calls []struct {
+		Ctx   context.Context
+		Owner string
+	}
+	mock.lockListOwnerRepositories.RLock()
+	calls = mock.calls.ListOwnerRepositories
+	mock.lockListOwnerRepositories.RUnlock()
+	return calls
+}
+
 // ListRuns calls ListRunsFunc.
-func (mock *MockClient) ListRuns(ctx context.Context, repo Repository, opts *ListRunsOpts) ([]Run, error) {
+func (mock *MockClient) ListRuns(ctx context.Context, repo Repository, opts *ListRunsOpts) (List[Run], error) {
 	if mock.ListRunsFunc == nil {
 		panic("MockClient.ListRunsFunc: method is nil but Client.ListRuns was just called")
 	}
@@ -330,8 +756,44 @@ func (mock *MockClient) ListRunsCalls() []struct {
 	return calls
 }
 
+// ListTags calls ListTagsFunc.
+func (mock *MockClient) ListTags(ctx context.Context, repo Repository) ([]string, error) {
+	if mock.ListTagsFunc == nil {
+		panic("MockClient.ListTagsFunc: method is nil but Client.ListTags was just called")
+	}
+	callInfo := struct {
+		Ctx  context.Context
+		Repo Repository
+	}{
+		Ctx:  ctx,
+		Repo: repo,
+	}
+	mock.lockListTags.Lock()
+	mock.calls.ListTags = append(mock.calls.ListTags, callInfo)
+	mock.lockListTags.Unlock()
+	return mock.ListTagsFunc(ctx, repo)
+}
+
+// ListTagsCalls gets all the calls that were made to ListTags.
+// Check the length with:
+//
+//	len(mockedClient.ListTagsCalls())
+func (mock *MockClient) ListTagsCalls() []struct {
+	Ctx  context.Context
+	Repo Repository
+} {
+	var calls []struct {
+		Ctx  context.Context
+		Repo Repository
+	}
+	mock.lockListTags.RLock()
+	calls = mock.calls.ListTags
+	mock.lockListTags.RUnlock()
+	return calls
+}
+
 // ListWorkflows calls ListWorkflowsFunc.
-func (mock *MockClient) ListWorkflows(ctx context.Context, repo Repository) ([]Workflow, error) {
+func (mock *MockClient) ListWorkflows(ctx context.Context, repo Repository) (List[Workflow], error) {
 	if mock.ListWorkflowsFunc == nil {
 		panic("MockClient.ListWorkflowsFunc: method is nil but Client.ListWorkflows was just called")
 	}
@@ -1,6 +1,14 @@
 package github
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strconv"
 	"testing"
 	"time"
 
@@ -8,7 +16,10 @@ import (
 )
 
 func TestNewClient_CreatesClient(t *testing.T) {
-	client := NewClient("test-token", "owner", "repo")
+	client, err := NewClient("test-token", "owner", "repo")
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
 
 	if client == nil {
 		t.Fatal("NewClient returned nil")
@@ -19,7 +30,10 @@ func TestNewClient_CreatesClient(t *testing.T) {
 }
 
 func TestNewClient_WithEmptyToken(t *testing.T) {
-	client := NewClient("", "owner", "repo")
+	client, err := NewClient("", "owner", "repo")
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
 
 	if client == nil {
 		t.Fatal("NewClient returned nil with empty token")
@@ -27,7 +41,11 @@ func TestNewClient_WithEmptyToken(t *testing.T) {
 }
 
 func TestRealClient_RateLimitRemaining(t *testing.T) {
-	client := NewClient("token", "owner", "repo").(*realClient)
+	c, err := NewClient("token", "owner", "repo")
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+	client := c.(*realClient)
 
 	// Default rate limit should be 5000
 	remaining := client.RateLimitRemaining()
@@ -36,6 +54,245 @@ func TestRealClient_RateLimitRemaining(t *testing.T) {
 	}
 }
 
+func TestNewClient_GitHubDotCom(t *testing.T) {
+	for _, host := range []string{"", DefaultHost} {
+		c, err := NewClient("token", "owner", "repo", WithHost(host))
+		if err != nil {
+			t.Fatalf("NewClient(WithHost(%q)) returned error: %v", host, err)
+		}
+		if got := c.(*realClient).client.BaseURL.String(); got != "https://api.github.com/" {
+			t.Errorf("BaseURL with host %q = %q, want https://api.github.com/", host, got)
+		}
+	}
+}
+
+func TestNewClient_Enterprise(t *testing.T) {
+	c, err := NewClient("token", "owner", "repo", WithHost("ghe.example.com"))
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+	gh := c.(*realClient).client
+	if got := gh.BaseURL.String(); got != "https://ghe.example.com/api/v3/" {
+		t.Errorf("BaseURL = %q, want https://ghe.example.com/api/v3/", got)
+	}
+	if got := gh.UploadURL.String(); got != "https://ghe.example.com/api/uploads/" {
+		t.Errorf("UploadURL = %q, want https://ghe.example.com/api/uploads/", got)
+	}
+}
+
+func TestNewClient_InvalidEnterpriseHost(t *testing.T) {
+	if _, err := NewClient("token", "owner", "repo", WithHost("http://ghe example.com")); err == nil {
+		t.Error("NewClient with invalid host should return error")
+	}
+}
+
+func TestRealClient_EnterpriseServer(t *testing.T) {
+	var gotAuth string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v3/repos/owner/repo", func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		w.Header().Set("X-RateLimit-Remaining", "4999")
+		fmt.Fprint(w, `{"default_branch":"develop","fork":true}`)
+	})
+	mux.HandleFunc("/api/v3/repos/owner/repo/branches", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			fmt.Fprint(w, `[{"name":"feature"}]`)
+			return
+		}
+		w.Header().Set("Link", fmt.Sprintf(`<http://%s/api/v3/repos/owner/repo/branches?page=2>; rel="next"`, r.Host))
+		fmt.Fprint(w, `[{"name":"develop"},{"name":"main"}]`)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	client, err := NewClient("ghp_test", "owner", "repo", WithHost(srv.URL))
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+	repo := Repository{Host: srv.URL, Owner: "owner", Name: "repo"}
+
+	branch, err := client.GetDefaultBranch(context.Background(), repo)
+	if err != nil {
+		t.Fatalf("GetDefaultBranch() error = %v", err)
+	}
+	if branch != "develop" {
+		t.Errorf("GetDefaultBranch() = %q, want develop", branch)
+	}
+	if gotAuth != "Bearer ghp_test" {
+		t.Errorf("Authorization = %q, want Bearer token", gotAuth)
+	}
+	if got := client.RateLimitRemaining(); got != 4999 {
+		t.Errorf("RateLimitRemaining() = %d, want 4999", got)
+	}
+
+	fork, err := client.IsFork(context.Background(), repo)
+	if err != nil {
+		t.Fatalf("IsFork() error = %v", err)
+	}
+	if !fork {
+		t.Error("IsFork() = false, want true")
+	}
+
+	branches, err := client.ListBranches(context.Background(), repo)
+	if err != nil {
+		t.Fatalf("ListBranches() error = %v", err)
+	}
+	if want := []string{"develop", "main", "feature"}; !reflect.DeepEqual(branches, want) {
+		t.Errorf("ListBranches() = %v, want %v", branches, want)
+	}
+}
+
+func TestRealClient_ListJobs_Pagination(t *testing.T) {
+	var requests int
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v3/repos/owner/repo/actions/runs/1/jobs", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		page := r.URL.Query().Get("page")
+		if page == "" {
+			page = "1"
+		}
+		// Every page links to the next, beyond the page bound
+		next, _ := strconv.Atoi(page)
+		w.Header().Set("Link", fmt.Sprintf(`<http://%s/api/v3/repos/owner/repo/actions/runs/1/jobs?page=%d>; rel="next"`, r.Host, next+1))
+		fmt.Fprintf(w, `{"total_count":2000,"jobs":[{"id":%s,"name":"job %s"}]}`, page, page)
+	})
+	mux.HandleFunc("/api/v3/repos/owner/repo/actions/workflows", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"total_count":2,"workflows":[{"id":1,"name":"CI"},{"id":2,"name":"Deploy"}]}`)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	client, err := NewClient("token", "owner", "repo", WithHost(srv.URL))
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+	repo := Repository{Host: srv.URL, Owner: "owner", Name: "repo"}
+
+	jobs, err := client.ListJobs(context.Background(), repo, 1)
+	if err != nil {
+		t.Fatalf("ListJobs() error = %v", err)
+	}
+	if requests != MaxListPages || len(jobs.Items) != MaxListPages {
+		t.Errorf("fetched %d jobs in %d requests, want %d", len(jobs.Items), requests, MaxListPages)
+	}
+	if jobs.Total != 2000 || !jobs.Truncated() {
+		t.Errorf("Total = %d, Truncated() = %v, want 2000 and true", jobs.Total, jobs.Truncated())
+	}
+
+	workflows, err := client.ListWorkflows(context.Background(), repo)
+	if err != nil {
+		t.Fatalf("ListWorkflows() error = %v", err)
+	}
+	if len(workflows.Items) != 2 || workflows.Truncated() {
+		t.Errorf("ListWorkflows() = %+v, want 2 workflows, complete", workflows)
+	}
+
+	t.Run("cancelled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		if _, err := client.ListJobs(ctx, repo, 1); !errors.Is(err, context.Canceled) {
+			t.Errorf("ListJobs() error = %v, want context.Canceled", err)
+		}
+	})
+}
+
+func TestRealClient_ListRuns_Filters(t *testing.T) {
+	var query url.Values
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v3/repos/owner/repo/actions/workflows/7/runs", func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query()
+		fmt.Fprint(w, `{"total_count":95,"workflow_runs":[{"id":1,"head_branch":"main"}]}`)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	client, err := NewClient("token", "owner", "repo", WithHost(srv.URL))
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+	repo := Repository{Host: srv.URL, Owner: "owner", Name: "repo"}
+
+	runs, err := client.ListRuns(context.Background(), repo, &ListRunsOpts{
+		WorkflowID: 7,
+		Status:     "failure",
+		Actor:      "octocat",
+		Created:    ">=2024-01-01",
+		Page:       3,
+		PerPage:    30,
+	})
+	if err != nil {
+		t.Fatalf("ListRuns() error = %v", err)
+	}
+	if len(runs.Items) != 1 || runs.Total != 95 {
+		t.Errorf("ListRuns() = %+v, want 1 run of 95", runs)
+	}
+	want := map[string]string{"status": "failure", "actor": "octocat", "created": ">=2024-01-01", "page": "3", "per_page": "30"}
+	for key, value := range want {
+		if got := query.Get(key); got != value {
+			t.Errorf("%s = %q, want %q", key, got, value)
+		}
+	}
+}
+
+func TestRealClient_ListOwnerRepositories(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v3/orgs/acme/repos", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			fmt.Fprint(w, `[{"name":"web","owner":{"login":"acme"}}]`)
+			return
+		}
+		w.Header().Set("Link", fmt.Sprintf(`<http://%s/api/v3/orgs/acme/repos?page=2>; rel="next"`, r.Host))
+		fmt.Fprint(w, `[{"name":"api","owner":{"login":"acme"}},{"name":"old","owner":{"login":"acme"},"archived":true}]`)
+	})
+	mux.HandleFunc("/api/v3/orgs/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message":"Not Found"}`)
+	})
+	mux.HandleFunc("/api/v3/user", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"login":"me"}`)
+	})
+	mux.HandleFunc("/api/v3/user/repos", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"name":"private","owner":{"login":"me"}}]`)
+	})
+	mux.HandleFunc("/api/v3/users/octocat/repos", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"name":"hello","owner":{"login":"octocat"}}]`)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	client, err := NewClient("token", "acme", "api", WithHost(srv.URL))
+	if err != nil {
+		t.Fatalf("NewClient returned error: %v", err)
+	}
+
+	tests := []struct {
+		owner string
+		want  []string
+	}{
+		{"acme", []string{"acme/api", "acme/web"}},
+		{"me", []string{"me/private"}},
+		{"octocat", []string{"octocat/hello"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.owner, func(t *testing.T) {
+			repos, err := client.ListOwnerRepositories(context.Background(), tt.owner)
+			if err != nil {
+				t.Fatalf("ListOwnerRepositories() error = %v", err)
+			}
+			var got []string
+			for _, r := range repos {
+				if r.Host != srv.URL {
+					t.Errorf("Host = %q, want the client host", r.Host)
+				}
+				got = append(got, r.FullName())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ListOwnerRepositories() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestTokenTransport_SetsAuthHeader(t *testing.T) {
 	// This is a basic test to ensure the transport is created
 	transport := &tokenTransport{token: "test-token"}
@@ -0,0 +1,122 @@
+package github
+
+import (
+	"errors"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Workflow dispatch input types
+const (
+	InputTypeString      = "string"
+	InputTypeBoolean     = "boolean"
+	InputTypeChoice      = "choice"
+	InputTypeEnvironment = "environment"
+	InputTypeNumber      = "number"
+)
+
+// ErrNoWorkflowDispatch is returned when a workflow has no workflow_dispatch trigger.
+var ErrNoWorkflowDispatch = errors.New("workflow has no workflow_dispatch trigger")
+
+// WorkflowInput represents an input declared under on.workflow_dispatch.inputs.
+type WorkflowInput struct {
+	Name        string
+	Description string
+	Type        string // string, boolean, choice, environment, number
+	Required    bool
+	Default     string
+	Options     []string // Only for choice inputs
+}
+
+// inputSpec is the YAML shape of a single workflow_dispatch input.
+type inputSpec struct {
+	Description string      `yaml:"description"`
+	Type        string      `yaml:"type"`
+	Required    bool        `yaml:"required"`
+	Default     interface{} `yaml:"default"`
+	Options     []string    `yaml:"options"`
+}
+
+// ParseDispatchInputs parses a workflow file and returns its workflow_dispatch inputs
+// in declaration order. It returns ErrNoWorkflowDispatch if the workflow cannot be
+// dispatched manually.
+func ParseDispatchInputs(content []byte) ([]WorkflowInput, error) {
+	var doc struct {
+		On yaml.Node `yaml:"on"`
+	}
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse workflow file: %w", err)
+	}
+
+	dispatch, ok := findDispatchTrigger(&doc.On)
+	if !ok {
+		return nil, ErrNoWorkflowDispatch
+	}
+	if dispatch == nil || dispatch.Kind != yaml.MappingNode {
+		return []WorkflowInput{}, nil
+	}
+
+	inputsNode := mappingValue(dispatch, "inputs")
+	if inputsNode == nil || inputsNode.Kind != yaml.MappingNode {
+		return []WorkflowInput{}, nil
+	}
+
+	inputs := make([]WorkflowInput, 0, len(inputsNode.Content)/2)
+	for i := 0; i+1 < len(inputsNode.Content); i += 2 {
+		name := inputsNode.Content[i].Value
+		var spec inputSpec
+		if err := inputsNode.Content[i+1].Decode(&spec); err != nil {
+			return nil, fmt.Errorf("invalid input %q: %w", name, err)
+		}
+
+		input := WorkflowInput{
+			Name:        name,
+			Description: spec.Description,
+			Type:        spec.Type,
+			Required:    spec.Required,
+			Options:     spec.Options,
+		}
+		if input.Type == "" {
+			input.Type = InputTypeString
+		}
+		if spec.Default != nil {
+			input.Default = fmt.Sprint(spec.Default)
+		}
+		inputs = append(inputs, input)
+	}
+	return inputs, nil
+}
+
+// findDispatchTrigger locates workflow_dispatch in the "on" node, which may be a
+// scalar, a sequence of event names, or a mapping of events to their configuration.
+// The returned node is the trigger configuration and may be nil.
+func findDispatchTrigger(on *yaml.Node) (*yaml.Node, bool) {
+	switch on.Kind {
+	case yaml.ScalarNode:
+		return nil, on.Value == "workflow_dispatch"
+	case yaml.SequenceNode:
+		for _, n := range on.Content {
+			if n.Value == "workflow_dispatch" {
+				return nil, true
+			}
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(on.Content); i += 2 {
+			if on.Content[i].Value == "workflow_dispatch" {
+				return on.Content[i+1], true
+			}
+		}
+	}
+	return nil, false
+}
+
+// mappingValue returns the value node for key in a mapping node, or nil.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package github
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestParseDispatchInputs(t *testing.T) {
+	content := `
+name: Deploy
+on:
+  push:
+    branches: [main]
+  workflow_dispatch:
+    inputs:
+      version:
+        description: Version to deploy
+        required: true
+      dry_run:
+        type: boolean
+        default: true
+      target:
+        type: choice
+        options: [staging, production]
+        default: staging
+      env:
+        type: environment
+        required: true
+      replicas:
+        type: number
+        default: 3
+jobs:
+  deploy:
+    runs-on: ubuntu-latest
+`
+	inputs, err := ParseDispatchInputs([]byte(content))
+	if err != nil {
+		t.Fatalf("ParseDispatchInputs() error = %v", err)
+	}
+
+	want := []WorkflowInput{
+		{Name: "version", Description: "Version to deploy", Type: InputTypeString, Required: true},
+		{Name: "dry_run", Type: InputTypeBoolean, Default: "true"},
+		{Name: "target", Type: InputTypeChoice, Default: "staging", Options: []string{"staging", "production"}},
+		{Name: "env", Type: InputTypeEnvironment, Required: true},
+		{Name: "replicas", Type: InputTypeNumber, Default: "3"},
+	}
+	if !reflect.DeepEqual(inputs, want) {
+		t.Errorf("ParseDispatchInputs() =\n%+v\nwant\n%+v", inputs, want)
+	}
+}
+
+func TestParseDispatchInputs_TriggerForms(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr error
+	}{
+		{name: "scalar", content: "on: workflow_dispatch\n"},
+		{name: "sequence", content: "on: [push, workflow_dispatch]\n"},
+		{name: "mapping without config", content: "on:\n  workflow_dispatch:\n"},
+		{name: "mapping without inputs", content: "on:\n  workflow_dispatch: {}\n"},
+		{name: "not dispatchable scalar", content: "on: push\n", wantErr: ErrNoWorkflowDispatch},
+		{name: "not dispatchable mapping", content: "on:\n  pull_request:\n", wantErr: ErrNoWorkflowDispatch},
+		{name: "missing on", content: "name: CI\n", wantErr: ErrNoWorkflowDispatch},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inputs, err := ParseDispatchInputs([]byte(tt.content))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseDispatchInputs() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && len(inputs) != 0 {
+				t.Errorf("ParseDispatchInputs() returned %d inputs, want 0", len(inputs))
+			}
+		})
+	}
+}
+
+func TestParseDispatchInputs_InvalidYAML(t *testing.T) {
+	_, err := ParseDispatchInputs([]byte("on: [workflow_dispatch\n"))
+	if err == nil {
+		t.Fatal("ParseDispatchInputs() with invalid YAML should return error")
+	}
+	if errors.Is(err, ErrNoWorkflowDispatch) {
+		t.Error("invalid YAML should not be reported as ErrNoWorkflowDispatch")
+	}
+}
@@ -3,6 +3,7 @@ package github
 import (
 	"context"
 	"errors"
+	"net"
 	"time"
 
 	ghlib "github.com/google/go-github/v68/github"
@@ -96,6 +97,16 @@ func WrapAPIError(err error) *AppError {
 		}
 	}
 
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return &AppError{
+			Type:      ErrTypeNetwork,
+			Message:   "Network error",
+			Cause:     err,
+			Retryable: true,
+		}
+	}
+
 	return &AppError{
 		Type:      ErrTypeUnknown,
 		Message:   "Unexpected error",
@@ -2,7 +2,9 @@ package github
 
 import (
 	"errors"
+	"net"
 	"net/http"
+	"net/url"
 	"testing"
 	"time"
 
@@ -335,6 +337,18 @@ func TestWrapAPIError_NonGitHubError(t *testing.T) {
 	}
 }
 
+func TestWrapAPIError_NetworkError(t *testing.T) {
+	netErr := &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+
+	appErr := WrapAPIError(&url.Error{Op: "Get", URL: "https://api.github.com", Err: netErr})
+	if appErr.Type != ErrTypeNetwork {
+		t.Errorf("AppError.Type = %v, want %v", appErr.Type, ErrTypeNetwork)
+	}
+	if !appErr.Retryable {
+		t.Error("Network error should be retryable")
+	}
+}
+
 func TestIsRetryable_True(t *testing.T) {
 	appErr := &AppError{
 		Type:      ErrTypeRateLimit,
@@ -0,0 +1,27 @@
+package github
+
+import "strings"
+
+// DefaultHost is the host of repositories on github.com.
+const DefaultHost = "github.com"
+
+// isEnterpriseHost returns true if host is a GitHub Enterprise Server host.
+// An empty host means github.com.
+func isEnterpriseHost(host string) bool {
+	name := host
+	if i := strings.Index(name, "://"); i >= 0 {
+		name = name[i+3:]
+	}
+	name = strings.ToLower(strings.TrimSuffix(name, "/"))
+	return name != "" && name != DefaultHost && name != "api."+DefaultHost
+}
+
+// enterpriseBaseURL returns the base URL of an enterprise host, without a trailing slash.
+// https is assumed unless the host carries its own scheme.
+func enterpriseBaseURL(host string) string {
+	host = strings.TrimSuffix(host, "/")
+	if strings.Contains(host, "://") {
+		return host
+	}
+	return "https://" + host
+}
@@ -0,0 +1,45 @@
+package github
+
+import "testing"
+
+func TestIsEnterpriseHost(t *testing.T) {
+	tests := []struct {
+		host string
+		want bool
+	}{
+		{host: "", want: false},
+		{host: "github.com", want: false},
+		{host: "GitHub.com", want: false},
+		{host: "api.github.com", want: false},
+		{host: "https://github.com/", want: false},
+		{host: "ghe.example.com", want: true},
+		{host: "http://127.0.0.1:8080", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.host, func(t *testing.T) {
+			if got := isEnterpriseHost(tt.host); got != tt.want {
+				t.Errorf("isEnterpriseHost(%q) = %v, want %v", tt.host, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnterpriseBaseURL(t *testing.T) {
+	tests := []struct {
+		host string
+		want string
+	}{
+		{host: "ghe.example.com", want: "https://ghe.example.com"},
+		{host: "ghe.example.com:8443", want: "https://ghe.example.com:8443"},
+		{host: "http://127.0.0.1:8080/", want: "http://127.0.0.1:8080"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.host, func(t *testing.T) {
+			if got := enterpriseBaseURL(tt.host); got != tt.want {
+				t.Errorf("enterpriseBaseURL(%q) = %q, want %q", tt.host, got, tt.want)
+			}
+		})
+	}
+}
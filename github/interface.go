@@ -8,21 +8,38 @@ import "context"
 // It enables dependency injection for testing.
 type Client interface {
 	// Workflows
-	ListWorkflows(ctx context.Context, repo Repository) ([]Workflow, error)
+	ListWorkflows(ctx context.Context, repo Repository) (List[Workflow], error)
 
 	// Runs
-	ListRuns(ctx context.Context, repo Repository, opts *ListRunsOpts) ([]Run, error)
+	ListRuns(ctx context.Context, repo Repository, opts *ListRunsOpts) (List[Run], error)
+	GetRun(ctx context.Context, repo Repository, runID int64) (Run, error)
 	CancelRun(ctx context.Context, repo Repository, runID int64) error
 	RerunWorkflow(ctx context.Context, repo Repository, runID int64) error
 	RerunFailedJobs(ctx context.Context, repo Repository, runID int64) error
 	TriggerWorkflow(ctx context.Context, repo Repository, workflowFile, ref string, inputs map[string]interface{}) error
 
 	// Jobs
-	ListJobs(ctx context.Context, repo Repository, runID int64) ([]Job, error)
+	ListJobs(ctx context.Context, repo Repository, runID int64) (List[Job], error)
 
 	// Logs
 	GetJobLogs(ctx context.Context, repo Repository, jobID int64) (string, error)
 
+	// Repository contents
+	GetFileContent(ctx context.Context, repo Repository, path, ref string) (string, error)
+	ListEnvironments(ctx context.Context, repo Repository) ([]string, error)
+
+	// Repository
+	IsFork(ctx context.Context, repo Repository) (bool, error)
+	ListOwnerRepositories(ctx context.Context, owner string) ([]Repository, error)
+
+	// Users
+	CurrentUser(ctx context.Context) (string, error)
+
+	// Refs
+	GetDefaultBranch(ctx context.Context, repo Repository) (string, error)
+	ListBranches(ctx context.Context, repo Repository) ([]string, error)
+	ListTags(ctx context.Context, repo Repository) ([]string, error)
+
 	// Rate limiting
 	RateLimitRemaining() int
 }
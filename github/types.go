@@ -4,30 +4,37 @@ import "time"
 
 // Repository represents a GitHub repository.
 type Repository struct {
+	Host  string // github.com or a GitHub Enterprise Server host; empty means github.com
 	Owner string
 	Name  string
 }
 
+// FullName returns the repository in owner/name form.
+func (r Repository) FullName() string {
+	return r.Owner + "/" + r.Name
+}
+
 // Workflow represents a GitHub Actions workflow definition.
 type Workflow struct {
-	ID    int64
-	Name  string
-	Path  string // .github/workflows/ci.yml
-	State string // active, disabled
+	ID    int64  `json:"id"`
+	Name  string `json:"name"`
+	Path  string `json:"path"`  // .github/workflows/ci.yml
+	State string `json:"state"` // active, disabled
 }
 
 // Run represents a workflow run.
 type Run struct {
-	ID         int64
-	RunNumber  int    // Sequential run number (e.g., 21 for #21)
-	Name       string
-	Status     string // queued, in_progress, completed
-	Conclusion string // success, failure, cancelled
-	Branch     string
-	Event      string // push, pull_request, workflow_dispatch
-	CreatedAt  time.Time
-	Actor      string
-	URL        string
+	ID         int64     `json:"id"`
+	RunNumber  int       `json:"run_number"` // Sequential run number (e.g., 21 for #21)
+	Name       string    `json:"name"`
+	Status     string    `json:"status"`     // queued, in_progress, completed
+	Conclusion string    `json:"conclusion"` // success, failure, cancelled
+	Branch     string    `json:"branch"`
+	HeadSHA    string    `json:"head_sha"`
+	Event      string    `json:"event"` // push, pull_request, workflow_dispatch
+	CreatedAt  time.Time `json:"created_at"`
+	Actor      string    `json:"actor"`
+	URL        string    `json:"url"`
 }
 
 // IsRunning returns true if the run is in progress or queued.
@@ -42,11 +49,11 @@ func (r Run) IsFailed() bool {
 
 // Job represents a job within a workflow run.
 type Job struct {
-	ID         int64
-	Name       string
-	Status     string // queued, in_progress, completed
-	Conclusion string // success, failure, cancelled
-	Steps      []Step
+	ID         int64  `json:"id"`
+	Name       string `json:"name"`
+	Status     string `json:"status"`     // queued, in_progress, completed
+	Conclusion string `json:"conclusion"` // success, failure, cancelled
+	Steps      []Step `json:"steps"`
 }
 
 // IsCompleted returns true if the job has completed.
@@ -54,6 +61,11 @@ func (j Job) IsCompleted() bool {
 	return j.Status == "completed"
 }
 
+// IsRunning returns true if the job is in progress or queued.
+func (j Job) IsRunning() bool {
+	return j.Status == "in_progress" || j.Status == "queued"
+}
+
 // IsQueued returns true if the job is queued.
 func (j Job) IsQueued() bool {
 	return j.Status == "queued"
@@ -61,10 +73,23 @@ func (j Job) IsQueued() bool {
 
 // Step represents a step within a job.
 type Step struct {
-	Name       string
-	Status     string // queued, in_progress, completed
-	Conclusion string // success, failure, skipped
-	Number     int
+	Name       string `json:"name"`
+	Status     string `json:"status"`     // queued, in_progress, completed
+	Conclusion string `json:"conclusion"` // success, failure, skipped
+	Number     int    `json:"number"`
+}
+
+// List is the result of a paginated list endpoint.
+// Total is the number of items reported by the API; it exceeds len(Items)
+// when the page bound was reached before the last page.
+type List[T any] struct {
+	Items []T
+	Total int
+}
+
+// Truncated returns true if the API reported more items than were fetched.
+func (l List[T]) Truncated() bool {
+	return l.Total > len(l.Items)
 }
 
 // ListRunsOpts represents options for listing workflow runs.
@@ -72,6 +97,10 @@ type ListRunsOpts struct {
 	WorkflowID int64
 	Branch     string
 	Event      string
-	Status     string
+	Status     string // a status or a conclusion
+	Actor      string // login of the user who triggered the run
+	Created    string // date or range in GitHub search syntax, e.g. ">=2024-01-01" or "2024-01-01..2024-01-31"
+	HeadSHA    string
+	Page       int // 1-based; 0 means the first page
 	PerPage    int
 }
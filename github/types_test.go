@@ -53,6 +53,36 @@ func TestRun_IsFailed(t *testing.T) {
 	}
 }
 
+func TestJob_IsRunning(t *testing.T) {
+	tests := []struct {
+		name   string
+		status string
+		want   bool
+	}{
+		{name: "queued", status: "queued", want: true},
+		{name: "in_progress", status: "in_progress", want: true},
+		{name: "completed", status: "completed", want: false},
+		{name: "empty", status: "", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := Job{Status: tt.status}
+			got := j.IsRunning()
+			if got != tt.want {
+				t.Errorf("Job.IsRunning() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRepository_FullName(t *testing.T) {
+	r := Repository{Owner: "owner", Name: "repo"}
+	if got := r.FullName(); got != "owner/repo" {
+		t.Errorf("Repository.FullName() = %q, want %q", got, "owner/repo")
+	}
+}
+
 func TestWorkflow_Fields(t *testing.T) {
 	w := Workflow{
 		ID:    12345,
@@ -205,3 +235,23 @@ func TestListRunsOpts_Fields(t *testing.T) {
 		t.Errorf("ListRunsOpts.PerPage = %v, want 50", opts.PerPage)
 	}
 }
+
+func TestList_Truncated(t *testing.T) {
+	tests := []struct {
+		name string
+		list List[Job]
+		want bool
+	}{
+		{name: "complete", list: List[Job]{Items: []Job{{ID: 1}}, Total: 1}, want: false},
+		{name: "truncated", list: List[Job]{Items: []Job{{ID: 1}}, Total: 240}, want: true},
+		{name: "empty", list: List[Job]{}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.list.Truncated(); got != tt.want {
+				t.Errorf("List.Truncated() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
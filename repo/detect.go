@@ -7,6 +7,7 @@ import (
 	neturl "net/url"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 
 	"github.com/nnnkkk7/lazyactions/github"
@@ -18,81 +19,281 @@ var ErrNotGitRepository = errors.New("not a git repository")
 // ErrNotGitHubRepository is returned when the remote URL is not a GitHub repository.
 var ErrNotGitHubRepository = errors.New("not a GitHub repository")
 
+// DefaultRemote is the remote read by Detect and DetectFromPath.
+const DefaultRemote = "origin"
+
 // Detect detects the GitHub repository from the current directory.
-// It reads the git remote origin URL and parses it to extract owner and repo name.
+// It reads the git remote origin URL and parses it to extract host, owner and repo name.
 // Works from any subdirectory within a git repository.
 func Detect() (*github.Repository, error) {
-	// Check if we're in a git repository by running git rev-parse
-	cmd := exec.Command("git", "rev-parse", "--git-dir")
-	if err := cmd.Run(); err != nil {
-		return nil, ErrNotGitRepository
+	return DetectRemote(".", DefaultRemote)
+}
+
+// DetectFromPath detects the GitHub repository from a specific path.
+// The path may be any directory inside the working tree.
+func DetectFromPath(path string) (*github.Repository, error) {
+	return DetectRemote(path, DefaultRemote)
+}
+
+// DetectRemote detects the GitHub repository from the URL of the named remote
+// of the git repository at path. The working directory is left unchanged.
+func DetectRemote(path, remote string) (*github.Repository, error) {
+	if err := checkGitRepository(path); err != nil {
+		return nil, err
 	}
 
-	// Get the remote origin URL
-	cmd = exec.Command("git", "remote", "get-url", "origin")
-	out, err := cmd.Output()
+	// Get the remote URL
+	out, err := git(path, "remote", "get-url", remote).Output()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get remote URL: %w", err)
+		return nil, fmt.Errorf("failed to get remote URL of %q: %w", remote, err)
 	}
 
 	// Parse the URL and return the repository
 	return parseGitHubURL(strings.TrimSpace(string(out)))
 }
 
-// parseGitHubURL parses a GitHub URL and extracts the owner and repository name.
-// Supported formats:
+// UpstreamRemote is the conventional name of the remote a fork was cloned from.
+const UpstreamRemote = "upstream"
+
+// Remote is a git remote that points to a GitHub repository.
+type Remote struct {
+	Name string
+	Repo github.Repository
+}
+
+// ListRemotes returns the remotes of the git repository at path that point to
+// GitHub, in the order git lists them. Remotes on other hosts are skipped.
+func ListRemotes(path string) ([]Remote, error) {
+	if err := checkGitRepository(path); err != nil {
+		return nil, err
+	}
+
+	// Exits with status 1 when no remote is configured
+	out, err := git(path, "config", "--get-regexp", `^remote\..*\.url$`).Output()
+	if err != nil && len(out) > 0 {
+		return nil, fmt.Errorf("failed to list remotes: %w", err)
+	}
+
+	var remotes []Remote
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		key, url, ok := strings.Cut(line, " ")
+		if !ok {
+			continue
+		}
+		name := strings.TrimSuffix(strings.TrimPrefix(key, "remote."), ".url")
+		repo, err := parseGitHubURL(strings.TrimSpace(url))
+		if err != nil {
+			continue
+		}
+		remotes = append(remotes, Remote{Name: name, Repo: *repo})
+	}
+	return remotes, nil
+}
+
+// PreferredRemote returns the remote whose repository CI is expected to run on:
+// upstream when origin is a fork of a repository on the same host, otherwise
+// origin, otherwise the first remote. isFork is only called when both origin
+// and upstream exist.
+func PreferredRemote(remotes []Remote, isFork func(github.Repository) bool) (Remote, bool) {
+	if len(remotes) == 0 {
+		return Remote{}, false
+	}
+	origin, hasOrigin := findRemote(remotes, DefaultRemote)
+	if !hasOrigin {
+		return remotes[0], true
+	}
+	upstream, hasUpstream := findRemote(remotes, UpstreamRemote)
+	if hasUpstream && upstream.Repo.Host == origin.Repo.Host && upstream.Repo != origin.Repo && isFork(origin.Repo) {
+		return upstream, true
+	}
+	return origin, true
+}
+
+// findRemote returns the remote with the given name
+func findRemote(remotes []Remote, name string) (Remote, bool) {
+	for _, r := range remotes {
+		if r.Name == name {
+			return r, true
+		}
+	}
+	return Remote{}, false
+}
+
+// Discover detects the GitHub repositories of the git checkouts directly
+// inside dir, in directory order. Subdirectories that are not git checkouts
+// or have no GitHub origin are skipped.
+func Discover(dir string) ([]github.Repository, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read directory %s: %w", dir, err)
+	}
+
+	var repos []github.Repository
+	for _, e := range entries {
+		path := filepath.Join(dir, e.Name())
+		// Check for .git first to avoid running git in every directory
+		if _, err := os.Stat(filepath.Join(path, ".git")); err != nil {
+			continue
+		}
+		repo, err := DetectFromPath(path)
+		if err != nil {
+			continue
+		}
+		repos = append(repos, *repo)
+	}
+	return repos, nil
+}
+
+// CurrentBranch returns the branch checked out in the repository at path.
+// It returns an empty string when HEAD is detached.
+func CurrentBranch(path string) (string, error) {
+	if err := checkGitRepository(path); err != nil {
+		return "", err
+	}
+
+	// symbolic-ref also works on an unborn branch and fails quietly on a detached HEAD
+	out, err := git(path, "symbolic-ref", "--quiet", "--short", "HEAD").Output()
+	if err != nil {
+		return "", nil
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// HeadSHA returns the commit SHA checked out in the repository at path.
+func HeadSHA(path string) (string, error) {
+	out, err := git(path, "rev-parse", "HEAD").Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve HEAD: %w", err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// Root returns the top-level directory of the working tree at path.
+func Root(path string) (string, error) {
+	if err := checkGitRepository(path); err != nil {
+		return "", err
+	}
+	out, err := git(path, "rev-parse", "--show-toplevel").Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to find the working tree root: %w", err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// ParseRepository parses a repository given as "owner/name" or "host/owner/name",
+// as accepted by the --repo flag. The host defaults to github.com.
+func ParseRepository(s string) (*github.Repository, error) {
+	parts := strings.Split(strings.TrimSuffix(s, ".git"), "/")
+	host := github.DefaultHost
+	if len(parts) == 3 {
+		host = strings.ToLower(parts[0])
+		parts = parts[1:]
+	}
+	if len(parts) != 2 || host == "" || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("invalid repository %q: expected [HOST/]OWNER/NAME", s)
+	}
+	return &github.Repository{Host: host, Owner: parts[0], Name: parts[1]}, nil
+}
+
+// checkGitRepository returns an error if path is not inside a git repository.
+func checkGitRepository(path string) error {
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("failed to access %s: %w", path, err)
+	}
+	if err := git(path, "rev-parse", "--git-dir").Run(); err != nil {
+		return ErrNotGitRepository
+	}
+	return nil
+}
+
+// git returns a git command that runs in the directory at path.
+func git(path string, args ...string) *exec.Cmd {
+	return exec.Command("git", append([]string{"-C", path}, args...)...)
+}
+
+// nonGitHubHosts are well-known hosting services that are never GitHub.
+// Any other host is assumed to be GitHub Enterprise Server.
+var nonGitHubHosts = map[string]bool{
+	"gitlab.com":        true,
+	"bitbucket.org":     true,
+	"codeberg.org":      true,
+	"dev.azure.com":     true,
+	"ssh.dev.azure.com": true,
+}
+
+// sshSchemes are the URL schemes of SSH remotes. Their port is the SSH port,
+// so it is not part of the repository host.
+var sshSchemes = map[string]bool{
+	"ssh":     true,
+	"git+ssh": true,
+	"ssh+git": true,
+}
+
+// parseGitHubURL parses a GitHub URL and extracts the host, owner and repository name.
+// Supported formats, on github.com or a GitHub Enterprise Server host:
 //   - SSH: git@github.com:owner/repo.git
+//   - SSH URL: ssh://git@github.com/owner/repo.git (also git+ssh:// and ssh+git://)
 //   - HTTPS: https://github.com/owner/repo.git
 //   - HTTP: http://github.com/owner/repo.git
 //
 // The .git suffix is optional in all formats.
 func parseGitHubURL(url string) (*github.Repository, error) {
 	// SSH format: git@github.com:owner/repo.git
-	if strings.HasPrefix(url, "git@github.com:") {
-		path := strings.TrimPrefix(url, "git@github.com:")
-		path = strings.TrimSuffix(path, ".git")
-		parts := strings.Split(path, "/")
-		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+	if host, path, ok := splitSCPLikeURL(url); ok {
+		if nonGitHubHosts[host] {
+			return nil, fmt.Errorf("%w: %s", ErrNotGitHubRepository, url)
+		}
+		owner, name, ok := splitRepoPath(path)
+		if !ok {
 			return nil, fmt.Errorf("invalid GitHub SSH URL: %s", url)
 		}
-		return &github.Repository{Owner: parts[0], Name: parts[1]}, nil
+		return &github.Repository{Host: host, Owner: owner, Name: name}, nil
 	}
 
-	// HTTPS/HTTP format: https://github.com/owner/repo.git
-	if strings.Contains(url, "github.com") {
-		u, err := neturl.Parse(url)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse URL: %w", err)
-		}
-		path := strings.TrimPrefix(u.Path, "/")
-		path = strings.TrimSuffix(path, ".git")
-		parts := strings.Split(path, "/")
-		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
-			return nil, fmt.Errorf("invalid GitHub HTTPS URL: %s", url)
-		}
-		return &github.Repository{Owner: parts[0], Name: parts[1]}, nil
+	scheme, _, ok := strings.Cut(url, "://")
+	scheme = strings.ToLower(scheme)
+	if !ok || (scheme != "https" && scheme != "http" && !sshSchemes[scheme]) {
+		return nil, fmt.Errorf("%w: %s", ErrNotGitHubRepository, url)
 	}
 
-	return nil, fmt.Errorf("%w: %s", ErrNotGitHubRepository, url)
-}
-
-// DetectFromPath detects the GitHub repository from a specific path.
-// It changes to the specified directory, detects the repository, then returns.
-func DetectFromPath(path string) (*github.Repository, error) {
-	// Save current directory
-	origDir, err := os.Getwd()
+	// URL formats: https://github.com/owner/repo.git, ssh://git@github.com/owner/repo.git
+	u, err := neturl.Parse(url)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get current directory: %w", err)
+		return nil, fmt.Errorf("failed to parse URL: %w", err)
 	}
-
-	// Change to the specified path
-	if err := os.Chdir(path); err != nil {
-		return nil, fmt.Errorf("failed to change to directory %s: %w", path, err)
+	host, kind := strings.ToLower(u.Host), "HTTPS"
+	if sshSchemes[scheme] {
+		host, kind = strings.ToLower(u.Hostname()), "SSH"
+	}
+	if host == "" || nonGitHubHosts[host] {
+		return nil, fmt.Errorf("%w: %s", ErrNotGitHubRepository, url)
+	}
+	owner, name, ok := splitRepoPath(strings.TrimPrefix(u.Path, "/"))
+	if !ok {
+		return nil, fmt.Errorf("invalid GitHub %s URL: %s", kind, url)
 	}
+	return &github.Repository{Host: host, Owner: owner, Name: name}, nil
+}
 
-	// Ensure we return to the original directory
-	defer func() { _ = os.Chdir(origDir) }()
+// splitSCPLikeURL splits an scp-like SSH URL (user@host:path) into its host and path.
+func splitSCPLikeURL(url string) (host, path string, ok bool) {
+	if strings.Contains(url, "://") {
+		return "", "", false
+	}
+	at := strings.Index(url, "@")
+	colon := strings.Index(url, ":")
+	if at < 0 || colon < at {
+		return "", "", false
+	}
+	return strings.ToLower(url[at+1 : colon]), url[colon+1:], true
+}
 
-	// Detect the repository
-	return Detect()
+// splitRepoPath splits an "owner/repo[.git]" path into owner and repository name.
+func splitRepoPath(path string) (owner, name string, ok bool) {
+	path = strings.TrimSuffix(path, ".git")
+	parts := strings.Split(path, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
 }
@@ -1,9 +1,11 @@
 package repo
 
 import (
+	"errors"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/nnnkkk7/lazyactions/github"
@@ -103,6 +105,67 @@ func TestParseGitHubURL(t *testing.T) {
 			wantErr: true,
 			errMsg:  "invalid GitHub HTTPS URL",
 		},
+		// GitHub Enterprise Server URLs
+		{
+			name:     "Enterprise SSH format",
+			url:      "git@ghe.example.com:owner/repo.git",
+			expected: &github.Repository{Host: "ghe.example.com", Owner: "owner", Name: "repo"},
+		},
+		{
+			name:     "Enterprise HTTPS format",
+			url:      "https://ghe.example.com/owner/repo.git",
+			expected: &github.Repository{Host: "ghe.example.com", Owner: "owner", Name: "repo"},
+		},
+		{
+			name:     "Enterprise HTTPS format with port",
+			url:      "https://GHE.example.com:8443/owner/repo",
+			expected: &github.Repository{Host: "ghe.example.com:8443", Owner: "owner", Name: "repo"},
+		},
+		{
+			name:    "Enterprise SSH format invalid - no repo",
+			url:     "git@ghe.example.com:owner",
+			wantErr: true,
+			errMsg:  "invalid GitHub SSH URL",
+		},
+		// SSH URL formats
+		{
+			name:     "SSH URL format",
+			url:      "ssh://git@github.com/owner/repo.git",
+			expected: &github.Repository{Owner: "owner", Name: "repo"},
+		},
+		{
+			name:     "SSH URL format with port",
+			url:      "ssh://git@ghe.example.com:2222/owner/repo",
+			expected: &github.Repository{Host: "ghe.example.com", Owner: "owner", Name: "repo"},
+		},
+		{
+			name:     "git+ssh URL format",
+			url:      "git+ssh://git@github.com/owner/repo.git",
+			expected: &github.Repository{Owner: "owner", Name: "repo"},
+		},
+		{
+			name:     "ssh+git URL format",
+			url:      "ssh+git://git@github.com/owner/repo.git",
+			expected: &github.Repository{Owner: "owner", Name: "repo"},
+		},
+		{
+			name:    "SSH URL format invalid - no repo",
+			url:     "ssh://git@github.com/owner",
+			wantErr: true,
+			errMsg:  "invalid GitHub SSH URL",
+		},
+		{
+			name:    "SSH URL format - GitLab",
+			url:     "ssh://git@gitlab.com/owner/repo.git",
+			wantErr: true,
+			errMsg:  "not a GitHub repository",
+		},
+		{
+			name:    "Unsupported scheme",
+			url:     "ftp://github.com/owner/repo.git",
+			wantErr: true,
+			errMsg:  "not a GitHub repository",
+		},
 		// Non-GitHub URLs
 		{
 			name:    "Non-GitHub URL - GitLab",
@@ -150,6 +213,13 @@ func TestParseGitHubURL(t *testing.T) {
 				return
 			}
 
+			wantHost := tt.expected.Host
+			if wantHost == "" {
+				wantHost = github.DefaultHost
+			}
+			if result.Host != wantHost {
+				t.Errorf("parseGitHubURL(%q) Host = %q, want %q", tt.url, result.Host, wantHost)
+			}
 			if result.Owner != tt.expected.Owner {
 				t.Errorf("parseGitHubURL(%q) Owner = %q, want %q", tt.url, result.Owner, tt.expected.Owner)
 			}
@@ -413,6 +483,311 @@ func TestDetectFromPath(t *testing.T) {
 	})
 }
 
+func TestDetectRemote(t *testing.T) {
+	tmpDir := t.TempDir()
+	cmds := [][]string{
+		{"git", "-C", tmpDir, "init"},
+		{"git", "-C", tmpDir, "remote", "add", "origin", "git@github.com:fork/repo.git"},
+		{"git", "-C", tmpDir, "remote", "add", "upstream", "https://github.com/upstream/repo.git"},
+	}
+	for _, args := range cmds {
+		if err := exec.Command(args[0], args[1:]...).Run(); err != nil {
+			t.Fatalf("Failed to run %v: %v", args, err)
+		}
+	}
+
+	t.Run("named remote", func(t *testing.T) {
+		result, err := DetectRemote(tmpDir, "upstream")
+		if err != nil {
+			t.Fatalf("DetectRemote() unexpected error: %v", err)
+		}
+		if result.Owner != "upstream" || result.Name != "repo" {
+			t.Errorf("DetectRemote() = %s/%s, want upstream/repo", result.Owner, result.Name)
+		}
+	})
+
+	t.Run("from a subdirectory", func(t *testing.T) {
+		subDir := filepath.Join(tmpDir, "sub", "dir")
+		if err := os.MkdirAll(subDir, 0755); err != nil {
+			t.Fatalf("Failed to create subdirectory: %v", err)
+		}
+		result, err := DetectRemote(subDir, DefaultRemote)
+		if err != nil {
+			t.Fatalf("DetectRemote() unexpected error: %v", err)
+		}
+		if result.Owner != "fork" {
+			t.Errorf("DetectRemote() Owner = %q, want %q", result.Owner, "fork")
+		}
+	})
+
+	t.Run("unknown remote", func(t *testing.T) {
+		_, err := DetectRemote(tmpDir, "missing")
+		if err == nil || !contains(err.Error(), `remote URL of "missing"`) {
+			t.Errorf("DetectRemote() error = %v, want error naming the remote", err)
+		}
+	})
+}
+
+func TestListRemotes(t *testing.T) {
+	t.Run("GitHub remotes in git order", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		cmds := [][]string{
+			{"git", "-C", tmpDir, "init"},
+			{"git", "-C", tmpDir, "remote", "add", "origin", "git@github.com:fork/repo.git"},
+			{"git", "-C", tmpDir, "remote", "add", "upstream", "ssh://git@github.com/upstream/repo.git"},
+			{"git", "-C", tmpDir, "remote", "add", "mirror", "https://gitlab.com/mirror/repo.git"},
+			{"git", "-C", tmpDir, "remote", "add", "my.remote", "https://github.com/other/repo"},
+		}
+		for _, args := range cmds {
+			if err := exec.Command(args[0], args[1:]...).Run(); err != nil {
+				t.Fatalf("Failed to run %v: %v", args, err)
+			}
+		}
+
+		remotes, err := ListRemotes(tmpDir)
+		if err != nil {
+			t.Fatalf("ListRemotes() unexpected error: %v", err)
+		}
+		want := []string{"origin:fork/repo", "upstream:upstream/repo", "my.remote:other/repo"}
+		if len(remotes) != len(want) {
+			t.Fatalf("ListRemotes() = %+v, want %v", remotes, want)
+		}
+		for i, r := range remotes {
+			if got := r.Name + ":" + r.Repo.FullName(); got != want[i] {
+				t.Errorf("remotes[%d] = %q, want %q", i, got, want[i])
+			}
+		}
+	})
+
+	t.Run("no remotes", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		if err := exec.Command("git", "-C", tmpDir, "init").Run(); err != nil {
+			t.Fatalf("Failed to initialize git repo: %v", err)
+		}
+		remotes, err := ListRemotes(tmpDir)
+		if err != nil || len(remotes) != 0 {
+			t.Errorf("ListRemotes() = %+v, %v, want no remotes", remotes, err)
+		}
+	})
+
+	t.Run("not a git repository", func(t *testing.T) {
+		if _, err := ListRemotes(t.TempDir()); err != ErrNotGitRepository {
+			t.Errorf("ListRemotes() error = %v, want ErrNotGitRepository", err)
+		}
+	})
+}
+
+func TestPreferredRemote(t *testing.T) {
+	origin := Remote{Name: "origin", Repo: github.Repository{Host: "github.com", Owner: "fork", Name: "repo"}}
+	upstream := Remote{Name: "upstream", Repo: github.Repository{Host: "github.com", Owner: "upstream", Name: "repo"}}
+	other := Remote{Name: "other", Repo: github.Repository{Host: "github.com", Owner: "other", Name: "repo"}}
+	enterprise := Remote{Name: "upstream", Repo: github.Repository{Host: "ghe.example.com", Owner: "upstream", Name: "repo"}}
+
+	tests := []struct {
+		name       string
+		remotes    []Remote
+		fork       bool
+		want       string
+		wantForked bool // whether isFork should be called
+	}{
+		{name: "origin is a fork", remotes: []Remote{origin, upstream}, fork: true, want: "upstream", wantForked: true},
+		{name: "origin is not a fork", remotes: []Remote{upstream, origin}, fork: false, want: "origin", wantForked: true},
+		{name: "no upstream", remotes: []Remote{other, origin}, fork: true, want: "origin"},
+		{name: "upstream on another host", remotes: []Remote{origin, enterprise}, fork: true, want: "origin"},
+		{name: "no origin", remotes: []Remote{other, upstream}, fork: true, want: "other"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			got, ok := PreferredRemote(tt.remotes, func(github.Repository) bool {
+				called = true
+				return tt.fork
+			})
+			if !ok || got.Name != tt.want {
+				t.Errorf("PreferredRemote() = %q, %v, want %q", got.Name, ok, tt.want)
+			}
+			if called != tt.wantForked {
+				t.Errorf("isFork called = %v, want %v", called, tt.wantForked)
+			}
+		})
+	}
+
+	t.Run("no remotes", func(t *testing.T) {
+		if _, ok := PreferredRemote(nil, nil); ok {
+			t.Error("PreferredRemote(nil) should report no remote")
+		}
+	})
+}
+
+func TestDiscover(t *testing.T) {
+	dir := t.TempDir()
+	checkouts := map[string]string{
+		"api":    "git@github.com:team/api.git",
+		"web":    "https://github.com/team/web",
+		"gitlab": "git@gitlab.com:team/other.git",
+	}
+	for name, url := range checkouts {
+		path := filepath.Join(dir, name)
+		cmds := [][]string{
+			{"git", "init", path},
+			{"git", "-C", path, "remote", "add", "origin", url},
+		}
+		for _, args := range cmds {
+			if err := exec.Command(args[0], args[1:]...).Run(); err != nil {
+				t.Fatalf("Failed to run %v: %v", args, err)
+			}
+		}
+	}
+	if err := os.Mkdir(filepath.Join(dir, "notes"), 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "README"), nil, 0644); err != nil {
+		t.Fatalf("Failed to create file: %v", err)
+	}
+
+	repos, err := Discover(dir)
+	if err != nil {
+		t.Fatalf("Discover() unexpected error: %v", err)
+	}
+	var got []string
+	for _, r := range repos {
+		got = append(got, r.FullName())
+	}
+	if strings.Join(got, ",") != "team/api,team/web" {
+		t.Errorf("Discover() = %v, want [team/api team/web]", got)
+	}
+
+	if _, err := Discover(filepath.Join(dir, "missing")); err == nil {
+		t.Error("Discover() expected error for a missing directory")
+	}
+}
+
+func TestParseRepository(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    *github.Repository
+		wantErr bool
+	}{
+		{input: "owner/repo", want: &github.Repository{Host: "github.com", Owner: "owner", Name: "repo"}},
+		{input: "owner/repo.git", want: &github.Repository{Host: "github.com", Owner: "owner", Name: "repo"}},
+		{input: "GHE.example.com/owner/repo", want: &github.Repository{Host: "ghe.example.com", Owner: "owner", Name: "repo"}},
+		{input: "repo", wantErr: true},
+		{input: "owner/", wantErr: true},
+		{input: "/owner/repo", wantErr: true},
+		{input: "a/b/c/d", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseRepository(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ParseRepository(%q) expected error, got %+v", tt.input, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseRepository(%q) unexpected error: %v", tt.input, err)
+			}
+			if *got != *tt.want {
+				t.Errorf("ParseRepository(%q) = %+v, want %+v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCurrentBranch(t *testing.T) {
+	t.Run("not a git repository", func(t *testing.T) {
+		if _, err := CurrentBranch(t.TempDir()); err != ErrNotGitRepository {
+			t.Errorf("CurrentBranch() error = %v, want ErrNotGitRepository", err)
+		}
+	})
+
+	t.Run("unborn branch", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		if err := exec.Command("git", "-C", tmpDir, "init", "--initial-branch", "develop").Run(); err != nil {
+			t.Fatalf("Failed to initialize git repo: %v", err)
+		}
+
+		branch, err := CurrentBranch(tmpDir)
+		if err != nil {
+			t.Fatalf("CurrentBranch() unexpected error: %v", err)
+		}
+		if branch != "develop" {
+			t.Errorf("CurrentBranch() = %q, want %q", branch, "develop")
+		}
+	})
+
+	t.Run("detached HEAD", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		cmds := [][]string{
+			{"git", "-C", tmpDir, "init"},
+			{"git", "-C", tmpDir, "-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "--allow-empty", "-m", "init"},
+			{"git", "-C", tmpDir, "checkout", "--detach"},
+		}
+		for _, args := range cmds {
+			if err := exec.Command(args[0], args[1:]...).Run(); err != nil {
+				t.Fatalf("Failed to run %v: %v", args, err)
+			}
+		}
+
+		branch, err := CurrentBranch(tmpDir)
+		if err != nil {
+			t.Fatalf("CurrentBranch() unexpected error: %v", err)
+		}
+		if branch != "" {
+			t.Errorf("CurrentBranch() = %q, want empty for detached HEAD", branch)
+		}
+	})
+}
+
+func TestHeadSHA(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := exec.Command("git", "-C", tmpDir, "init").Run(); err != nil {
+		t.Fatalf("Failed to initialize git repo: %v", err)
+	}
+
+	if _, err := HeadSHA(tmpDir); err == nil {
+		t.Error("HeadSHA() expected error without commits, got nil")
+	}
+
+	commit := exec.Command("git", "-C", tmpDir, "-c", "user.name=test", "-c", "user.email=test@example.com", "commit", "--allow-empty", "-m", "init")
+	if err := commit.Run(); err != nil {
+		t.Fatalf("Failed to commit: %v", err)
+	}
+	sha, err := HeadSHA(tmpDir)
+	if err != nil {
+		t.Fatalf("HeadSHA() unexpected error: %v", err)
+	}
+	if len(sha) != 40 {
+		t.Errorf("HeadSHA() = %q, want a 40 character SHA", sha)
+	}
+}
+
+func TestRoot(t *testing.T) {
+	tmpDir := t.TempDir()
+	if _, err := Root(tmpDir); !errors.Is(err, ErrNotGitRepository) {
+		t.Errorf("Root() error = %v, want ErrNotGitRepository", err)
+	}
+
+	if err := exec.Command("git", "-C", tmpDir, "init").Run(); err != nil {
+		t.Fatalf("Failed to initialize git repo: %v", err)
+	}
+	sub := filepath.Join(tmpDir, "a", "b")
+	if err := os.MkdirAll(sub, 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+	root, err := Root(sub)
+	if err != nil {
+		t.Fatalf("Root() unexpected error: %v", err)
+	}
+	want, _ := filepath.EvalSymlinks(tmpDir)
+	if got, _ := filepath.EvalSymlinks(root); got != want {
+		t.Errorf("Root() = %q, want %q", root, want)
+	}
+}
+
 // contains checks if s contains substr (helper function)
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(s) > 0 && containsHelper(s, substr))
@@ -0,0 +1,189 @@
+// Package state persists UI state across sessions.
+// State is stored as JSON under $XDG_STATE_HOME/lazyactions (~/.local/state/lazyactions by default).
+package state
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// fileName is the name of the state file inside the state directory.
+const fileName = "state.json"
+
+// State holds UI state that is remembered between sessions.
+// A State with an empty path lives only in memory and Save is a no-op.
+type State struct {
+	mu   sync.RWMutex
+	path string
+
+	// LastRefs maps a workflow key to the ref it was last dispatched on
+	LastRefs map[string]string `json:"last_refs,omitempty"`
+
+	// PaneLayout is the arrangement of the panes last chosen
+	PaneLayout *Layout `json:"layout,omitempty"`
+
+	// LogNoWrap is whether the log viewer scrolls long lines horizontally
+	// rather than wrapping them
+	LogNoWrap bool `json:"log_no_wrap,omitempty"`
+
+	// LogLineNumbers is whether the log viewer numbers the log lines
+	LogLineNumbers bool `json:"log_line_numbers,omitempty"`
+}
+
+// Layout is the arrangement of the panes chosen by the user.
+// Zero values leave the defaults.
+type Layout struct {
+	// Mode is the name of the layout
+	Mode string `json:"mode,omitempty"`
+	// SidebarWidth is the share of the width taken by the sidebar
+	SidebarWidth float64 `json:"sidebar_width,omitempty"`
+	// StackedHeight is the share of the height taken by the panes when they
+	// are stacked above the detail panel
+	StackedHeight float64 `json:"stacked_height,omitempty"`
+	// PaneHeights are the relative heights of the panes by name
+	PaneHeights map[string]float64 `json:"pane_heights,omitempty"`
+}
+
+// New creates an empty in-memory State.
+func New() *State {
+	return &State{LastRefs: map[string]string{}}
+}
+
+// DefaultPath returns the default state file path.
+// It honors $XDG_STATE_HOME and falls back to ~/.local/state.
+func DefaultPath() (string, error) {
+	dir := os.Getenv("XDG_STATE_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to get home directory: %w", err)
+		}
+		dir = filepath.Join(home, ".local", "state")
+	}
+	return filepath.Join(dir, "lazyactions", fileName), nil
+}
+
+// Load reads the state file at path.
+// A missing file yields an empty State that will be written to path on Save.
+func Load(path string) (*State, error) {
+	s := New()
+	s.path = path
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return s, fmt.Errorf("failed to read state file: %w", err)
+	}
+	if err := json.Unmarshal(data, s); err != nil {
+		return s, fmt.Errorf("failed to parse state file %s: %w", path, err)
+	}
+	if s.LastRefs == nil {
+		s.LastRefs = map[string]string{}
+	}
+	return s, nil
+}
+
+// Save writes the state to its file, creating the directory if needed.
+// The file is replaced atomically so a crash never leaves it half-written.
+func (s *State) Save() error {
+	if s.path == "" {
+		return nil
+	}
+
+	s.mu.RLock()
+	data, err := json.MarshalIndent(s, "", "  ")
+	s.mu.RUnlock()
+	if err != nil {
+		return fmt.Errorf("failed to encode state: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return fmt.Errorf("failed to create state directory: %w", err)
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+	return nil
+}
+
+// LastRef returns the ref last used for key, or "" if none was recorded.
+func (s *State) LastRef(key string) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.LastRefs[key]
+}
+
+// SetLastRef records ref as the last ref used for key.
+func (s *State) SetLastRef(key, ref string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.LastRefs[key] = ref
+}
+
+// Layout returns the layout last chosen, or the zero Layout if none was recorded.
+func (s *State) Layout() Layout {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if s.PaneLayout == nil {
+		return Layout{}
+	}
+	l := *s.PaneLayout
+	l.PaneHeights = maps.Clone(l.PaneHeights)
+	return l
+}
+
+// SetLayout records l as the layout last chosen.
+func (s *State) SetLayout(l Layout) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	l.PaneHeights = maps.Clone(l.PaneHeights)
+	s.PaneLayout = &l
+}
+
+// LogWrap reports whether the log viewer wraps long lines, the default.
+func (s *State) LogWrap() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return !s.LogNoWrap
+}
+
+// SetLogWrap records whether the log viewer wraps long lines.
+func (s *State) SetLogWrap(wrap bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.LogNoWrap = !wrap
+}
+
+// ShowLogLineNumbers reports whether the log viewer numbers the log lines.
+func (s *State) ShowLogLineNumbers() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.LogLineNumbers
+}
+
+// SetShowLogLineNumbers records whether the log viewer numbers the log lines.
+func (s *State) SetShowLogLineNumbers(show bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.LogLineNumbers = show
+}
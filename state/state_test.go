@@ -0,0 +1,156 @@
+package state
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDefaultPath_XDGStateHome(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", "/tmp/xdg-state")
+
+	path, err := DefaultPath()
+	if err != nil {
+		t.Fatalf("DefaultPath() error = %v", err)
+	}
+	want := filepath.Join("/tmp/xdg-state", "lazyactions", "state.json")
+	if path != want {
+		t.Errorf("DefaultPath() = %q, want %q", path, want)
+	}
+}
+
+func TestDefaultPath_HomeFallback(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", "")
+	t.Setenv("HOME", "/tmp/home")
+
+	path, err := DefaultPath()
+	if err != nil {
+		t.Fatalf("DefaultPath() error = %v", err)
+	}
+	want := filepath.Join("/tmp/home", ".local", "state", "lazyactions", "state.json")
+	if path != want {
+		t.Errorf("DefaultPath() = %q, want %q", path, want)
+	}
+}
+
+func TestLoad_MissingFile(t *testing.T) {
+	s, err := Load(filepath.Join(t.TempDir(), "state.json"))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if got := s.LastRef("any"); got != "" {
+		t.Errorf("LastRef() = %q, want empty", got)
+	}
+}
+
+func TestSaveAndLoad_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "state.json")
+
+	s, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	s.SetLastRef("owner/repo:.github/workflows/ci.yml", "develop")
+	if err := s.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() after Save() error = %v", err)
+	}
+	if got := loaded.LastRef("owner/repo:.github/workflows/ci.yml"); got != "develop" {
+		t.Errorf("LastRef() = %q, want develop", got)
+	}
+}
+
+func TestLoad_CorruptFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := Load(path)
+	if err == nil {
+		t.Error("Load() with corrupt file should return error")
+	}
+	if s == nil {
+		t.Fatal("Load() should return a usable empty state on error")
+	}
+	s.SetLastRef("k", "v")
+	if got := s.LastRef("k"); got != "v" {
+		t.Errorf("LastRef() = %q, want v", got)
+	}
+}
+
+func TestNew_SaveIsNoop(t *testing.T) {
+	s := New()
+	s.SetLastRef("k", "v")
+	if err := s.Save(); err != nil {
+		t.Errorf("Save() on in-memory state error = %v", err)
+	}
+}
+
+func TestLayout_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	s, _ := Load(path)
+	if got := s.Layout(); got.Mode != "" || got.PaneHeights != nil {
+		t.Errorf("Layout() = %+v, want the zero Layout", got)
+	}
+
+	want := Layout{Mode: "stacked", SidebarWidth: 0.4, StackedHeight: 0.6, PaneHeights: map[string]float64{"runs": 2}}
+	s.SetLayout(want)
+	want.PaneHeights["runs"] = 3 // the state keeps its own copy
+	if err := s.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	got := loaded.Layout()
+	if got.Mode != "stacked" || got.SidebarWidth != 0.4 || got.StackedHeight != 0.6 || got.PaneHeights["runs"] != 2 {
+		t.Errorf("Layout() = %+v", got)
+	}
+}
+
+func TestLogWrap_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	s, _ := Load(path)
+	if !s.LogWrap() {
+		t.Error("LogWrap() = false, want wrapping by default")
+	}
+
+	s.SetLogWrap(false)
+	if err := s.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if loaded.LogWrap() {
+		t.Error("LogWrap() = true after saving false")
+	}
+}
+
+func TestShowLogLineNumbers_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	s, _ := Load(path)
+	if s.ShowLogLineNumbers() {
+		t.Error("ShowLogLineNumbers() = true, want no numbers by default")
+	}
+
+	s.SetShowLogLineNumbers(true)
+	if err := s.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if !loaded.ShowLogLineNumbers() {
+		t.Error("ShowLogLineNumbers() = false after saving true")
+	}
+}
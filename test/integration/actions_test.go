@@ -1,6 +1,7 @@
 package integration
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/nnnkkk7/lazyactions/app"
@@ -249,6 +250,68 @@ func TestActions_TriggerWorkflow(t *testing.T) {
 		}
 	})
 
+	t.Run("t on workflow with inputs opens form and dispatches values", func(t *testing.T) {
+		ta := NewTestApp(t,
+			WithMockWorkflows(DefaultTestWorkflows()),
+			WithMockWorkflowFile("on:\n  workflow_dispatch:\n    inputs:\n      env:\n        type: environment\n        required: true\n"),
+			WithMockEnvironments([]string{"staging", "production"}),
+		)
+		ta.SetSize(120, 40)
+		ta.App.Update(app.WorkflowsLoadedMsg{Workflows: DefaultTestWorkflows()})
+
+		ta.ProcessCmdAndUpdate(ta.SendKey("t"))
+		ta.ProcessCmdAndUpdate(ta.SendKey("enter"))
+
+		view := ta.App.View()
+		if !strings.Contains(view, "env *") {
+			t.Fatal("dispatch form should be shown")
+		}
+
+		// Pick the second environment and submit
+		ta.SendKey("right")
+		ta.ProcessCmd(ta.SendKey("enter"))
+
+		calls := ta.Mock().TriggerWorkflowCalls()
+		if len(calls) != 1 {
+			t.Fatalf("TriggerWorkflow calls = %d, want 1", len(calls))
+		}
+		if calls[0].Inputs["env"] != "production" {
+			t.Errorf("env input = %v, want production", calls[0].Inputs["env"])
+		}
+	})
+
+	t.Run("t offers repository refs and dispatches on the chosen ref", func(t *testing.T) {
+		ta := NewTestApp(t,
+			WithMockWorkflows(DefaultTestWorkflows()),
+			WithMockWorkflowFile("on: workflow_dispatch\n"),
+			WithMockRefs("develop", []string{"develop", "feature/a"}, []string{"v1.0.0"}),
+		)
+		ta.SetSize(120, 40)
+		ta.App.Update(app.WorkflowsLoadedMsg{Workflows: DefaultTestWorkflows()})
+
+		ta.ProcessCmdAndUpdate(ta.SendKey("t"))
+
+		view := ta.App.View()
+		for _, ref := range []string{"develop", "feature/a", "v1.0.0"} {
+			if !strings.Contains(view, ref) {
+				t.Errorf("ref picker should list %s", ref)
+			}
+		}
+
+		for _, r := range "v1.0" {
+			ta.SendKey(string(r))
+		}
+		ta.ProcessCmd(ta.ProcessCmdAndUpdate(ta.SendKey("enter")))
+
+		calls := ta.Mock().TriggerWorkflowCalls()
+		if len(calls) != 1 {
+			t.Fatalf("TriggerWorkflow calls = %d, want 1", len(calls))
+		}
+		if calls[0].Ref != "v1.0.0" {
+			t.Errorf("Ref = %q, want v1.0.0", calls[0].Ref)
+		}
+	})
+
 	t.Run("trigger success shows flash message", func(t *testing.T) {
 		ta := NewTestApp(t)
 		ta.SetSize(120, 40)
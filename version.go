@@ -1,3 +1,5 @@
-package main
+// Package lazyactions holds the release version of lazyactions.
+package lazyactions
 
+// Version is the current release, updated by tagpr.
 const Version = "0.0.13"